@@ -0,0 +1,38 @@
+// File attach.go attaches to the session the user jumped to once the TUI has
+// exited outside tmux.
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// attachSession attaches the terminal to session with the tmux binary at bin,
+// or with mode "print" writes the attach command to out instead.
+func attachSession(bin, session, mode string, out io.Writer) error {
+	if mode == "print" {
+		_, err := fmt.Fprintf(out, "tmux attach -t %s\n", shellQuote(session))
+		return err
+	}
+	cmd := exec.Command(bin, "attach-session", "-t", "="+session)
+	cmd.Stdin, cmd.Stdout, cmd.Stderr = os.Stdin, os.Stdout, os.Stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("tmux attach -t %s: %w", session, err)
+	}
+	return nil
+}
+
+// shellQuote single-quotes s unless it is made of characters every shell
+// passes through unchanged.
+func shellQuote(s string) string {
+	safe := s != "" && strings.IndexFunc(s, func(r rune) bool {
+		return !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || strings.ContainsRune("-_./:@%+=", r))
+	}) < 0
+	if safe {
+		return s
+	}
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
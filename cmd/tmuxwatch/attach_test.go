@@ -0,0 +1,30 @@
+// File attach_test.go covers printing the attach command after a jump.
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestAttachSessionPrints writes a shell-safe attach command in print mode.
+func TestAttachSessionPrints(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		session string
+		want    string
+	}{
+		{session: "build", want: "tmux attach -t build\n"},
+		{session: "my notes", want: "tmux attach -t 'my notes'\n"},
+		{session: "it's", want: `tmux attach -t 'it'\''s'` + "\n"},
+	}
+	for _, tc := range tests {
+		var out strings.Builder
+		if err := attachSession("tmux", tc.session, "print", &out); err != nil {
+			t.Fatalf("attachSession returned error: %v", err)
+		}
+		if out.String() != tc.want {
+			t.Fatalf("attachSession(%q) printed %q, want %q", tc.session, out.String(), tc.want)
+		}
+	}
+}
@@ -0,0 +1,60 @@
+// File config.go resolves the effective configuration from the config file and
+// command-line flags, and implements the `config` subcommand.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/steipete/tmuxwatch/internal/config"
+	"github.com/steipete/tmuxwatch/internal/ui"
+)
+
+// loadConfig reads the config file at path (or the default location) and
+// applies explicitly set flags on top so flags always win.
+func loadConfig(path string, interval time.Duration) (config.Config, string, error) {
+	if path == "" {
+		defaultPath, err := config.Path()
+		if err != nil {
+			return config.Config{}, "", err
+		}
+		path = defaultPath
+	}
+	cfg, err := config.Load(path, ui.ValidateKeymap)
+	if err != nil {
+		return config.Config{}, path, err
+	}
+	flag.Visit(func(f *flag.Flag) {
+		if f.Name == "interval" {
+			cfg.PollInterval = config.Duration(interval)
+		}
+	})
+	if err := cfg.Validate(); err != nil {
+		return config.Config{}, path, fmt.Errorf("flags: %w", err)
+	}
+	return cfg, path, nil
+}
+
+// runConfigCommand handles `tmuxwatch config <print|path>`.
+func runConfigCommand(args []string, cfg config.Config, path string, out io.Writer) error {
+	sub := "print"
+	if len(args) > 0 {
+		sub = args[0]
+	}
+	switch sub {
+	case "print":
+		data, err := cfg.Print()
+		if err != nil {
+			return err
+		}
+		_, err = out.Write(data)
+		return err
+	case "path":
+		_, err := fmt.Fprintln(out, path)
+		return err
+	default:
+		return fmt.Errorf("unknown config command %q (want print or path)", sub)
+	}
+}
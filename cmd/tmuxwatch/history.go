@@ -0,0 +1,114 @@
+// File history.go opens the persistent activity history for the TUI and
+// implements the `history` subcommand that summarises a day.
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/steipete/tmuxwatch/internal/config"
+	"github.com/steipete/tmuxwatch/internal/history"
+)
+
+// openHistory opens the history store after applying retention and
+// compacting finished days. Problems are reported but never block start-up.
+func openHistory(cfg config.History) *history.Store {
+	if !cfg.Enabled {
+		return nil
+	}
+	dir, err := history.Dir()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "warning: activity history disabled: %v\n", err)
+		return nil
+	}
+	now := time.Now()
+	if err := history.Prune(dir, time.Duration(cfg.Retention), now); err != nil {
+		fmt.Fprintf(os.Stderr, "warning: %v\n", err)
+	}
+	if err := history.Compact(dir, now); err != nil {
+		fmt.Fprintf(os.Stderr, "warning: %v\n", err)
+	}
+	st, err := history.Open(dir)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "warning: activity history disabled: %v\n", err)
+		return nil
+	}
+	return st
+}
+
+// runHistoryCommand handles `tmuxwatch history [--date YYYY-MM-DD] [--json]`.
+func runHistoryCommand(args []string, out io.Writer, now time.Time) error {
+	fs := flag.NewFlagSet("history", flag.ContinueOnError)
+	fs.SetOutput(out)
+	date := fs.String("date", "", "day to summarise as YYYY-MM-DD (defaults to today)")
+	asJSON := fs.Bool("json", false, "print the summary as JSON")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	day := now
+	if *date != "" {
+		parsed, err := time.ParseInLocation("2006-01-02", *date, time.Local)
+		if err != nil {
+			return fmt.Errorf("invalid --date %q (want YYYY-MM-DD)", *date)
+		}
+		day = parsed
+	}
+	dir, err := history.Dir()
+	if err != nil {
+		return err
+	}
+	records, err := history.Load(dir, day)
+	if err != nil {
+		return err
+	}
+	summary := history.Summarize(records)
+	if *asJSON {
+		enc := json.NewEncoder(out)
+		enc.SetIndent("", "  ")
+		return enc.Encode(summary)
+	}
+	return printSummary(out, day, summary)
+}
+
+// printSummary renders the day's sessions and commands as aligned tables.
+func printSummary(out io.Writer, day time.Time, summary history.Summary) error {
+	fmt.Fprintf(out, "tmuxwatch history for %s\n", day.Format("Mon 2006-01-02"))
+	if len(summary.Sessions) == 0 {
+		_, err := fmt.Fprintln(out, "\nno activity recorded")
+		return err
+	}
+	tw := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "\nSESSION\tRAN\tLINES\tEXITS\tFAILED\tLAST OUTPUT")
+	for _, s := range summary.Sessions {
+		last := "-"
+		if !s.LastOutput.IsZero() {
+			last = s.LastOutput.Local().Format("15:04")
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%d\t%d\t%d\t%s\n", s.Session, formatSpan(s.Running), s.Lines, s.Exits, s.Failures, last)
+	}
+	if len(summary.Commands) > 0 {
+		fmt.Fprintln(tw, "\nCOMMAND\tRUNS\tFAILED\tTOTAL")
+		for _, c := range summary.Commands {
+			fmt.Fprintf(tw, "%s\t%d\t%d\t%s\n", c.Command, c.Runs, c.Failures, formatSpan(c.Total))
+		}
+	}
+	return tw.Flush()
+}
+
+// formatSpan renders a duration to the minute, e.g. 6h12m or 45m.
+func formatSpan(d time.Duration) string {
+	d = d.Round(time.Minute)
+	switch {
+	case d < time.Minute:
+		return "<1m"
+	case d < time.Hour:
+		return fmt.Sprintf("%dm", int(d.Minutes()))
+	default:
+		return fmt.Sprintf("%dh%02dm", int(d.Hours()), int(d.Minutes())%60)
+	}
+}
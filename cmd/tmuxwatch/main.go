@@ -14,6 +14,12 @@ import (
 	tea "charm.land/bubbletea/v2"
 	zone "github.com/steipete/tmuxwatch/internal/zone"
 
+	"github.com/steipete/tmuxwatch/internal/archive"
+	"github.com/steipete/tmuxwatch/internal/cast"
+	"github.com/steipete/tmuxwatch/internal/config"
+	"github.com/steipete/tmuxwatch/internal/eventlog"
+	"github.com/steipete/tmuxwatch/internal/export"
+	"github.com/steipete/tmuxwatch/internal/store"
 	"github.com/steipete/tmuxwatch/internal/tmux"
 	"github.com/steipete/tmuxwatch/internal/ui"
 )
@@ -31,7 +37,14 @@ func main() {
 		dump       = flag.Bool("dump", false, "print current tmux snapshot as JSON and exit")
 		simulate   = flag.String("debug-click", "", "simulate a mouse left-click at the given coordinates (x,y)")
 		traceMouse = flag.Bool("trace-mouse", false, "log mouse hit testing details to stderr")
+		configPath = flag.String("config", "", "path to config file (defaults to $XDG_CONFIG_HOME/tmuxwatch/config.json)")
+		fresh      = flag.Bool("fresh", false, "start with a clean view, ignoring the saved hidden, collapsed, focus, tab, and search state")
 	)
+	flag.Usage = func() {
+		out := flag.CommandLine.Output()
+		fmt.Fprintf(out, "Usage of tmuxwatch:\n  tmuxwatch [flags]\n  tmuxwatch [flags] config print|path\n  tmuxwatch [flags] history [--date YYYY-MM-DD] [--json]\n\nFlags:\n")
+		flag.PrintDefaults()
+	}
 	flag.Parse()
 
 	if *showVer {
@@ -62,6 +75,26 @@ func main() {
 		}
 	}
 
+	cfg, path, err := loadConfig(*configPath, *interval)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to load config: %v\n", err)
+		os.Exit(1)
+	}
+	if flag.Arg(0) == "config" {
+		if err := runConfigCommand(flag.Args()[1:], cfg, path, os.Stdout); err != nil {
+			fmt.Fprintf(os.Stderr, "%v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+	if flag.Arg(0) == "history" {
+		if err := runHistoryCommand(flag.Args()[1:], os.Stdout, time.Now()); err != nil {
+			fmt.Fprintf(os.Stderr, "%v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+
 	client, err := tmux.NewClient(*tmuxBin)
 	// If tmux isn't running, inform the user early.
 	if err != nil {
@@ -86,11 +119,129 @@ func main() {
 		return
 	}
 
-	model := ui.NewModel(client, *interval, debugMsgs, *traceMouse)
+	statePath, state := loadState()
+	if *fresh {
+		state.View = store.View{}
+	}
+	events := openEventLog(cfg.EventLog)
+	if events != nil {
+		defer events.Close()
+	}
+	activity := openHistory(cfg.History)
+	if activity != nil {
+		defer activity.Close()
+	}
+	model, err := ui.NewModel(client, ui.Options{
+		Config:     cfg,
+		State:      state,
+		StatePath:  statePath,
+		EventLog:   events,
+		History:    activity,
+		ArchiveDir: archiveDir(cfg.Archive),
+		RecordDir:  recordDir(cfg.Record),
+		ExportDir:  exportDir(cfg.Export),
+		DebugMsgs:  debugMsgs,
+		TraceMouse: *traceMouse,
+	})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to load config: %s: %v\n", path, err)
+		os.Exit(1)
+	}
 	program := tea.NewProgram(model)
 
-	if _, err := program.Run(); err != nil {
+	final, err := program.Run()
+	m, ok := final.(*ui.Model)
+	if ok {
+		m.StopRecordings()
+		m.Flush()
+		m.Close()
+		if err := m.SaveState(); err != nil {
+			fmt.Fprintf(os.Stderr, "warning: could not save state: %v\n", err)
+		}
+	}
+	if err != nil {
 		fmt.Fprintf(os.Stderr, "tmuxwatch exited with error: %v\n", err)
 		os.Exit(1)
 	}
+	if ok && m.AttachTarget() != "" {
+		if err := attachSession(client.Binary(), m.AttachTarget(), cfg.Jump.Outside, os.Stdout); err != nil {
+			fmt.Fprintf(os.Stderr, "%v\n", err)
+			os.Exit(1)
+		}
+	}
+}
+
+// loadState reads persisted UI state. Problems are reported but never block
+// start-up; an unreadable state file is simply not written back.
+func loadState() (string, store.State) {
+	path, err := store.Path()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "warning: %v\n", err)
+		return "", store.State{}
+	}
+	st, err := store.Load(path)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "warning: ignoring saved state: %v\n", err)
+		return "", store.State{}
+	}
+	return path, st
+}
+
+// openEventLog opens the configured event log. Like saved state, a log that
+// cannot be opened is reported and skipped rather than blocking start-up.
+func openEventLog(path string) *eventlog.Writer {
+	if path == "" {
+		return nil
+	}
+	w, err := eventlog.Open(path)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "warning: event log disabled: %v\n", err)
+		return nil
+	}
+	return w
+}
+
+// archiveDir resolves where killed sessions are archived, or "" when
+// archiving is off or the state directory cannot be found.
+func archiveDir(cfg config.Archive) string {
+	if !cfg.Enabled {
+		return ""
+	}
+	if cfg.Dir != "" {
+		return cfg.Dir
+	}
+	dir, err := archive.Dir()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "warning: session archive disabled: %v\n", err)
+		return ""
+	}
+	return dir
+}
+
+// recordDir resolves where asciicast recordings are written, or "" when the
+// state directory cannot be found.
+func recordDir(cfg config.Record) string {
+	if cfg.Dir != "" {
+		return cfg.Dir
+	}
+	dir, err := cast.Dir()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "warning: recording disabled: %v\n", err)
+		return ""
+	}
+	return dir
+}
+
+// exportDir resolves where exported pane text is saved, or "" when the state
+// directory cannot be found.
+func exportDir(cfg config.Export) string {
+	if cfg.Dir != "" {
+		return cfg.Dir
+	}
+	dir, err := export.Dir()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "warning: saving exports disabled: %v\n", err)
+		return ""
+	}
+	return dir
 }
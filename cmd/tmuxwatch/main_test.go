@@ -122,3 +122,76 @@ func TestHelpFlag(t *testing.T) {
 		t.Errorf("expected help output to contain usage information, got: %s", outputStr)
 	}
 }
+
+// TestConfigPrintMergesFlags verifies `config print` shows file values with
+// explicit flags taking precedence.
+func TestConfigPrintMergesFlags(t *testing.T) {
+	dir := t.TempDir()
+	cfgPath := filepath.Join(dir, "config.json")
+	doc := `{"version": 1, "poll_interval": "5s", "stale_threshold": "30m"}`
+	if err := os.WriteFile(cfgPath, []byte(doc), 0o600); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+
+	cmd := exec.Command(testBinPath, "--config", cfgPath, "--interval", "2s", "config", "print")
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("config print failed: %v, output: %s", err, output)
+	}
+	outputStr := string(output)
+	if !strings.Contains(outputStr, `"poll_interval": "2s"`) {
+		t.Errorf("expected flag to override poll_interval, got: %s", outputStr)
+	}
+	if !strings.Contains(outputStr, `"stale_threshold": "30m0s"`) {
+		t.Errorf("expected stale_threshold from file, got: %s", outputStr)
+	}
+}
+
+// TestInvalidConfigReportsLine verifies config errors point at the bad line.
+func TestInvalidConfigReportsLine(t *testing.T) {
+	dir := t.TempDir()
+	cfgPath := filepath.Join(dir, "config.json")
+	doc := "{\n  \"poll_interval\": \"often\"\n}\n"
+	if err := os.WriteFile(cfgPath, []byte(doc), 0o600); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+
+	cmd := exec.Command(testBinPath, "--config", cfgPath, "config", "print")
+	output, err := cmd.CombinedOutput()
+	if err == nil {
+		t.Fatal("expected invalid config to fail")
+	}
+	want := cfgPath + ":2:3: poll_interval"
+	if !strings.Contains(string(output), want) {
+		t.Errorf("expected error containing %q, got: %s", want, output)
+	}
+}
+
+// TestHistorySummarisesDay verifies `history` reports run time and failures
+// from the day file in the state directory.
+func TestHistorySummarisesDay(t *testing.T) {
+	dir := t.TempDir()
+	historyDir := filepath.Join(dir, "tmuxwatch", "history")
+	if err := os.MkdirAll(historyDir, 0o755); err != nil {
+		t.Fatalf("create history dir: %v", err)
+	}
+	doc := `{"time":"2026-10-18T09:00:00Z","kind":"sample","session":"api-server","pane":"%1","seconds":22320,"lines":40}
+{"time":"2026-10-18T10:00:00Z","kind":"exit","session":"ci","pane":"%2","command":"go","seconds":90,"exit_code":1}
+`
+	if err := os.WriteFile(filepath.Join(historyDir, "2026-10-18.jsonl"), []byte(doc), 0o600); err != nil {
+		t.Fatalf("write history: %v", err)
+	}
+
+	cmd := exec.Command(testBinPath, "--config", filepath.Join(dir, "none.json"), "history", "--date", "2026-10-18")
+	cmd.Env = append(os.Environ(), "XDG_STATE_HOME="+dir, "TZ=UTC")
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("history failed: %v, output: %s", err, output)
+	}
+	outputStr := string(output)
+	for _, want := range []string{"tmuxwatch history for Sun 2026-10-18", "api-server  6h12m", "go       1     1"} {
+		if !strings.Contains(outputStr, want) {
+			t.Errorf("expected output to contain %q, got:\n%s", want, outputStr)
+		}
+	}
+}
@@ -0,0 +1,143 @@
+// Package archive saves the full scrollback of sessions before tmuxwatch
+// kills them, one timestamped directory per session holding a metadata file
+// and a text file per pane.
+package archive
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
+
+	"github.com/steipete/tmuxwatch/internal/store"
+)
+
+// metaFile is the metadata document inside each archive directory.
+const metaFile = "meta.json"
+
+// Meta describes an archived session.
+type Meta struct {
+	Session  string    `json:"session"`
+	KilledAt time.Time `json:"killed_at"`
+	Panes    []Pane    `json:"panes"`
+}
+
+// Pane describes one archived pane and the file holding its output.
+type Pane struct {
+	ID       string `json:"id"`
+	Window   string `json:"window"`
+	Title    string `json:"title,omitempty"`
+	Command  string `json:"command,omitempty"`
+	Path     string `json:"path,omitempty"`
+	Dead     bool   `json:"dead,omitempty"`
+	ExitCode int    `json:"exit_code,omitempty"`
+	File     string `json:"file"`
+	// Output is the captured scrollback; it is written to File, not to the
+	// metadata.
+	Output string `json:"-"`
+}
+
+// Entry is an archive found on disk.
+type Entry struct {
+	Dir  string
+	Meta Meta
+}
+
+// Dir returns the default archive directory inside the tmuxwatch state
+// directory.
+func Dir() (string, error) {
+	dir, err := store.Dir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "archive"), nil
+}
+
+// Write stores meta and each pane's output under root and returns the new
+// archive directory.
+func Write(root string, meta Meta) (string, error) {
+	dir, err := createDir(root, meta.KilledAt.Format("20060102-150405")+"-"+store.SafeName(meta.Session))
+	if err != nil {
+		return "", err
+	}
+	for i := range meta.Panes {
+		pane := &meta.Panes[i]
+		pane.File = fmt.Sprintf("%02d-%s-%s.txt", i+1, store.SafeName(pane.Window), store.SafeName(pane.ID))
+		if err := os.WriteFile(filepath.Join(dir, pane.File), []byte(pane.Output), 0o600); err != nil {
+			return "", fmt.Errorf("write archive: %w", err)
+		}
+	}
+	data, err := json.MarshalIndent(meta, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("encode archive: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, metaFile), append(data, '\n'), 0o600); err != nil {
+		return "", fmt.Errorf("write archive: %w", err)
+	}
+	return dir, nil
+}
+
+// createDir makes a new directory named name under root, adding a -2, -3,
+// ... suffix when sessions killed in the same second share a name.
+func createDir(root, name string) (string, error) {
+	if err := os.MkdirAll(root, 0o700); err != nil {
+		return "", fmt.Errorf("create archive: %w", err)
+	}
+	dir := filepath.Join(root, name)
+	for n := 2; ; n++ {
+		err := os.Mkdir(dir, 0o700)
+		if err == nil {
+			return dir, nil
+		}
+		if !errors.Is(err, fs.ErrExist) {
+			return "", fmt.Errorf("create archive: %w", err)
+		}
+		dir = filepath.Join(root, fmt.Sprintf("%s-%d", name, n))
+	}
+}
+
+// List returns the archives under root, newest first. Directories without
+// readable metadata are skipped.
+func List(root string) ([]Entry, error) {
+	dirs, err := os.ReadDir(root)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read archive dir: %w", err)
+	}
+	var out []Entry
+	for _, d := range dirs {
+		if !d.IsDir() {
+			continue
+		}
+		dir := filepath.Join(root, d.Name())
+		data, err := os.ReadFile(filepath.Join(dir, metaFile))
+		if err != nil {
+			continue
+		}
+		var meta Meta
+		if err := json.Unmarshal(data, &meta); err != nil {
+			continue
+		}
+		out = append(out, Entry{Dir: dir, Meta: meta})
+	}
+	slices.SortFunc(out, func(a, b Entry) int { return b.Meta.KilledAt.Compare(a.Meta.KilledAt) })
+	return out, nil
+}
+
+// ReadPane returns the archived output of the entry's i-th pane.
+func (e Entry) ReadPane(i int) (string, error) {
+	if i < 0 || i >= len(e.Meta.Panes) {
+		return "", fmt.Errorf("archive %s has no pane %d", filepath.Base(e.Dir), i)
+	}
+	data, err := os.ReadFile(filepath.Join(e.Dir, filepath.Base(e.Meta.Panes[i].File)))
+	if err != nil {
+		return "", fmt.Errorf("read archive: %w", err)
+	}
+	return string(data), nil
+}
@@ -0,0 +1,81 @@
+// File archive_test.go covers writing, listing, and reading archives.
+package archive
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+)
+
+// TestWriteListRead round-trips an archive and lists the newest first.
+func TestWriteListRead(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	at := time.Date(2026, 10, 18, 14, 2, 0, 0, time.UTC)
+	older := Meta{Session: "docs", KilledAt: at.Add(-time.Hour), Panes: []Pane{{ID: "%9", Window: "main", Output: "bye\n"}}}
+	newer := Meta{Session: "ci/../build", KilledAt: at, Panes: []Pane{
+		{ID: "%1", Window: "editor", Command: "vim", Output: "first\n"},
+		{ID: "%2", Window: "tests", Command: "go", Dead: true, ExitCode: 1, Output: "FAIL pkg/api\n"},
+	}}
+	for _, meta := range []Meta{older, newer} {
+		if _, err := Write(root, meta); err != nil {
+			t.Fatalf("Write returned error: %v", err)
+		}
+	}
+	if err := os.Mkdir(filepath.Join(root, "junk"), 0o700); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+
+	entries, err := List(root)
+	if err != nil {
+		t.Fatalf("List returned error: %v", err)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("entries = %+v, want 2", entries)
+	}
+	got := entries[0]
+	if got.Meta.Session != "ci/../build" || filepath.Base(got.Dir) != "20261018-140200-ci_.._build" {
+		t.Fatalf("newest = %s (%s)", got.Meta.Session, got.Dir)
+	}
+	if p := got.Meta.Panes[1]; !p.Dead || p.ExitCode != 1 || p.Command != "go" {
+		t.Fatalf("pane meta = %+v", p)
+	}
+	out, err := got.ReadPane(1)
+	if err != nil {
+		t.Fatalf("ReadPane returned error: %v", err)
+	}
+	if out != "FAIL pkg/api\n" {
+		t.Fatalf("ReadPane = %q", out)
+	}
+	if _, err := got.ReadPane(5); err == nil {
+		t.Fatal("expected an error for a missing pane")
+	}
+}
+
+// TestWriteKeepsSameSecondArchives gives sessions killed in the same second
+// whose names sanitise alike their own directories.
+func TestWriteKeepsSameSecondArchives(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	at := time.Date(2026, 10, 18, 14, 2, 0, 0, time.UTC)
+	var dirs []string
+	for _, session := range []string{"a/b", "a_b", "a/b"} {
+		dir, err := Write(root, Meta{Session: session, KilledAt: at, Panes: []Pane{{ID: "%1", Window: "main", Output: session}}})
+		if err != nil {
+			t.Fatalf("Write returned error: %v", err)
+		}
+		dirs = append(dirs, filepath.Base(dir))
+	}
+	want := []string{"20261018-140200-a_b", "20261018-140200-a_b-2", "20261018-140200-a_b-3"}
+	if !slices.Equal(dirs, want) {
+		t.Fatalf("dirs = %q, want %q", dirs, want)
+	}
+	entries, err := List(root)
+	if err != nil || len(entries) != 3 {
+		t.Fatalf("List = %d entries (%v), want 3", len(entries), err)
+	}
+}
@@ -0,0 +1,239 @@
+// Package cast writes and reads asciinema v2 recordings (.cast files): a
+// JSON header line followed by one JSON array per output event, timed in
+// seconds since the recording started.
+package cast
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/steipete/tmuxwatch/internal/store"
+)
+
+// Version is the asciicast format version written in the header.
+const Version = 2
+
+// clearScreen homes the cursor and clears the screen before each frame.
+const clearScreen = "\x1b[H\x1b[2J"
+
+// Header is the first line of a recording.
+type Header struct {
+	Version   int    `json:"version"`
+	Width     int    `json:"width"`
+	Height    int    `json:"height"`
+	Timestamp int64  `json:"timestamp"`
+	Title     string `json:"title,omitempty"`
+}
+
+// Event is one timed output chunk. It is encoded as [time, "o", data].
+type Event struct {
+	Time float64
+	Data string
+}
+
+// MarshalJSON renders the event as an asciicast event array.
+func (e Event) MarshalJSON() ([]byte, error) {
+	return json.Marshal([]any{e.Time, "o", e.Data})
+}
+
+// UnmarshalJSON reads an asciicast event array. Events other than output
+// keep an empty Data.
+func (e *Event) UnmarshalJSON(data []byte) error {
+	var fields []json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return err
+	}
+	if len(fields) != 3 {
+		return fmt.Errorf("event has %d fields, want 3", len(fields))
+	}
+	var kind string
+	if err := json.Unmarshal(fields[0], &e.Time); err != nil {
+		return fmt.Errorf("event time: %w", err)
+	}
+	if err := json.Unmarshal(fields[1], &kind); err != nil {
+		return fmt.Errorf("event type: %w", err)
+	}
+	e.Data = ""
+	if kind != "o" {
+		return nil
+	}
+	return json.Unmarshal(fields[2], &e.Data)
+}
+
+// Recording is a recording read back from disk.
+type Recording struct {
+	Header Header
+	Events []Event
+}
+
+// Duration returns the time of the last event.
+func (r Recording) Duration() time.Duration {
+	if len(r.Events) == 0 {
+		return 0
+	}
+	return time.Duration(r.Events[len(r.Events)-1].Time * float64(time.Second))
+}
+
+// Read loads the recording at path. Only output events are kept.
+func Read(path string) (Recording, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return Recording{}, fmt.Errorf("open recording: %w", err)
+	}
+	defer f.Close()
+	var rec Recording
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	line, header := 0, false
+	for scanner.Scan() {
+		line++
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" {
+			continue
+		}
+		if !header {
+			header = true
+			if err := json.Unmarshal([]byte(text), &rec.Header); err != nil {
+				return Recording{}, fmt.Errorf("%s: header: %w", filepath.Base(path), err)
+			}
+			if rec.Header.Version != Version {
+				return Recording{}, fmt.Errorf("%s: unsupported asciicast version %d", filepath.Base(path), rec.Header.Version)
+			}
+			continue
+		}
+		var ev Event
+		if err := json.Unmarshal([]byte(text), &ev); err != nil {
+			return Recording{}, fmt.Errorf("%s: line %d: %w", filepath.Base(path), line, err)
+		}
+		if ev.Data != "" {
+			rec.Events = append(rec.Events, ev)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return Recording{}, fmt.Errorf("read recording: %w", err)
+	}
+	if !header {
+		return Recording{}, errors.New(filepath.Base(path) + ": empty recording")
+	}
+	return rec, nil
+}
+
+// Writer appends frames to a recording. It is safe for concurrent use.
+type Writer struct {
+	mu     sync.Mutex
+	f      *os.File
+	enc    *json.Encoder
+	path   string
+	start  time.Time
+	height int
+	last   string
+}
+
+// Dir returns the default recording directory inside the tmuxwatch state
+// directory.
+func Dir() (string, error) {
+	dir, err := store.Dir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "recordings"), nil
+}
+
+// List returns the .cast files in dir, newest first.
+func List(dir string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read recording dir: %w", err)
+	}
+	type file struct {
+		path string
+		mod  time.Time
+	}
+	var files []file
+	for _, e := range entries {
+		if e.IsDir() || filepath.Ext(e.Name()) != ".cast" {
+			continue
+		}
+		info, err := e.Info()
+		if err != nil {
+			continue
+		}
+		files = append(files, file{filepath.Join(dir, e.Name()), info.ModTime()})
+	}
+	slices.SortFunc(files, func(a, b file) int {
+		if c := b.mod.Compare(a.mod); c != 0 {
+			return c
+		}
+		return strings.Compare(b.path, a.path)
+	})
+	out := make([]string, len(files))
+	for i, f := range files {
+		out[i] = f.path
+	}
+	return out, nil
+}
+
+// Create starts a recording named after name and start in dir and writes
+// its header. Width and height are the recorded pane's size.
+func Create(dir, name string, width, height int, start time.Time) (*Writer, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, fmt.Errorf("create recording dir: %w", err)
+	}
+	path := filepath.Join(dir, start.Format("20060102-150405")+"-"+store.SafeName(name)+".cast")
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("create recording: %w", err)
+	}
+	w := &Writer{f: f, enc: json.NewEncoder(f), path: path, start: start, height: max(height, 1)}
+	header := Header{Version: Version, Width: max(width, 1), Height: w.height, Timestamp: start.Unix(), Title: name}
+	if err := w.enc.Encode(header); err != nil {
+		_ = f.Close()
+		return nil, fmt.Errorf("write recording: %w", err)
+	}
+	return w, nil
+}
+
+// Path returns the recording's file.
+func (w *Writer) Path() string {
+	return w.path
+}
+
+// Screen records the pane text captured at at as a full frame: the screen is
+// cleared and the last Height lines are drawn. Unchanged screens are skipped.
+func (w *Writer) Screen(at time.Time, text string) error {
+	lines := strings.Split(strings.TrimRight(text, "\n"), "\n")
+	if over := len(lines) - w.height; over > 0 {
+		lines = lines[over:]
+	}
+	frame := clearScreen + strings.Join(lines, "\r\n")
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if frame == w.last {
+		return nil
+	}
+	w.last = frame
+	elapsed := max(at.Sub(w.start), 0)
+	if err := w.enc.Encode(Event{Time: elapsed.Seconds(), Data: frame}); err != nil {
+		return fmt.Errorf("write recording: %w", err)
+	}
+	return nil
+}
+
+// Close finishes the recording.
+func (w *Writer) Close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.f.Close()
+}
@@ -0,0 +1,139 @@
+// File cast_test.go covers writing asciicast recordings.
+package cast
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestWriterFrames writes a header and one frame per changed screen, keeping
+// only the last Height lines of each capture.
+func TestWriterFrames(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	start := time.Date(2026, 10, 18, 14, 2, 0, 0, time.UTC)
+	w, err := Create(dir, "ci/build", 80, 2, start)
+	if err != nil {
+		t.Fatalf("Create returned error: %v", err)
+	}
+	if filepath.Base(w.Path()) != "20261018-140200-ci_build.cast" {
+		t.Fatalf("path = %s", w.Path())
+	}
+	steps := []struct {
+		after time.Duration
+		text  string
+	}{
+		{0, "$ make\n"},
+		{500 * time.Millisecond, "$ make\nok\n"},
+		{time.Second, "$ make\nok\n"},
+		{1500 * time.Millisecond, "$ make\nok\nFAIL\n"},
+	}
+	for _, step := range steps {
+		if err := w.Screen(start.Add(step.after), step.text); err != nil {
+			t.Fatalf("Screen returned error: %v", err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("Close returned error: %v", err)
+	}
+
+	data, err := os.ReadFile(w.Path())
+	if err != nil {
+		t.Fatalf("read: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	if len(lines) != 4 {
+		t.Fatalf("lines = %q, want header and three frames", lines)
+	}
+	var header Header
+	if err := json.Unmarshal([]byte(lines[0]), &header); err != nil {
+		t.Fatalf("header: %v", err)
+	}
+	if header != (Header{Version: 2, Width: 80, Height: 2, Timestamp: start.Unix(), Title: "ci/build"}) {
+		t.Fatalf("header = %+v", header)
+	}
+	wants := []struct {
+		at   float64
+		data string
+	}{
+		{0, clearScreen + "$ make"},
+		{0.5, clearScreen + "$ make\r\nok"},
+		{1.5, clearScreen + "ok\r\nFAIL"},
+	}
+	for i, want := range wants {
+		var ev []any
+		if err := json.Unmarshal([]byte(lines[i+1]), &ev); err != nil {
+			t.Fatalf("event %d: %v", i, err)
+		}
+		if len(ev) != 3 || ev[0] != want.at || ev[1] != "o" || ev[2] != want.data {
+			t.Fatalf("event %d = %v, want [%v o %q]", i, ev, want.at, want.data)
+		}
+	}
+}
+
+// TestRead loads what Writer wrote, keeps only output events from other
+// recorders, and rejects other format versions.
+func TestRead(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	start := time.Date(2026, 10, 18, 14, 2, 0, 0, time.UTC)
+	w, err := Create(dir, "demo", 100, 30, start)
+	if err != nil {
+		t.Fatalf("Create returned error: %v", err)
+	}
+	_ = w.Screen(start.Add(250*time.Millisecond), "hello\n")
+	_ = w.Close()
+	rec, err := Read(w.Path())
+	if err != nil {
+		t.Fatalf("Read returned error: %v", err)
+	}
+	if rec.Header.Width != 100 || len(rec.Events) != 1 || rec.Duration() != 250*time.Millisecond {
+		t.Fatalf("recording = %+v, duration %v", rec, rec.Duration())
+	}
+	if err := os.WriteFile(filepath.Join(dir, "notes.txt"), nil, 0o600); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	if files, err := List(dir); err != nil || len(files) != 1 || files[0] != w.Path() {
+		t.Fatalf("List = %v (%v), want only the recording", files, err)
+	}
+	if files, err := List(filepath.Join(dir, "absent")); err != nil || files != nil {
+		t.Fatalf("List(absent) = %v (%v)", files, err)
+	}
+
+	tests := []struct {
+		name   string
+		doc    string
+		events int
+		err    string
+	}{
+		{name: "input events", doc: "{\"version\":2,\"width\":80,\"height\":24}\n[0.1,\"i\",\"x\"]\n[0.2,\"o\",\"x\"]\n", events: 1},
+		{name: "version", doc: "{\"version\":1}\n", err: "unsupported asciicast version 1"},
+		{name: "bad event", doc: "{\"version\":2}\n[0.1]\n", err: "line 2: event has 1 fields"},
+		{name: "empty", doc: "\n", err: "empty recording"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			path := filepath.Join(t.TempDir(), "x.cast")
+			if err := os.WriteFile(path, []byte(tt.doc), 0o600); err != nil {
+				t.Fatalf("write: %v", err)
+			}
+			rec, err := Read(path)
+			if tt.err != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.err) {
+					t.Fatalf("error = %v, want %q", err, tt.err)
+				}
+				return
+			}
+			if err != nil || len(rec.Events) != tt.events {
+				t.Fatalf("events = %d (%v), want %d", len(rec.Events), err, tt.events)
+			}
+		})
+	}
+}
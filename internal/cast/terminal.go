@@ -0,0 +1,195 @@
+// File terminal.go turns recorded terminal output back into text.
+package cast
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Terminal replays terminal output into plain text lines. It understands
+// the control sequences recordings rely on most: newlines, carriage returns,
+// backspace, tabs, cursor movement, and erasing the screen or a line. Other
+// escape sequences, colours included, are dropped. Lines are not wrapped;
+// output scrolled off the top of the screen is kept as scrollback.
+type Terminal struct {
+	height   int
+	lines    [][]rune
+	row, col int
+}
+
+// NewTerminal returns an empty terminal with a screen height rows tall. A
+// height of zero treats every line as part of the screen.
+func NewTerminal(height int) *Terminal {
+	return &Terminal{height: max(height, 0), lines: [][]rune{nil}}
+}
+
+// Reset clears the terminal and its scrollback.
+func (t *Terminal) Reset() {
+	t.lines = [][]rune{nil}
+	t.row, t.col = 0, 0
+}
+
+// String returns the scrollback and screen, without trailing blanks.
+func (t *Terminal) String() string {
+	out := make([]string, len(t.lines))
+	for i, line := range t.lines {
+		out[i] = strings.TrimRight(string(line), " ")
+	}
+	return strings.TrimRight(strings.Join(out, "\n"), "\n")
+}
+
+// Write interprets output.
+func (t *Terminal) Write(s string) {
+	runes := []rune(s)
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+		switch {
+		case r == '\n':
+			t.moveTo(t.row+1, 0)
+		case r == '\r':
+			t.col = 0
+		case r == '\b':
+			t.col = max(t.col-1, 0)
+		case r == '\t':
+			t.col = (t.col/8 + 1) * 8
+		case r == 0x1b:
+			i = t.escape(runes, i)
+		case r < 0x20 || r == 0x7f:
+		default:
+			t.put(r)
+		}
+	}
+}
+
+// escape handles the escape sequence starting at runes[i] and returns the
+// index of its last rune.
+func (t *Terminal) escape(runes []rune, i int) int {
+	if i+1 >= len(runes) {
+		return i
+	}
+	switch runes[i+1] {
+	case '[':
+		end := i + 2
+		for end < len(runes) && (runes[end] < 0x40 || runes[end] > 0x7e) {
+			end++
+		}
+		if end >= len(runes) {
+			return len(runes) - 1
+		}
+		t.csi(string(runes[i+2:end]), runes[end])
+		return end
+	case ']':
+		// Operating system commands end with BEL or ESC \.
+		for end := i + 2; end < len(runes); end++ {
+			if runes[end] == 0x07 {
+				return end
+			}
+			if runes[end] == 0x1b && end+1 < len(runes) && runes[end+1] == '\\' {
+				return end + 1
+			}
+		}
+		return len(runes) - 1
+	default:
+		return i + 1
+	}
+}
+
+// csi applies a control sequence with its parameters and final byte.
+func (t *Terminal) csi(params string, final rune) {
+	if strings.HasPrefix(params, "?") {
+		return
+	}
+	args := strings.Split(params, ";")
+	arg := func(n, def int) int {
+		if n >= len(args) {
+			return def
+		}
+		v, err := strconv.Atoi(args[n])
+		if err != nil || v == 0 {
+			return def
+		}
+		return v
+	}
+	top := t.top()
+	switch final {
+	case 'A':
+		t.moveTo(max(t.row-arg(0, 1), top), t.col)
+	case 'B':
+		t.moveTo(t.row+arg(0, 1), t.col)
+	case 'C':
+		t.col += arg(0, 1)
+	case 'D':
+		t.col = max(t.col-arg(0, 1), 0)
+	case 'G':
+		t.col = arg(0, 1) - 1
+	case 'H', 'f':
+		t.moveTo(top+arg(0, 1)-1, arg(1, 1)-1)
+	case 'J':
+		switch arg(0, 0) {
+		case 0:
+			t.truncate()
+			t.lines = t.lines[:t.row+1]
+		case 1:
+			for row := top; row < t.row; row++ {
+				t.lines[row] = nil
+			}
+			t.blank(0, t.col+1)
+		default:
+			for row := top; row < len(t.lines); row++ {
+				t.lines[row] = nil
+			}
+		}
+	case 'K':
+		switch arg(0, 0) {
+		case 0:
+			t.truncate()
+		case 1:
+			t.blank(0, t.col+1)
+		default:
+			t.lines[t.row] = nil
+		}
+	}
+}
+
+// top returns the index of the first screen line.
+func (t *Terminal) top() int {
+	if t.height == 0 {
+		return 0
+	}
+	return max(len(t.lines)-t.height, 0)
+}
+
+// moveTo places the cursor, adding lines below the last one as needed.
+func (t *Terminal) moveTo(row, col int) {
+	t.row = max(row, 0)
+	t.col = max(col, 0)
+	for len(t.lines) <= t.row {
+		t.lines = append(t.lines, nil)
+	}
+}
+
+// put writes r at the cursor and advances it.
+func (t *Terminal) put(r rune) {
+	line := t.lines[t.row]
+	for len(line) <= t.col {
+		line = append(line, ' ')
+	}
+	line[t.col] = r
+	t.lines[t.row] = line
+	t.col++
+}
+
+// truncate erases from the cursor to the end of the line.
+func (t *Terminal) truncate() {
+	if line := t.lines[t.row]; t.col < len(line) {
+		t.lines[t.row] = line[:t.col]
+	}
+}
+
+// blank erases the cursor line's columns from..to-1.
+func (t *Terminal) blank(from, to int) {
+	line := t.lines[t.row]
+	for col := from; col < to && col < len(line); col++ {
+		line[col] = ' '
+	}
+}
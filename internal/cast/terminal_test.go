@@ -0,0 +1,41 @@
+// File terminal_test.go covers replaying output into text.
+package cast
+
+import "testing"
+
+// TestTerminalWrite interprets the control sequences recordings use.
+func TestTerminalWrite(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		height int
+		writes []string
+		want   string
+	}{
+		{name: "lines", writes: []string{"one\r\ntwo\r\n", "three"}, want: "one\ntwo\nthree"},
+		{name: "carriage return progress", writes: []string{"10%\r", "50%\r", "done\r\n"}, want: "done"},
+		{name: "backspace and erase line", writes: []string{"abcd\b\b\x1b[K", "X"}, want: "abX"},
+		{name: "colours and titles dropped", writes: []string{"\x1b]0;title\x07\x1b[31mred\x1b[0m \x1b[?25lok"}, want: "red ok"},
+		{name: "frames redraw the screen", height: 2, writes: []string{"\x1b[H\x1b[2Ja\r\nb", "\x1b[H\x1b[2Jc\r\nd"}, want: "c\nd"},
+		{name: "cursor positioning", height: 3, writes: []string{"aaa\r\nbbb\r\nccc", "\x1b[2;2HX\x1b[3;1H\x1b[2K"}, want: "aaa\nbXb"},
+		{name: "scrollback kept", height: 2, writes: []string{"1\r\n2\r\n3\r\n", "\x1b[1;1H\x1b[J"}, want: "1\n2"},
+		{name: "split sequence dropped", writes: []string{"ok\x1b["}, want: "ok"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			term := NewTerminal(tt.height)
+			for _, w := range tt.writes {
+				term.Write(w)
+			}
+			if got := term.String(); got != tt.want {
+				t.Fatalf("String() = %q, want %q", got, tt.want)
+			}
+			term.Reset()
+			if got := term.String(); got != "" {
+				t.Fatalf("after Reset = %q", got)
+			}
+		})
+	}
+}
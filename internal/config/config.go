@@ -0,0 +1,809 @@
+// Package config loads, validates, and prints the optional tmuxwatch
+// configuration file.
+package config
+
+import (
+	"bytes"
+	"cmp"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"maps"
+	"os"
+	"path"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// fileName is the configuration file looked up inside the tmuxwatch config
+// directory.
+const fileName = "config.json"
+
+// CurrentVersion is the schema version written by `tmuxwatch config print`.
+// Files without a version are read as the current version.
+const CurrentVersion = 1
+
+// Built-in defaults used when neither the config file nor flags override a
+// setting.
+const (
+	DefaultPollInterval    = time.Second
+	DefaultMinCaptureLines = 80
+	DefaultMaxCaptureLines = 600
+	DefaultMaxPerTick      = 6
+	DefaultStaleThreshold  = time.Hour
+	DefaultTheme           = "auto"
+	DefaultSort            = "tmux"
+	DefaultGroup           = "none"
+	DefaultAlertCooldown   = 30 * time.Second
+	DefaultNotifyOn        = "watched"
+	DefaultSilence         = 10 * time.Minute
+	DefaultResumeAfter     = time.Minute
+	DefaultRetention       = 30 * 24 * time.Hour
+	DefaultStaleCPU        = 5.0
+	DefaultJumpOutside     = "exec"
+	minRetention           = 24 * time.Hour
+	minPollInterval        = 100 * time.Millisecond
+)
+
+// Themes lists the accepted theme names. "auto" picks a dark or light theme
+// from the terminal background.
+var Themes = []string{DefaultTheme, "default", "dracula", "nord", "catppuccin", "solarized-light"}
+
+// SortModes lists the accepted default sort modes.
+var SortModes = []string{DefaultSort, "manual", "name", "created", "activity", "failures", "stale", "cpu"}
+
+// GroupModes lists the accepted default grouping modes.
+var GroupModes = []string{DefaultGroup, "prefix", "repo", "var", "attached"}
+
+// AlertActions lists what an alert rule may do when it fires.
+var AlertActions = []string{"highlight", "bell", "toast", "unread"}
+
+// DefaultAlertActions apply when a rule lists no actions.
+var DefaultAlertActions = []string{"highlight", "toast"}
+
+// NotifyModes lists which pane exits raise a notification: only sessions
+// marked with "notify when finished", any non-zero exit, every exit, or none.
+var NotifyModes = []string{DefaultNotifyOn, "failures", "all", "off"}
+
+// NotifyMethods lists how notifications are delivered.
+var NotifyMethods = []string{"toast", "bell", "osc9", "osc777", "command"}
+
+// DefaultNotifyMethods apply when the config lists no methods.
+var DefaultNotifyMethods = []string{"toast", "bell"}
+
+// JumpModes lists what jumping to a session does outside tmux: run
+// `tmux attach` in place of tmuxwatch, or print the command and exit.
+var JumpModes = []string{DefaultJumpOutside, "print"}
+
+// StaleSignals lists what keeps a session from going stale: pane output and
+// tmux pane activity, client input and attaches, or process CPU use.
+var StaleSignals = []string{"pane", "client", "cpu"}
+
+// DefaultStaleSignals apply when the config lists no signals.
+var DefaultStaleSignals = []string{"pane", "client"}
+
+// Config mirrors the on-disk configuration document.
+type Config struct {
+	Version        int         `json:"version"`
+	PollInterval   Duration    `json:"poll_interval"`
+	Capture        Capture     `json:"capture"`
+	StaleThreshold Duration    `json:"stale_threshold"`
+	Theme          string      `json:"theme"`
+	Sort           string      `json:"sort"`
+	Group          string      `json:"group"`
+	Hidden         []string    `json:"hidden"`
+	HideRules      []HideRule  `json:"hide_rules,omitempty"`
+	Alerts         []AlertRule `json:"alerts"`
+	Notify         Notify      `json:"notify"`
+	Monitors       Monitors    `json:"monitors"`
+	Stale          Stale       `json:"stale"`
+	History        History     `json:"history"`
+	Archive        Archive     `json:"archive"`
+	Record         Record      `json:"record"`
+	Export         Export      `json:"export"`
+	Jump           Jump        `json:"jump"`
+	Snippets       []Snippet   `json:"snippets,omitempty"`
+	Keymap         Keymap      `json:"keymap"`
+	// EventLog, when set, is a JSON Lines file that receives a copy of every
+	// timeline event.
+	EventLog string `json:"event_log,omitempty"`
+}
+
+// HideRule hides every session whose name matches the Session glob and
+// that has a pane running a command matching the Command glob. Either glob
+// may be empty, but not both.
+type HideRule struct {
+	Session string `json:"session,omitempty"`
+	Command string `json:"command,omitempty"`
+}
+
+// Snippet is a named command offered in the command palette. Placeholders
+// such as {session} and {cwd} are filled in for each pane it is sent to.
+type Snippet struct {
+	Name    string `json:"name"`
+	Command string `json:"command"`
+}
+
+// SnippetPlaceholders lists the placeholders a snippet command may use.
+var SnippetPlaceholders = []string{"session", "window", "pane", "cwd"}
+
+// snippetPlaceholder matches a {name} placeholder.
+var snippetPlaceholder = regexp.MustCompile(`\{([a-z_]+)\}`)
+
+// AlertRule raises an alert when a pane prints a line matching Pattern.
+// Session and Pane are optional glob patterns matched against the session
+// name and the pane title (or command); Cooldown suppresses repeats from the
+// same pane.
+type AlertRule struct {
+	Name     string   `json:"name,omitempty"`
+	Pattern  string   `json:"pattern"`
+	Session  string   `json:"session,omitempty"`
+	Pane     string   `json:"pane,omitempty"`
+	Cooldown Duration `json:"cooldown,omitempty"`
+	Actions  []string `json:"actions,omitempty"`
+}
+
+// Notify configures pane exit notifications. Command runs through the shell
+// with the event as JSON on stdin when Methods includes "command".
+type Notify struct {
+	On      string   `json:"on"`
+	Methods []string `json:"methods"`
+	Command string   `json:"command,omitempty"`
+}
+
+// Monitors configures per-card silence and activity monitors. Silence is how
+// long a pane must stay quiet before a silence monitor fires; ResumeAfter is
+// how long it must have been quiet for new output to trip an activity
+// monitor. TmuxFlags raises alerts when tmux sets a window's bell, activity,
+// or silence flag.
+type Monitors struct {
+	Silence     Duration `json:"silence"`
+	ResumeAfter Duration `json:"resume_after"`
+	TmuxFlags   bool     `json:"tmux_flags"`
+}
+
+// Stale refines stale detection beyond stale_threshold. Rules give matching
+// sessions their own threshold (first match wins); Exempt sessions never go
+// stale; Protect sessions are never stale and never killed. Signals lists
+// the activity sources that count, and CPU is the process-tree CPU percent
+// above which the "cpu" signal treats a session as busy.
+type Stale struct {
+	Rules   []StaleRule `json:"rules,omitempty"`
+	Exempt  []string    `json:"exempt,omitempty"`
+	Protect []string    `json:"protect,omitempty"`
+	Signals []string    `json:"signals"`
+	CPU     float64     `json:"cpu"`
+}
+
+// StaleRule sets the stale threshold for sessions whose name matches the
+// Session glob.
+type StaleRule struct {
+	Session string   `json:"session"`
+	After   Duration `json:"after"`
+}
+
+// History controls the on-disk activity history. Retention is how long day
+// files are kept before they are deleted at start-up.
+type History struct {
+	Enabled   bool     `json:"enabled"`
+	Retention Duration `json:"retention"`
+}
+
+// Archive controls saving a session's full scrollback before tmuxwatch
+// kills it. Dir overrides the default archive directory in the state
+// directory.
+type Archive struct {
+	Enabled bool   `json:"enabled"`
+	Dir     string `json:"dir,omitempty"`
+}
+
+// Record controls asciicast recordings of pane output. Dir overrides the
+// default recording directory in the state directory.
+type Record struct {
+	Dir string `json:"dir,omitempty"`
+}
+
+// Export controls saving and copying pane text. Dir overrides the default
+// export directory in the state directory; ANSI keeps colour escape
+// sequences instead of stripping them.
+type Export struct {
+	Dir  string `json:"dir,omitempty"`
+	ANSI bool   `json:"ansi"`
+}
+
+// Jump controls jumping from a card into tmux itself. Inside tmux the
+// calling client switches to the session; Outside picks what happens when
+// tmuxwatch runs outside tmux.
+type Jump struct {
+	Outside string `json:"outside"`
+}
+
+// Capture bounds how much pane history is read per refresh.
+type Capture struct {
+	MinLines   int `json:"min_lines"`
+	MaxLines   int `json:"max_lines"`
+	MaxPerTick int `json:"max_per_tick"`
+}
+
+// Keymap customises key bindings. Bindings maps action names to the keys that
+// trigger them; listing an action replaces its default keys and an empty list
+// unbinds it. Leader, when set, must be pressed before tmuxwatch commands while
+// a pane is focused so every other key reaches the pane.
+type Keymap struct {
+	Leader   string              `json:"leader,omitempty"`
+	Bindings map[string][]string `json:"bindings,omitempty"`
+}
+
+// Duration is a time.Duration encoded as a Go duration string ("1s", "10m").
+type Duration time.Duration
+
+// MarshalJSON renders the duration as a string.
+func (d Duration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(time.Duration(d).String())
+}
+
+// UnmarshalJSON parses a Go duration string. Failures are reported as
+// *json.UnmarshalTypeError so the decoder records the offending key.
+func (d *Duration) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return &json.UnmarshalTypeError{Value: string(data), Type: durationType}
+	}
+	parsed, err := time.ParseDuration(s)
+	if err != nil {
+		return &json.UnmarshalTypeError{Value: strconv.Quote(s), Type: durationType}
+	}
+	*d = Duration(parsed)
+	return nil
+}
+
+var durationType = reflect.TypeFor[Duration]()
+
+// Default returns the built-in configuration.
+func Default() Config {
+	return Config{
+		Version:      CurrentVersion,
+		PollInterval: Duration(DefaultPollInterval),
+		Capture: Capture{
+			MinLines:   DefaultMinCaptureLines,
+			MaxLines:   DefaultMaxCaptureLines,
+			MaxPerTick: DefaultMaxPerTick,
+		},
+		StaleThreshold: Duration(DefaultStaleThreshold),
+		Theme:          DefaultTheme,
+		Sort:           DefaultSort,
+		Group:          DefaultGroup,
+		Notify: Notify{
+			On:      DefaultNotifyOn,
+			Methods: slices.Clone(DefaultNotifyMethods),
+		},
+		Monitors: Monitors{
+			Silence:     Duration(DefaultSilence),
+			ResumeAfter: Duration(DefaultResumeAfter),
+			TmuxFlags:   true,
+		},
+		Stale: Stale{
+			Signals: append([]string(nil), DefaultStaleSignals...),
+			CPU:     DefaultStaleCPU,
+		},
+		History: History{
+			Enabled:   true,
+			Retention: Duration(DefaultRetention),
+		},
+		Archive: Archive{Enabled: true},
+		Jump:    Jump{Outside: DefaultJumpOutside},
+	}
+}
+
+// Dir returns the tmuxwatch configuration directory, honouring
+// $XDG_CONFIG_HOME before falling back to the platform default.
+func Dir() (string, error) {
+	if xdg := os.Getenv("XDG_CONFIG_HOME"); xdg != "" {
+		return filepath.Join(xdg, "tmuxwatch"), nil
+	}
+	base, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("locate config dir: %w", err)
+	}
+	return filepath.Join(base, "tmuxwatch"), nil
+}
+
+// Path returns the default configuration file location.
+func Path() (string, error) {
+	dir, err := Dir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, fileName), nil
+}
+
+// Check is an extra validation run by Load and Parse after Validate, for
+// settings checked by other packages such as the keymap. A *FieldError it
+// returns is located in the document like any other validation error.
+type Check func(Config) error
+
+// Load reads the configuration at path on top of Default. A missing file
+// yields the defaults so tmuxwatch runs without any configuration.
+func Load(path string, checks ...Check) (Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return Default(), nil
+		}
+		return Config{}, fmt.Errorf("read config %s: %w", path, err)
+	}
+	cfg, err := Parse(data, checks...)
+	if err != nil {
+		var pe *ParseError
+		if errors.As(err, &pe) {
+			pe.Path = path
+		}
+		return Config{}, err
+	}
+	return cfg, nil
+}
+
+// ParseError describes an invalid configuration document. Line and Col are
+// 1-based and zero when the location is unknown.
+type ParseError struct {
+	Path string
+	Line int
+	Col  int
+	Msg  string
+}
+
+func (e *ParseError) Error() string {
+	loc := e.Path
+	if e.Line > 0 {
+		loc = fmt.Sprintf("%s:%d:%d", e.Path, e.Line, e.Col)
+		if e.Path == "" {
+			loc = fmt.Sprintf("line %d, col %d", e.Line, e.Col)
+		}
+	}
+	if loc == "" {
+		return e.Msg
+	}
+	return loc + ": " + e.Msg
+}
+
+// Parse decodes and validates a configuration document, returning a
+// *ParseError that points at the offending key when it can be located.
+func Parse(data []byte, checks ...Check) (Config, error) {
+	cfg := Default()
+	positions := keyOffsets(data)
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&cfg); err != nil {
+		return Config{}, decodeError(data, positions, err)
+	}
+	if cfg.Version == 0 {
+		cfg.Version = CurrentVersion
+	}
+	if err := cfg.Validate(); err != nil {
+		return Config{}, validationError(data, positions, err)
+	}
+	for _, check := range checks {
+		if err := check(cfg); err != nil {
+			return Config{}, validationError(data, positions, err)
+		}
+	}
+	return cfg, nil
+}
+
+// validationError wraps a validation failure in a *ParseError located at the
+// offending key when it is a *FieldError.
+func validationError(data []byte, positions map[string]int64, err error) error {
+	pe := &ParseError{Msg: err.Error()}
+	var fe *FieldError
+	if errors.As(err, &fe) {
+		if offset, ok := positions[fe.Field]; ok {
+			pe.Line, pe.Col = lineCol(data, offset)
+		}
+	}
+	return pe
+}
+
+// FieldError reports an invalid value for a dotted config key.
+type FieldError struct {
+	Field string
+	Msg   string
+}
+
+func (e *FieldError) Error() string {
+	return e.Field + ": " + e.Msg
+}
+
+// Validate checks value ranges and enumerations.
+func (c Config) Validate() error {
+	if c.Version < 0 || c.Version > CurrentVersion {
+		return &FieldError{"version", fmt.Sprintf("unsupported version %d (latest is %d)", c.Version, CurrentVersion)}
+	}
+	if time.Duration(c.PollInterval) < minPollInterval {
+		return &FieldError{"poll_interval", fmt.Sprintf("must be at least %s", minPollInterval)}
+	}
+	if c.Capture.MinLines < 1 {
+		return &FieldError{"capture.min_lines", "must be at least 1"}
+	}
+	if c.Capture.MaxLines < c.Capture.MinLines {
+		return &FieldError{"capture.max_lines", "must not be smaller than capture.min_lines"}
+	}
+	if c.Capture.MaxPerTick < 1 {
+		return &FieldError{"capture.max_per_tick", "must be at least 1"}
+	}
+	if c.StaleThreshold <= 0 {
+		return &FieldError{"stale_threshold", "must be positive"}
+	}
+	if !slices.Contains(Themes, c.Theme) {
+		return &FieldError{"theme", fmt.Sprintf("unknown theme %q (want one of %s)", c.Theme, strings.Join(Themes, ", "))}
+	}
+	if !slices.Contains(SortModes, c.Sort) {
+		return &FieldError{"sort", fmt.Sprintf("unknown sort %q (want one of %s)", c.Sort, strings.Join(SortModes, ", "))}
+	}
+	if !slices.Contains(GroupModes, c.Group) {
+		return &FieldError{"group", fmt.Sprintf("unknown group %q (want one of %s)", c.Group, strings.Join(GroupModes, ", "))}
+	}
+	for _, pattern := range c.Hidden {
+		if _, err := path.Match(pattern, ""); err != nil || strings.TrimSpace(pattern) == "" {
+			return &FieldError{"hidden", fmt.Sprintf("invalid pattern %q", pattern)}
+		}
+	}
+	for i, rule := range c.HideRules {
+		if err := rule.validate(fmt.Sprintf("hide_rules[%d]", i)); err != nil {
+			return err
+		}
+	}
+	names := make(map[string]bool, len(c.Snippets))
+	for i, snippet := range c.Snippets {
+		field := fmt.Sprintf("snippets[%d]", i)
+		if err := snippet.validate(field); err != nil {
+			return err
+		}
+		if names[snippet.Name] {
+			return &FieldError{field + ".name", fmt.Sprintf("duplicate snippet %q", snippet.Name)}
+		}
+		names[snippet.Name] = true
+	}
+	rules := make(map[string]bool, len(c.Alerts))
+	for i, rule := range c.Alerts {
+		field := fmt.Sprintf("alerts[%d]", i)
+		if err := rule.validate(field); err != nil {
+			return err
+		}
+		// Active alerts are tracked by rule name, so two rules sharing one
+		// would overwrite each other's alerts.
+		name := rule.DisplayName()
+		if rules[name] {
+			if strings.TrimSpace(rule.Name) == "" {
+				return &FieldError{field + ".pattern", fmt.Sprintf("duplicate alert rule %q (give one a name)", name)}
+			}
+			return &FieldError{field + ".name", fmt.Sprintf("duplicate alert rule %q", name)}
+		}
+		rules[name] = true
+	}
+	if c.Monitors.Silence <= 0 {
+		return &FieldError{"monitors.silence", "must be positive"}
+	}
+	if c.Monitors.ResumeAfter <= 0 {
+		return &FieldError{"monitors.resume_after", "must be positive"}
+	}
+	if err := c.Stale.validate(); err != nil {
+		return err
+	}
+	if !slices.Contains(JumpModes, c.Jump.Outside) {
+		return &FieldError{"jump.outside", fmt.Sprintf("unknown mode %q (want one of %s)", c.Jump.Outside, strings.Join(JumpModes, ", "))}
+	}
+	if time.Duration(c.History.Retention) < minRetention {
+		return &FieldError{"history.retention", fmt.Sprintf("must be at least %s", minRetention)}
+	}
+	return c.Notify.validate()
+}
+
+// validate checks the notification settings.
+func (n Notify) validate() error {
+	if !slices.Contains(NotifyModes, n.On) {
+		return &FieldError{"notify.on", fmt.Sprintf("unknown mode %q (want one of %s)", n.On, strings.Join(NotifyModes, ", "))}
+	}
+	for _, method := range n.Methods {
+		if !slices.Contains(NotifyMethods, method) {
+			return &FieldError{"notify.methods", fmt.Sprintf("unknown method %q (want one of %s)", method, strings.Join(NotifyMethods, ", "))}
+		}
+	}
+	if slices.Contains(n.Methods, "command") && strings.TrimSpace(n.Command) == "" {
+		return &FieldError{"notify.methods", `"command" needs notify.command`}
+	}
+	return nil
+}
+
+// DisplayName is the name alerts from the rule are shown and tracked under:
+// its name, or its pattern when unnamed.
+func (r AlertRule) DisplayName() string {
+	if name := strings.TrimSpace(r.Name); name != "" {
+		return name
+	}
+	return r.Pattern
+}
+
+// validate checks a single alert rule; field is its path in the document.
+func (r AlertRule) validate(field string) error {
+	if strings.TrimSpace(r.Pattern) == "" {
+		return &FieldError{field, "pattern is required"}
+	}
+	if _, err := regexp.Compile(r.Pattern); err != nil {
+		return &FieldError{field + ".pattern", fmt.Sprintf("invalid regexp: %v", err)}
+	}
+	if _, err := path.Match(r.Session, ""); err != nil {
+		return &FieldError{field + ".session", fmt.Sprintf("invalid pattern %q", r.Session)}
+	}
+	if _, err := path.Match(r.Pane, ""); err != nil {
+		return &FieldError{field + ".pane", fmt.Sprintf("invalid pattern %q", r.Pane)}
+	}
+	if r.Cooldown < 0 {
+		return &FieldError{field + ".cooldown", "must not be negative"}
+	}
+	for _, act := range r.Actions {
+		if !slices.Contains(AlertActions, act) {
+			return &FieldError{field + ".actions", fmt.Sprintf("unknown action %q (want one of %s)", act, strings.Join(AlertActions, ", "))}
+		}
+	}
+	return nil
+}
+
+// validate checks a hide rule's globs.
+func (r HideRule) validate(field string) error {
+	if strings.TrimSpace(r.Session) == "" && strings.TrimSpace(r.Command) == "" {
+		return &FieldError{field, "needs a session or command pattern"}
+	}
+	if _, err := path.Match(r.Session, ""); err != nil {
+		return &FieldError{field + ".session", fmt.Sprintf("invalid pattern %q", r.Session)}
+	}
+	if _, err := path.Match(r.Command, ""); err != nil {
+		return &FieldError{field + ".command", fmt.Sprintf("invalid pattern %q", r.Command)}
+	}
+	return nil
+}
+
+// validate checks that a snippet has a name and a command using only known
+// placeholders.
+func (s Snippet) validate(field string) error {
+	if strings.TrimSpace(s.Name) == "" {
+		return &FieldError{field + ".name", "must not be empty"}
+	}
+	if strings.TrimSpace(s.Command) == "" {
+		return &FieldError{field + ".command", "must not be empty"}
+	}
+	for _, match := range snippetPlaceholder.FindAllStringSubmatch(s.Command, -1) {
+		if !slices.Contains(SnippetPlaceholders, match[1]) {
+			return &FieldError{field + ".command", fmt.Sprintf("unknown placeholder %s (want one of {%s})", match[0], strings.Join(SnippetPlaceholders, "}, {"))}
+		}
+	}
+	return nil
+}
+
+// validate checks the stale policy.
+func (s Stale) validate() error {
+	for i, rule := range s.Rules {
+		field := fmt.Sprintf("stale.rules[%d]", i)
+		if _, err := path.Match(rule.Session, ""); err != nil || strings.TrimSpace(rule.Session) == "" {
+			return &FieldError{field + ".session", fmt.Sprintf("invalid pattern %q", rule.Session)}
+		}
+		if rule.After <= 0 {
+			return &FieldError{field + ".after", "must be positive"}
+		}
+	}
+	for _, list := range []struct {
+		field    string
+		patterns []string
+	}{{"stale.exempt", s.Exempt}, {"stale.protect", s.Protect}} {
+		for _, pattern := range list.patterns {
+			if _, err := path.Match(pattern, ""); err != nil || strings.TrimSpace(pattern) == "" {
+				return &FieldError{list.field, fmt.Sprintf("invalid pattern %q", pattern)}
+			}
+		}
+	}
+	for _, signal := range s.Signals {
+		if !slices.Contains(StaleSignals, signal) {
+			return &FieldError{"stale.signals", fmt.Sprintf("unknown signal %q (want one of %s)", signal, strings.Join(StaleSignals, ", "))}
+		}
+	}
+	if s.CPU <= 0 {
+		return &FieldError{"stale.cpu", "must be positive"}
+	}
+	return nil
+}
+
+// Print writes the configuration as indented JSON.
+func (c Config) Print() ([]byte, error) {
+	out, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(out, '\n'), nil
+}
+
+// decodeError converts JSON decoding failures into a located *ParseError.
+func decodeError(data []byte, positions map[string]int64, err error) error {
+	var syntaxErr *json.SyntaxError
+	var typeErr *json.UnmarshalTypeError
+	pe := &ParseError{Msg: err.Error()}
+	switch {
+	case errors.As(err, &syntaxErr):
+		// Offset counts the bytes read including the offending one.
+		pe.Line, pe.Col = lineCol(data, max(syntaxErr.Offset-1, 0))
+		pe.Msg = syntaxErr.Error()
+	case errors.As(err, &typeErr):
+		want := typeErr.Type.String()
+		if typeErr.Type == durationType {
+			want = `a duration like "30s"`
+		}
+		field := typeErr.Field
+		if field == "" {
+			// Errors from custom unmarshalers carry no field context, so find
+			// the first key in the document whose raw value matches; decoding
+			// stops at the first bad value.
+			for _, candidate := range keysByOffset(positions) {
+				if rawValueAt(data, positions[candidate]) == typeErr.Value {
+					field = candidate
+					break
+				}
+			}
+		}
+		pe.Msg = fmt.Sprintf("%s: expected %s, got %s", field, want, typeErr.Value)
+		if offset, ok := positions[field]; ok {
+			pe.Line, pe.Col = lineCol(data, offset)
+		} else {
+			pe.Line, pe.Col = lineCol(data, typeErr.Offset)
+		}
+	default:
+		if name, ok := strings.CutPrefix(pe.Msg, "json: unknown field "); ok {
+			name = strings.Trim(name, `"`)
+			pe.Msg = fmt.Sprintf("unknown key %q", name)
+			best := int64(-1)
+			for field, offset := range positions {
+				if field != name && !strings.HasSuffix(field, "."+name) {
+					continue
+				}
+				if best < 0 || offset < best {
+					best = offset
+					pe.Msg = fmt.Sprintf("unknown key %q", field)
+				}
+			}
+			if best >= 0 {
+				pe.Line, pe.Col = lineCol(data, best)
+			}
+		}
+	}
+	return pe
+}
+
+// keyOffsets walks the document and records the byte offset of every object
+// key, indexed by its dotted path (e.g. "capture.max_lines" or
+// "alerts[1].pattern").
+func keyOffsets(data []byte) map[string]int64 {
+	positions := make(map[string]int64)
+	dec := json.NewDecoder(bytes.NewReader(data))
+	type frame struct {
+		object    bool
+		expectKey bool
+		key       string
+		index     int
+	}
+	var stack []frame
+	pathOf := func() string {
+		var b strings.Builder
+		for _, f := range stack {
+			switch {
+			case !f.object:
+				fmt.Fprintf(&b, "[%d]", f.index)
+			case f.key != "":
+				if b.Len() > 0 {
+					b.WriteByte('.')
+				}
+				b.WriteString(f.key)
+			}
+		}
+		return b.String()
+	}
+	beginValue := func() {
+		if n := len(stack); n > 0 && !stack[n-1].object {
+			stack[n-1].index++
+		}
+	}
+	afterValue := func() {
+		if n := len(stack); n > 0 && stack[n-1].object {
+			stack[n-1].expectKey = true
+		}
+	}
+	for {
+		offset := dec.InputOffset()
+		tok, err := dec.Token()
+		if err != nil {
+			return positions
+		}
+		switch v := tok.(type) {
+		case json.Delim:
+			switch v {
+			case '{':
+				beginValue()
+				stack = append(stack, frame{object: true, expectKey: true})
+			case '[':
+				beginValue()
+				stack = append(stack, frame{index: -1})
+			case '}', ']':
+				if len(stack) > 0 {
+					stack = stack[:len(stack)-1]
+				}
+				afterValue()
+			}
+		default:
+			if n := len(stack); n > 0 && stack[n-1].object && stack[n-1].expectKey {
+				key, _ := v.(string)
+				stack[n-1].key = key
+				stack[n-1].expectKey = false
+				positions[pathOf()] = offset + int64(leadingSpace(data[offset:]))
+				continue
+			}
+			beginValue()
+			afterValue()
+		}
+	}
+}
+
+// keysByOffset returns the keys in positions in document order.
+func keysByOffset(positions map[string]int64) []string {
+	keys := slices.Collect(maps.Keys(positions))
+	slices.SortFunc(keys, func(a, b string) int {
+		return cmp.Compare(positions[a], positions[b])
+	})
+	return keys
+}
+
+// rawValueAt returns the raw JSON value following the key at offset.
+func rawValueAt(data []byte, offset int64) string {
+	dec := json.NewDecoder(bytes.NewReader(data[offset:]))
+	if _, err := dec.Token(); err != nil {
+		return ""
+	}
+	rest := data[offset+dec.InputOffset():]
+	rest = rest[leadingSpace(rest):]
+	var raw json.RawMessage
+	if err := json.NewDecoder(bytes.NewReader(rest)).Decode(&raw); err != nil {
+		return ""
+	}
+	return string(raw)
+}
+
+// leadingSpace counts whitespace and separators before the next token.
+func leadingSpace(data []byte) int {
+	for i, b := range data {
+		switch b {
+		case ' ', '\t', '\n', '\r', ',', ':':
+			continue
+		default:
+			return i
+		}
+	}
+	return len(data)
+}
+
+// lineCol converts a byte offset into 1-based line and column numbers.
+func lineCol(data []byte, offset int64) (int, int) {
+	if offset > int64(len(data)) {
+		offset = int64(len(data))
+	}
+	line, col := 1, 1
+	for _, b := range data[:offset] {
+		if b == '\n' {
+			line++
+			col = 1
+			continue
+		}
+		col++
+	}
+	return line, col
+}
@@ -0,0 +1,176 @@
+// File config_test.go covers configuration discovery and parsing.
+package config
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestLoadMissingFile returns defaults when no config exists.
+func TestLoadMissingFile(t *testing.T) {
+	t.Parallel()
+
+	cfg, err := Load(filepath.Join(t.TempDir(), "absent.json"))
+	if err != nil {
+		t.Fatalf("Load returned error: %v", err)
+	}
+	if !reflect.DeepEqual(cfg, Default()) {
+		t.Fatalf("expected defaults, got %+v", cfg)
+	}
+}
+
+// TestParseMergesDefaults keeps defaults for keys the file omits.
+func TestParseMergesDefaults(t *testing.T) {
+	t.Parallel()
+
+	cfg, err := Parse([]byte(`{"version": 1, "poll_interval": "2s", "capture": {"max_lines": 900}, "hidden": ["scratch-*"], "record": {"dir": "/tmp/casts"}, "export": {"ansi": true}}`))
+	if err != nil {
+		t.Fatalf("Parse returned error: %v", err)
+	}
+	if time.Duration(cfg.PollInterval) != 2*time.Second {
+		t.Fatalf("poll interval = %v, want 2s", time.Duration(cfg.PollInterval))
+	}
+	if cfg.Capture.MaxLines != 900 || cfg.Capture.MinLines != DefaultMinCaptureLines {
+		t.Fatalf("capture = %+v, want max 900 and default min", cfg.Capture)
+	}
+	if time.Duration(cfg.StaleThreshold) != DefaultStaleThreshold {
+		t.Fatalf("stale threshold = %v, want default", time.Duration(cfg.StaleThreshold))
+	}
+	if len(cfg.Hidden) != 1 || cfg.Hidden[0] != "scratch-*" {
+		t.Fatalf("hidden = %v, want [scratch-*]", cfg.Hidden)
+	}
+	if !cfg.Archive.Enabled || cfg.Archive.Dir != "" {
+		t.Fatalf("archive = %+v, want enabled with the default dir", cfg.Archive)
+	}
+	if cfg.Record.Dir != "/tmp/casts" {
+		t.Fatalf("record dir = %q, want /tmp/casts", cfg.Record.Dir)
+	}
+	if !cfg.Export.ANSI || cfg.Export.Dir != "" {
+		t.Fatalf("export = %+v, want ANSI kept in the default dir", cfg.Export)
+	}
+}
+
+// TestParseErrorsCarryLineNumbers points invalid documents at the offending
+// line and column.
+func TestParseErrorsCarryLineNumbers(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		doc  string
+		want string
+	}{
+		{name: "syntax", doc: "{\n  \"theme\": 3,\n}", want: "line 3, col 1"},
+		{name: "bad duration", doc: "{\n  \"poll_interval\": \"soon\"\n}", want: "line 2, col 3: poll_interval: expected a duration"},
+		{name: "repeated bad value", doc: "{\n  \"stale_threshold\": \"soon\",\n  \"poll_interval\": \"soon\"\n}", want: "line 2, col 3: stale_threshold: expected a duration"},
+		{name: "wrong type", doc: "{\n\n  \"capture\": {\"min_lines\": \"many\"}\n}", want: "line 3, col 15: capture.min_lines: expected int"},
+		{name: "unknown key", doc: "{\n  \"capture\": {\n    \"lines\": 5\n  }\n}", want: "line 3, col 5: unknown key \"capture.lines\""},
+		{name: "range", doc: "{\n  \"capture\": {\"min_lines\": 10, \"max_lines\": 5}\n}", want: "line 2, col 32: capture.max_lines"},
+		{name: "future version", doc: "{\"version\": 9}", want: "line 1, col 2: version: unsupported version 9"},
+		{name: "bad pattern", doc: "{\"hidden\": [\"[\"]}", want: "hidden: invalid pattern"},
+		{name: "empty hide rule", doc: "{\"hide_rules\": [{\"session\": \"x\"}, {}]}", want: "hide_rules[1]: needs a session or command pattern"},
+		{name: "bad hide command", doc: "{\"hide_rules\": [{\"command\": \"[\"}]}", want: "hide_rules[0].command: invalid pattern"},
+		{name: "bad alert regexp", doc: "{\"alerts\": [\n  {\"pattern\": \"ok\"},\n  {\"pattern\": \"(\"}\n]}", want: "line 3, col 4: alerts[1].pattern: invalid regexp"},
+		{name: "bad alert action", doc: "{\"alerts\": [{\"pattern\": \"x\", \"actions\": [\"email\"]}]}", want: "alerts[0].actions: unknown action \"email\""},
+		{name: "duplicate alert name", doc: "{\"alerts\": [\n  {\"name\": \"err\", \"pattern\": \"a\"},\n  {\"name\": \"err\", \"pattern\": \"b\"}\n]}", want: "line 3, col 4: alerts[1].name: duplicate alert rule \"err\""},
+		{name: "duplicate unnamed alert", doc: "{\"alerts\": [{\"pattern\": \"x\"}, {\"pattern\": \"x\", \"session\": \"api\"}]}", want: "alerts[1].pattern: duplicate alert rule \"x\" (give one a name)"},
+		{name: "bad notify mode", doc: "{\n  \"notify\": {\"on\": \"never\"}\n}", want: "line 2, col 14: notify.on: unknown mode"},
+		{name: "bad silence", doc: "{\"monitors\": {\"silence\": \"0s\"}}", want: "line 1, col 15: monitors.silence: must be positive"},
+		{name: "bad stale signal", doc: "{\"stale\": {\"signals\": [\"mouse\"]}}", want: "line 1, col 12: stale.signals: unknown signal \"mouse\" (want one of pane, client, cpu)"},
+		{name: "bad stale rule", doc: "{\"stale\": {\"rules\": [{\"session\": \"db-*\", \"after\": \"0s\"}]}}", want: "line 1, col 42: stale.rules[0].after: must be positive"},
+		{name: "short retention", doc: "{\"history\": {\"retention\": \"1h\"}}", want: "line 1, col 14: history.retention: must be at least 24h0m0s"},
+		{name: "bad snippet placeholder", doc: "{\"snippets\": [{\"name\": \"logs\", \"command\": \"tail {log}\"}]}", want: "snippets[0].command: unknown placeholder {log}"},
+		{name: "duplicate snippet", doc: "{\"snippets\": [{\"name\": \"t\", \"command\": \"make\"}, {\"name\": \"t\", \"command\": \"go test\"}]}", want: "snippets[1].name: duplicate snippet \"t\""},
+		{name: "bad jump mode", doc: "{\"jump\": {\"outside\": \"detach\"}}", want: "line 1, col 11: jump.outside: unknown mode \"detach\" (want one of exec, print)"},
+		{name: "notify command missing", doc: "{\"notify\": {\"methods\": [\"command\"]}}", want: "notify.methods: \"command\" needs notify.command"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			_, err := Parse([]byte(tt.doc))
+			if err == nil {
+				t.Fatal("expected error")
+			}
+			var pe *ParseError
+			if !errors.As(err, &pe) {
+				t.Fatalf("error %T is not a *ParseError", err)
+			}
+			if !strings.Contains(err.Error(), tt.want) {
+				t.Fatalf("error = %q, want substring %q", err, tt.want)
+			}
+		})
+	}
+}
+
+// TestPrintRoundTrips emits JSON that parses back to the same config.
+func TestPrintRoundTrips(t *testing.T) {
+	t.Parallel()
+
+	cfg := Default()
+	cfg.Hidden = []string{"tmp-*"}
+	out, err := cfg.Print()
+	if err != nil {
+		t.Fatalf("Print returned error: %v", err)
+	}
+	parsed, err := Parse(out)
+	if err != nil {
+		t.Fatalf("Parse(Print()) returned error: %v", err)
+	}
+	if !reflect.DeepEqual(parsed, cfg) {
+		t.Fatalf("round trip mismatch:\n got %+v\nwant %+v", parsed, cfg)
+	}
+}
+
+// TestLoadKeymap parses leader and binding overrides.
+func TestLoadKeymap(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "config.json")
+	doc := `{"keymap": {"leader": "ctrl+b", "bindings": {"quit": ["Q"], "collapse": []}}}`
+	if err := os.WriteFile(path, []byte(doc), 0o600); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+	cfg, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load returned error: %v", err)
+	}
+	if cfg.Keymap.Leader != "ctrl+b" {
+		t.Fatalf("leader = %q, want ctrl+b", cfg.Keymap.Leader)
+	}
+	if got := cfg.Keymap.Bindings["quit"]; len(got) != 1 || got[0] != "Q" {
+		t.Fatalf("quit bindings = %v, want [Q]", got)
+	}
+	if got, ok := cfg.Keymap.Bindings["collapse"]; !ok || len(got) != 0 {
+		t.Fatalf("collapse bindings = %v (present %v), want explicit empty list", got, ok)
+	}
+}
+
+// TestLoadInvalidJSON surfaces parse failures.
+func TestLoadInvalidJSON(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "config.json")
+	if err := os.WriteFile(path, []byte("{"), 0o600); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+	if _, err := Load(path); err == nil {
+		t.Fatal("expected parse error")
+	}
+}
+
+// TestDirHonoursXDG prefers $XDG_CONFIG_HOME when set.
+func TestDirHonoursXDG(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", "/tmp/xdg")
+	dir, err := Dir()
+	if err != nil {
+		t.Fatalf("Dir returned error: %v", err)
+	}
+	if dir != filepath.Join("/tmp/xdg", "tmuxwatch") {
+		t.Fatalf("Dir() = %q, want /tmp/xdg/tmuxwatch", dir)
+	}
+}
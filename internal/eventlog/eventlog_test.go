@@ -0,0 +1,58 @@
+// File eventlog_test.go covers appending timeline events to disk.
+package eventlog
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// TestAppendWritesJSONLines appends across reopenings, one entry per line.
+func TestAppendWritesJSONLines(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "nested", "events.jsonl")
+	at := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	want := []Entry{
+		{Time: at, Kind: "session", Session: "ci", Text: "session ci created"},
+		{Time: at.Add(time.Second), Kind: "action", Session: "ci", Text: "killed session ci"},
+	}
+	for _, e := range want {
+		w, err := Open(path)
+		if err != nil {
+			t.Fatalf("Open returned error: %v", err)
+		}
+		if err := w.Append(e); err != nil {
+			t.Fatalf("Append returned error: %v", err)
+		}
+		if err := w.Close(); err != nil {
+			t.Fatalf("Close returned error: %v", err)
+		}
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatalf("open log: %v", err)
+	}
+	defer f.Close()
+	scanner := bufio.NewScanner(f)
+	var got []Entry
+	for scanner.Scan() {
+		var e Entry
+		if err := json.Unmarshal(scanner.Bytes(), &e); err != nil {
+			t.Fatalf("decode line %q: %v", scanner.Text(), err)
+		}
+		got = append(got, e)
+	}
+	if len(got) != len(want) {
+		t.Fatalf("got %d entries, want %d", len(got), len(want))
+	}
+	for i := range want {
+		if !got[i].Time.Equal(want[i].Time) || got[i].Kind != want[i].Kind || got[i].Text != want[i].Text {
+			t.Fatalf("entry %d = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+}
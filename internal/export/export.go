@@ -0,0 +1,36 @@
+// Package export saves pane text exported from tmuxwatch, one timestamped
+// file per export.
+package export
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/steipete/tmuxwatch/internal/store"
+)
+
+// Dir returns the default export directory inside the tmuxwatch state
+// directory.
+func Dir() (string, error) {
+	dir, err := store.Dir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "exports"), nil
+}
+
+// Write saves text exported from a session's pane at at under dir and
+// returns the new file. Scope names what was exported, e.g. "scrollback".
+func Write(dir, session, paneID, scope string, at time.Time, text string) (string, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return "", fmt.Errorf("create export dir: %w", err)
+	}
+	name := fmt.Sprintf("%s-%s-%s-%s.txt", at.Format("20060102-150405"), store.SafeName(session), store.SafeName(paneID), scope)
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, []byte(text), 0o600); err != nil {
+		return "", fmt.Errorf("write export: %w", err)
+	}
+	return path, nil
+}
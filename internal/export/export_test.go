@@ -0,0 +1,28 @@
+// File export_test.go covers writing export files.
+package export
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// TestWrite names the file after the time, session, pane, and scope.
+func TestWrite(t *testing.T) {
+	t.Parallel()
+
+	dir := filepath.Join(t.TempDir(), "exports")
+	at := time.Date(2026, 10, 18, 14, 2, 0, 0, time.UTC)
+	path, err := Write(dir, "ci/build", "%3", "screen", at, "FAIL\n")
+	if err != nil {
+		t.Fatalf("Write returned error: %v", err)
+	}
+	if filepath.Base(path) != "20261018-140200-ci_build-_3-screen.txt" {
+		t.Fatalf("path = %s", path)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil || string(data) != "FAIL\n" {
+		t.Fatalf("content = %q (%v)", data, err)
+	}
+}
@@ -0,0 +1,299 @@
+// Package history keeps an append-only record of pane activity across
+// tmuxwatch runs: periodic output samples, pane starts, and exits with their
+// codes. Records live in one JSON Lines file per local day so retention is a
+// matter of deleting old files.
+package history
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/steipete/tmuxwatch/internal/store"
+)
+
+// Record kinds.
+const (
+	// KindSample covers Seconds of a running pane and the Lines it printed.
+	KindSample = "sample"
+	// KindStart marks a pane that appeared while tmuxwatch was running.
+	KindStart = "start"
+	// KindExit marks a pane whose process ended with ExitCode.
+	KindExit = "exit"
+	// KindClosed marks a running pane that disappeared.
+	KindClosed = "closed"
+)
+
+// dayLayout names the per-day files.
+const dayLayout = "2006-01-02"
+
+// Record is one history entry.
+type Record struct {
+	Time     time.Time `json:"time"`
+	Kind     string    `json:"kind"`
+	Session  string    `json:"session"`
+	Window   string    `json:"window,omitempty"`
+	Pane     string    `json:"pane"`
+	Command  string    `json:"command,omitempty"`
+	Seconds  float64   `json:"seconds,omitempty"`
+	Lines    int       `json:"lines,omitempty"`
+	ExitCode int       `json:"exit_code,omitempty"`
+}
+
+// Dir returns the history directory inside the tmuxwatch state directory.
+func Dir() (string, error) {
+	dir, err := store.Dir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "history"), nil
+}
+
+// Store appends records to the file for each record's day. It is safe for
+// concurrent use.
+type Store struct {
+	mu  sync.Mutex
+	dir string
+	day string
+	f   *os.File
+	enc *json.Encoder
+}
+
+// Open prepares dir for appending, creating it when missing.
+func Open(dir string) (*Store, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("create history dir: %w", err)
+	}
+	return &Store{dir: dir}, nil
+}
+
+// Append writes records, switching files when the day changes.
+func (s *Store) Append(records ...Record) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, r := range records {
+		day := r.Time.Local().Format(dayLayout)
+		if day != s.day || s.f == nil {
+			if err := s.rotate(day); err != nil {
+				return err
+			}
+		}
+		if err := s.enc.Encode(r); err != nil {
+			return fmt.Errorf("write history: %w", err)
+		}
+	}
+	return nil
+}
+
+// rotate closes the current file and opens the one for day.
+func (s *Store) rotate(day string) error {
+	if s.f != nil {
+		if err := s.f.Close(); err != nil {
+			return fmt.Errorf("close history: %w", err)
+		}
+		s.f = nil
+	}
+	f, err := os.OpenFile(filepath.Join(s.dir, day+".jsonl"), os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0o600)
+	if err != nil {
+		return fmt.Errorf("open history: %w", err)
+	}
+	s.f, s.enc, s.day = f, json.NewEncoder(f), day
+	return nil
+}
+
+// Close closes the open day file, if any.
+func (s *Store) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.f == nil {
+		return nil
+	}
+	err := s.f.Close()
+	s.f = nil
+	return err
+}
+
+// days lists the day files in dir with their dates, oldest first.
+func days(dir string) ([]time.Time, error) {
+	entries, err := os.ReadDir(dir)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read history dir: %w", err)
+	}
+	var out []time.Time
+	for _, entry := range entries {
+		name, ok := strings.CutSuffix(entry.Name(), ".jsonl")
+		if !ok || entry.IsDir() {
+			continue
+		}
+		day, err := time.ParseInLocation(dayLayout, name, time.Local)
+		if err != nil {
+			continue
+		}
+		out = append(out, day)
+	}
+	slices.SortFunc(out, time.Time.Compare)
+	return out, nil
+}
+
+// Prune deletes day files that ended more than retention before now.
+func Prune(dir string, retention time.Duration, now time.Time) error {
+	list, err := days(dir)
+	if err != nil {
+		return err
+	}
+	cutoff := now.Add(-retention)
+	for _, day := range list {
+		if day.AddDate(0, 0, 1).After(cutoff) {
+			break
+		}
+		if err := os.Remove(filepath.Join(dir, day.Format(dayLayout)+".jsonl")); err != nil {
+			return fmt.Errorf("prune history: %w", err)
+		}
+	}
+	return nil
+}
+
+// Compact merges the samples of every finished day into one record per pane
+// and hour. Starts and exits are kept as they are. Files that are already
+// compact are left untouched.
+func Compact(dir string, now time.Time) error {
+	list, err := days(dir)
+	if err != nil {
+		return err
+	}
+	today := now.Local().Format(dayLayout)
+	for _, day := range list {
+		name := day.Format(dayLayout)
+		if name >= today {
+			continue
+		}
+		path := filepath.Join(dir, name+".jsonl")
+		records, err := readFile(path)
+		if err != nil {
+			return err
+		}
+		compacted := compact(records, time.Local)
+		if len(compacted) == len(records) {
+			continue
+		}
+		if err := writeFile(path, compacted); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// compact folds samples into hourly buckets per pane, keyed by the hour in
+// loc the sample ended in. Buckets follow the wall clock, so zones with a
+// half-hour offset still split on the hour.
+func compact(records []Record, loc *time.Location) []Record {
+	type bucket struct {
+		session, pane string
+		hour          time.Time
+	}
+	index := make(map[bucket]int)
+	var out []Record
+	for _, r := range records {
+		if r.Kind != KindSample {
+			out = append(out, r)
+			continue
+		}
+		key := bucket{r.Session, r.Pane, hourOf(r.Time, loc)}
+		if i, ok := index[key]; ok {
+			merged := &out[i]
+			merged.Seconds += r.Seconds
+			merged.Lines += r.Lines
+			if r.Time.After(merged.Time) {
+				merged.Time = r.Time
+				merged.Command = r.Command
+				merged.Window = r.Window
+			}
+			continue
+		}
+		index[key] = len(out)
+		out = append(out, r)
+	}
+	slices.SortStableFunc(out, func(a, b Record) int { return a.Time.Compare(b.Time) })
+	return out
+}
+
+// hourOf returns the start of the wall-clock hour containing t in loc.
+func hourOf(t time.Time, loc *time.Location) time.Time {
+	t = t.In(loc)
+	return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), 0, 0, 0, loc)
+}
+
+// writeFile replaces path with records via a temporary file so a crash never
+// leaves a half-written day.
+func writeFile(path string, records []Record) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".compact-*")
+	if err != nil {
+		return fmt.Errorf("compact history: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+	w := bufio.NewWriter(tmp)
+	enc := json.NewEncoder(w)
+	for _, r := range records {
+		if err := enc.Encode(r); err != nil {
+			tmp.Close()
+			return fmt.Errorf("compact history: %w", err)
+		}
+	}
+	if err := w.Flush(); err != nil {
+		tmp.Close()
+		return fmt.Errorf("compact history: %w", err)
+	}
+	if err := tmp.Chmod(0o600); err != nil {
+		tmp.Close()
+		return fmt.Errorf("compact history: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("compact history: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("compact history: %w", err)
+	}
+	return nil
+}
+
+// readFile decodes one day file. A torn final line from a crash is skipped.
+func readFile(path string) ([]Record, error) {
+	f, err := os.Open(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read history: %w", err)
+	}
+	defer f.Close()
+	var out []Record
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		var r Record
+		if err := json.Unmarshal(scanner.Bytes(), &r); err != nil {
+			continue
+		}
+		out = append(out, r)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("read history: %w", err)
+	}
+	return out, nil
+}
+
+// Load returns the records of the local day containing day.
+func Load(dir string, day time.Time) ([]Record, error) {
+	return readFile(filepath.Join(dir, day.Local().Format(dayLayout)+".jsonl"))
+}
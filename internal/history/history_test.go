@@ -0,0 +1,160 @@
+// File history_test.go covers day files, retention, and compaction.
+package history
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// TestAppendSplitsByDay writes each record to its own day's file.
+func TestAppendSplitsByDay(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	s, err := Open(dir)
+	if err != nil {
+		t.Fatalf("Open returned error: %v", err)
+	}
+	day := time.Date(2026, 10, 17, 23, 59, 0, 0, time.Local)
+	err = s.Append(
+		Record{Time: day, Kind: KindSample, Session: "api", Pane: "%1", Seconds: 60, Lines: 5},
+		Record{Time: day.Add(2 * time.Minute), Kind: KindExit, Session: "api", Pane: "%1", ExitCode: 1},
+	)
+	if err != nil {
+		t.Fatalf("Append returned error: %v", err)
+	}
+	if err := s.Close(); err != nil {
+		t.Fatalf("Close returned error: %v", err)
+	}
+
+	first, err := Load(dir, day)
+	if err != nil {
+		t.Fatalf("Load returned error: %v", err)
+	}
+	second, err := Load(dir, day.Add(time.Hour))
+	if err != nil {
+		t.Fatalf("Load returned error: %v", err)
+	}
+	if len(first) != 1 || first[0].Kind != KindSample {
+		t.Fatalf("first day = %+v, want the sample", first)
+	}
+	if len(second) != 1 || second[0].ExitCode != 1 {
+		t.Fatalf("second day = %+v, want the exit", second)
+	}
+}
+
+// TestPruneDropsExpiredDays removes days that ended before the retention
+// window and keeps the rest.
+func TestPruneDropsExpiredDays(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	for _, name := range []string{"2026-10-01.jsonl", "2026-10-16.jsonl", "2026-10-18.jsonl", "notes.txt"} {
+		if err := os.WriteFile(filepath.Join(dir, name), nil, 0o600); err != nil {
+			t.Fatalf("write %s: %v", name, err)
+		}
+	}
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.Local)
+	if err := Prune(dir, 7*24*time.Hour, now); err != nil {
+		t.Fatalf("Prune returned error: %v", err)
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatalf("read dir: %v", err)
+	}
+	var got []string
+	for _, e := range entries {
+		got = append(got, e.Name())
+	}
+	want := []string{"2026-10-16.jsonl", "2026-10-18.jsonl", "notes.txt"}
+	if len(got) != len(want) {
+		t.Fatalf("files = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("files = %v, want %v", got, want)
+		}
+	}
+}
+
+// TestCompactMergesHourlySamples folds finished days into hourly samples per
+// pane, keeps exits, and leaves today alone.
+func TestCompactMergesHourlySamples(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	s, err := Open(dir)
+	if err != nil {
+		t.Fatalf("Open returned error: %v", err)
+	}
+	yesterday := time.Date(2026, 10, 17, 9, 0, 0, 0, time.Local)
+	today := yesterday.AddDate(0, 0, 1)
+	var records []Record
+	for i := range 3 {
+		at := yesterday.Add(time.Duration(i+1) * time.Minute)
+		records = append(records, Record{Time: at, Kind: KindSample, Session: "api", Pane: "%1", Seconds: 60, Lines: 10})
+	}
+	records = append(
+		records,
+		Record{Time: yesterday.Add(90 * time.Minute), Kind: KindSample, Session: "api", Pane: "%1", Seconds: 60, Lines: 1},
+		Record{Time: yesterday.Add(91 * time.Minute), Kind: KindExit, Session: "api", Pane: "%1", ExitCode: 2},
+		Record{Time: today, Kind: KindSample, Session: "api", Pane: "%1", Seconds: 60},
+		Record{Time: today.Add(time.Minute), Kind: KindSample, Session: "api", Pane: "%1", Seconds: 60},
+	)
+	if err := s.Append(records...); err != nil {
+		t.Fatalf("Append returned error: %v", err)
+	}
+	if err := s.Close(); err != nil {
+		t.Fatalf("Close returned error: %v", err)
+	}
+
+	if err := Compact(dir, today.Add(time.Hour)); err != nil {
+		t.Fatalf("Compact returned error: %v", err)
+	}
+	old, err := Load(dir, yesterday)
+	if err != nil {
+		t.Fatalf("Load returned error: %v", err)
+	}
+	if len(old) != 3 {
+		t.Fatalf("compacted day = %+v, want 3 records", old)
+	}
+	if old[0].Seconds != 180 || old[0].Lines != 30 || !old[0].Time.Equal(yesterday.Add(3*time.Minute)) {
+		t.Fatalf("merged sample = %+v, want 180s and 30 lines ending 09:03", old[0])
+	}
+	if old[2].Kind != KindExit {
+		t.Fatalf("last record = %+v, want the exit", old[2])
+	}
+	current, err := Load(dir, today)
+	if err != nil {
+		t.Fatalf("Load returned error: %v", err)
+	}
+	if len(current) != 2 {
+		t.Fatalf("today = %+v, want it untouched", current)
+	}
+}
+
+// TestCompactBucketsByLocalHour splits samples on the wall-clock hour in
+// zones whose offset is not a whole number of hours.
+func TestCompactBucketsByLocalHour(t *testing.T) {
+	t.Parallel()
+
+	loc := time.FixedZone("IST", 5*60*60+30*60)
+	at := func(hour, minute int) time.Time { return time.Date(2026, 10, 17, hour, minute, 0, 0, loc) }
+	records := []Record{
+		{Time: at(10, 10), Kind: KindSample, Session: "api", Pane: "%1", Seconds: 60},
+		{Time: at(10, 40), Kind: KindSample, Session: "api", Pane: "%1", Seconds: 60},
+		{Time: at(11, 5), Kind: KindSample, Session: "api", Pane: "%1", Seconds: 60},
+	}
+	got := compact(records, loc)
+	if len(got) != 2 {
+		t.Fatalf("compact = %+v, want 2 hourly samples", got)
+	}
+	if got[0].Seconds != 120 || !got[0].Time.Equal(at(10, 40)) {
+		t.Fatalf("10:00 bucket = %+v, want 120s ending 10:40", got[0])
+	}
+	if got[1].Seconds != 60 || !got[1].Time.Equal(at(11, 5)) {
+		t.Fatalf("11:00 bucket = %+v, want 60s ending 11:05", got[1])
+	}
+}
@@ -0,0 +1,92 @@
+// File summary.go aggregates a day of history into per-session and
+// per-command totals for the `tmuxwatch history` report.
+package history
+
+import (
+	"cmp"
+	"slices"
+	"time"
+)
+
+// SessionSummary totals one session's day.
+type SessionSummary struct {
+	Session string `json:"session"`
+	// Running is how long the session had at least one live pane while
+	// tmuxwatch was watching, summed across panes.
+	Running    time.Duration `json:"running"`
+	Lines      int           `json:"lines"`
+	Exits      int           `json:"exits"`
+	Failures   int           `json:"failures"`
+	LastOutput time.Time     `json:"last_output,omitzero"`
+}
+
+// CommandSummary totals how often a command finished and failed.
+type CommandSummary struct {
+	Command  string        `json:"command"`
+	Runs     int           `json:"runs"`
+	Failures int           `json:"failures"`
+	Total    time.Duration `json:"total"`
+}
+
+// Summary is the report for one day.
+type Summary struct {
+	Sessions []SessionSummary `json:"sessions"`
+	Commands []CommandSummary `json:"commands"`
+}
+
+// Summarize folds records into session totals, busiest first, and command
+// totals, most frequent first.
+func Summarize(records []Record) Summary {
+	sessions := make(map[string]*SessionSummary)
+	commands := make(map[string]*CommandSummary)
+	session := func(name string) *SessionSummary {
+		s, ok := sessions[name]
+		if !ok {
+			s = &SessionSummary{Session: name}
+			sessions[name] = s
+		}
+		return s
+	}
+	for _, r := range records {
+		s := session(r.Session)
+		switch r.Kind {
+		case KindSample:
+			s.Running += time.Duration(r.Seconds * float64(time.Second))
+			s.Lines += r.Lines
+			if r.Lines > 0 && r.Time.After(s.LastOutput) {
+				s.LastOutput = r.Time
+			}
+		case KindExit, KindClosed:
+			s.Exits++
+			name := r.Command
+			if name == "" {
+				name = "(unknown)"
+			}
+			c, ok := commands[name]
+			if !ok {
+				c = &CommandSummary{Command: name}
+				commands[name] = c
+			}
+			c.Runs++
+			c.Total += time.Duration(r.Seconds * float64(time.Second))
+			if r.Kind == KindExit && r.ExitCode != 0 {
+				s.Failures++
+				c.Failures++
+			}
+		}
+	}
+	var out Summary
+	for _, s := range sessions {
+		out.Sessions = append(out.Sessions, *s)
+	}
+	for _, c := range commands {
+		out.Commands = append(out.Commands, *c)
+	}
+	slices.SortFunc(out.Sessions, func(a, b SessionSummary) int {
+		return cmp.Or(cmp.Compare(b.Running, a.Running), cmp.Compare(a.Session, b.Session))
+	})
+	slices.SortFunc(out.Commands, func(a, b CommandSummary) int {
+		return cmp.Or(cmp.Compare(b.Runs, a.Runs), cmp.Compare(a.Command, b.Command))
+	})
+	return out
+}
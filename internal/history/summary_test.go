@@ -0,0 +1,44 @@
+// File summary_test.go covers the daily history report totals.
+package history
+
+import (
+	"testing"
+	"time"
+)
+
+// TestSummarizeTotalsSessionsAndCommands sums run time and output per
+// session and counts runs and failures per command.
+func TestSummarizeTotalsSessionsAndCommands(t *testing.T) {
+	t.Parallel()
+
+	at := time.Date(2026, 10, 18, 9, 0, 0, 0, time.UTC)
+	got := Summarize([]Record{
+		{Time: at, Kind: KindSample, Session: "api-server", Pane: "%1", Seconds: 3600, Lines: 120},
+		{Time: at.Add(time.Hour), Kind: KindSample, Session: "api-server", Pane: "%1", Seconds: 1800},
+		{Time: at, Kind: KindSample, Session: "ci", Pane: "%2", Seconds: 600, Lines: 40},
+		{Time: at.Add(10 * time.Minute), Kind: KindExit, Session: "ci", Pane: "%2", Command: "go", Seconds: 600, ExitCode: 1},
+		{Time: at.Add(20 * time.Minute), Kind: KindExit, Session: "ci", Pane: "%3", Command: "go", Seconds: 30},
+		{Time: at.Add(30 * time.Minute), Kind: KindClosed, Session: "ci", Pane: "%4", Command: "make", Seconds: 5},
+	})
+
+	if len(got.Sessions) != 2 {
+		t.Fatalf("sessions = %+v, want 2", got.Sessions)
+	}
+	api := got.Sessions[0]
+	if api.Session != "api-server" || api.Running != 90*time.Minute || api.Lines != 120 || !api.LastOutput.Equal(at) {
+		t.Fatalf("api-server = %+v", api)
+	}
+	ci := got.Sessions[1]
+	if ci.Exits != 3 || ci.Failures != 1 {
+		t.Fatalf("ci = %+v, want 3 exits and 1 failure", ci)
+	}
+	if len(got.Commands) != 2 {
+		t.Fatalf("commands = %+v, want 2", got.Commands)
+	}
+	if c := got.Commands[0]; c.Command != "go" || c.Runs != 2 || c.Failures != 1 || c.Total != 630*time.Second {
+		t.Fatalf("go = %+v", c)
+	}
+	if c := got.Commands[1]; c.Command != "make" || c.Failures != 0 {
+		t.Fatalf("make = %+v", c)
+	}
+}
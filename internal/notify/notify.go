@@ -0,0 +1,142 @@
+// Package notify delivers pane notifications outside the TUI: desktop
+// notification escape sequences and user commands that receive the event as
+// JSON on stdin.
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+// Event kinds. KindExit is a pane tmux kept after its process exited
+// (remain-on-exit), so the exit code is known; KindClosed is a running pane
+// that disappeared. KindSilence and KindActivity come from silence and
+// activity monitors, KindBell from a tmux window bell.
+const (
+	KindExit     = "exit"
+	KindClosed   = "closed"
+	KindSilence  = "silence"
+	KindActivity = "activity"
+	KindBell     = "bell"
+)
+
+// Event describes a pane transition worth telling the user about.
+type Event struct {
+	Kind      string    `json:"event"`
+	Session   string    `json:"session"`
+	Window    string    `json:"window,omitempty"`
+	Pane      string    `json:"pane"`
+	Title     string    `json:"title,omitempty"`
+	Command   string    `json:"command,omitempty"`
+	ExitCode  int       `json:"exit_code"`
+	StartedAt time.Time `json:"started_at,omitzero"`
+	EndedAt   time.Time `json:"ended_at"`
+	Seconds   float64   `json:"duration_seconds"`
+}
+
+// Failed reports whether the event is an exit with a non-zero status.
+func (e Event) Failed() bool {
+	return e.Kind == KindExit && e.ExitCode != 0
+}
+
+// Duration is the span the event covers: the run time for exits and the
+// quiet time for silence and activity.
+func (e Event) Duration() time.Duration {
+	return time.Duration(e.Seconds * float64(time.Second))
+}
+
+// Summary renders a one-line description such as
+// "make in ci: exited 2 after 12m".
+func (e Event) Summary() string {
+	what := e.Title
+	if what == "" {
+		what = e.Command
+	}
+	if what == "" {
+		what = e.Pane
+	}
+	status, span := "finished", " after "
+	switch {
+	case e.Kind == KindClosed:
+		status = "closed"
+	case e.Kind == KindSilence:
+		status, span = "silent", " for "
+	case e.Kind == KindActivity:
+		status = "output resumed"
+	case e.Kind == KindBell:
+		status = "bell"
+	case e.Failed():
+		status = fmt.Sprintf("exited %d", e.ExitCode)
+	}
+	if d := e.Duration(); d > 0 {
+		status += span + shortDuration(d)
+	}
+	return fmt.Sprintf("%s in %s: %s", what, e.Session, status)
+}
+
+// shortDuration trims sub-second noise from durations.
+func shortDuration(d time.Duration) string {
+	switch {
+	case d >= time.Minute:
+		return d.Round(time.Second).String()
+	case d >= time.Second:
+		return d.Round(100 * time.Millisecond).String()
+	default:
+		return d.Round(time.Millisecond).String()
+	}
+}
+
+// OSC9 builds the iTerm2/Windows Terminal style desktop notification escape.
+func OSC9(msg string) string {
+	return "\x1b]9;" + sanitize(msg) + "\a"
+}
+
+// OSC777 builds the rxvt/foot/WezTerm style desktop notification escape.
+func OSC777(title, body string) string {
+	return "\x1b]777;notify;" + sanitize(title) + ";" + sanitize(body) + "\a"
+}
+
+// Passthrough wraps an escape sequence so tmux forwards it to the outer
+// terminal. tmux only does so with `allow-passthrough on`.
+func Passthrough(seq string) string {
+	return "\x1bPtmux;" + strings.ReplaceAll(seq, "\x1b", "\x1b\x1b") + "\x1b\\"
+}
+
+// sanitize drops control characters that would terminate or corrupt an OSC
+// payload, and semicolons that OSC 777 uses as separators.
+func sanitize(s string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r < 0x20, r == 0x7f:
+			return ' '
+		case r == ';':
+			return ','
+		}
+		return r
+	}, s)
+}
+
+// Run executes command through the shell with the event encoded as JSON on
+// stdin. Output is discarded; a failing command returns its stderr.
+func Run(ctx context.Context, command string, ev Event) error {
+	payload, err := json.Marshal(ev)
+	if err != nil {
+		return err
+	}
+	cmd := exec.CommandContext(ctx, "sh", "-c", command)
+	cmd.Stdin = bytes.NewReader(append(payload, '\n'))
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return fmt.Errorf("notify command: %w: %s", err, msg)
+		}
+		return fmt.Errorf("notify command: %w", err)
+	}
+	return nil
+}
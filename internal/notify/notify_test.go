@@ -0,0 +1,100 @@
+// File notify_test.go covers notification escapes, summaries, and commands.
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// TestSummary describes exits with their status and run time.
+func TestSummary(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		ev   Event
+		want string
+	}{
+		{
+			name: "failure",
+			ev:   Event{Kind: KindExit, Session: "ci", Title: "make", ExitCode: 2, Seconds: 754.3},
+			want: "make in ci: exited 2 after 12m34s",
+		},
+		{
+			name: "success falls back to command",
+			ev:   Event{Kind: KindExit, Session: "ci", Command: "go", Seconds: 1.25},
+			want: "go in ci: finished after 1.3s",
+		},
+		{
+			name: "silence",
+			ev:   Event{Kind: KindSilence, Session: "ci", Title: "tail", Seconds: 600},
+			want: "tail in ci: silent for 10m0s",
+		},
+		{
+			name: "activity",
+			ev:   Event{Kind: KindActivity, Session: "ci", Title: "tail", Seconds: 90},
+			want: "tail in ci: output resumed after 1m30s",
+		},
+		{
+			name: "pane id without duration",
+			ev:   Event{Kind: KindExit, Session: "ci", Pane: "%4"},
+			want: "%4 in ci: finished",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			if got := tc.ev.Summary(); got != tc.want {
+				t.Fatalf("Summary() = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+// TestEscapes keeps control characters out of OSC payloads and doubles ESC
+// inside tmux passthrough.
+func TestEscapes(t *testing.T) {
+	t.Parallel()
+
+	if got, want := OSC9("done\a;ok"), "\x1b]9;done ,ok\a"; got != want {
+		t.Fatalf("OSC9 = %q, want %q", got, want)
+	}
+	if got, want := OSC777("tmuxwatch", "a;b"), "\x1b]777;notify;tmuxwatch;a,b\a"; got != want {
+		t.Fatalf("OSC777 = %q, want %q", got, want)
+	}
+	if got, want := Passthrough("\x1b]9;x\a"), "\x1bPtmux;\x1b\x1b]9;x\a\x1b\\"; got != want {
+		t.Fatalf("Passthrough = %q, want %q", got, want)
+	}
+}
+
+// TestRunPipesJSON hands the event to the command on stdin and reports
+// failures with stderr.
+func TestRunPipesJSON(t *testing.T) {
+	t.Parallel()
+
+	out := filepath.Join(t.TempDir(), "event.json")
+	ev := Event{Kind: KindExit, Session: "ci", Pane: "%1", ExitCode: 1, EndedAt: time.Unix(100, 0).UTC()}
+	if err := Run(context.Background(), "cat > "+out, ev); err != nil {
+		t.Fatalf("Run returned error: %v", err)
+	}
+	data, err := os.ReadFile(out)
+	if err != nil {
+		t.Fatalf("read output: %v", err)
+	}
+	var got Event
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatalf("decode output: %v", err)
+	}
+	if got != ev {
+		t.Fatalf("event = %+v, want %+v", got, ev)
+	}
+
+	err = Run(context.Background(), "echo nope >&2; exit 3", ev)
+	if err == nil || err.Error() != "notify command: exit status 3: nope" {
+		t.Fatalf("Run error = %v, want exit status with stderr", err)
+	}
+}
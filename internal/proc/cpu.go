@@ -0,0 +1,94 @@
+// Package proc reads process statistics so the UI can rank panes by the CPU
+// their process trees consume.
+package proc
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os/exec"
+	"strconv"
+	"strings"
+)
+
+type process struct {
+	pid  int
+	ppid int
+	cpu  float64
+}
+
+// TreeCPU returns the summed CPU percentage of each root process and all of
+// its descendants, as reported by ps. Roots that no longer exist map to zero.
+func TreeCPU(ctx context.Context, roots []int) (map[int]float64, error) {
+	out, err := exec.CommandContext(ctx, "ps", "-A", "-o", "pid=,ppid=,pcpu=").Output()
+	if err != nil {
+		return nil, fmt.Errorf("ps: %w", err)
+	}
+	procs, err := parsePS(string(out))
+	if err != nil {
+		return nil, err
+	}
+	return treeCPU(procs, roots), nil
+}
+
+// parsePS decodes `ps -o pid=,ppid=,pcpu=` output.
+func parsePS(out string) ([]process, error) {
+	var procs []process
+	scanner := bufio.NewScanner(strings.NewReader(out))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 {
+			continue
+		}
+		if len(fields) < 3 {
+			return nil, fmt.Errorf("ps: malformed line %q", scanner.Text())
+		}
+		pid, err := strconv.Atoi(fields[0])
+		if err != nil {
+			return nil, fmt.Errorf("ps: invalid pid %q: %w", fields[0], err)
+		}
+		ppid, err := strconv.Atoi(fields[1])
+		if err != nil {
+			return nil, fmt.Errorf("ps: invalid ppid %q: %w", fields[1], err)
+		}
+		cpu, err := strconv.ParseFloat(strings.ReplaceAll(fields[2], ",", "."), 64)
+		if err != nil {
+			return nil, fmt.Errorf("ps: invalid pcpu %q: %w", fields[2], err)
+		}
+		procs = append(procs, process{pid: pid, ppid: ppid, cpu: cpu})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return procs, nil
+}
+
+// treeCPU sums CPU usage for each root across its descendants.
+func treeCPU(procs []process, roots []int) map[int]float64 {
+	children := make(map[int][]process, len(procs))
+	self := make(map[int]float64, len(procs))
+	for _, p := range procs {
+		children[p.ppid] = append(children[p.ppid], p)
+		self[p.pid] = p.cpu
+	}
+	usage := make(map[int]float64, len(roots))
+	for _, root := range roots {
+		total := self[root]
+		seen := map[int]struct{}{root: {}}
+		queue := []int{root}
+		for len(queue) > 0 {
+			pid := queue[0]
+			queue = queue[1:]
+			for _, child := range children[pid] {
+				if _, ok := seen[child.pid]; ok {
+					continue
+				}
+				seen[child.pid] = struct{}{}
+				total += child.cpu
+				queue = append(queue, child.pid)
+			}
+		}
+		usage[root] = total
+	}
+	return usage
+}
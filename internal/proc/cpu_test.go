@@ -0,0 +1,44 @@
+// File cpu_test.go validates ps parsing and process-tree aggregation.
+package proc
+
+import (
+	"testing"
+)
+
+// TestParsePS decodes pid, parent, and CPU columns.
+func TestParsePS(t *testing.T) {
+	t.Parallel()
+
+	procs, err := parsePS("    1     0  0.0\n  200     1 12.5\n\n  201   200  3,5\n")
+	if err != nil {
+		t.Fatalf("parsePS returned error: %v", err)
+	}
+	if len(procs) != 3 {
+		t.Fatalf("expected 3 processes, got %d", len(procs))
+	}
+	if procs[2].pid != 201 || procs[2].ppid != 200 || procs[2].cpu != 3.5 {
+		t.Fatalf("third process = %+v, want pid 201 ppid 200 cpu 3.5", procs[2])
+	}
+	if _, err := parsePS("12 x 1.0"); err == nil {
+		t.Fatal("expected error for invalid ppid")
+	}
+}
+
+// TestTreeCPUSumsDescendants adds child usage to each root.
+func TestTreeCPUSumsDescendants(t *testing.T) {
+	t.Parallel()
+
+	procs := []process{
+		{pid: 10, ppid: 1, cpu: 1},
+		{pid: 11, ppid: 10, cpu: 20},
+		{pid: 12, ppid: 11, cpu: 5},
+		{pid: 30, ppid: 1, cpu: 2},
+	}
+	got := treeCPU(procs, []int{10, 30, 99})
+	want := map[int]float64{10: 26, 30: 2, 99: 0}
+	for pid, cpu := range want {
+		if got[pid] != cpu {
+			t.Fatalf("treeCPU[%d] = %v, want %v", pid, got[pid], cpu)
+		}
+	}
+}
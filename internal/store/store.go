@@ -0,0 +1,153 @@
+// Package store persists tmuxwatch UI state between runs. Sessions are keyed
+// by name because tmux reassigns $ids whenever the server restarts.
+package store
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// CurrentVersion is the state schema version written by this build.
+const CurrentVersion = 1
+
+// State is the persisted UI state document.
+type State struct {
+	Version int `json:"version"`
+	// Pins lists pinned session names; pinned cards always sort first.
+	Pins []string `json:"pins,omitempty"`
+	// Order records the manual card order by session name.
+	Order []string `json:"order,omitempty"`
+	// Sort is the last sort mode, so a manual order survives restarts.
+	Sort string `json:"sort,omitempty"`
+	// View is the layout on the last exit.
+	View View `json:"view,omitzero"`
+	// Commands is the command composer history, oldest first.
+	Commands []string `json:"commands,omitempty"`
+}
+
+// View records what was hidden, collapsed, focused, and searched when
+// tmuxwatch last exited.
+type View struct {
+	Sessions []SessionView `json:"sessions,omitempty"`
+	// Focused is the session that had keyboard focus.
+	Focused SessionRef `json:"focused,omitzero"`
+	// Detail is the session whose tab was open, if any.
+	Detail SessionRef `json:"detail,omitzero"`
+	// Cursor is the overview cursor.
+	Cursor SessionRef `json:"cursor,omitzero"`
+	Search string     `json:"search,omitempty"`
+}
+
+// SessionRef identifies a session across tmux server restarts: by name,
+// with the creation time telling apart sessions that reuse a name.
+type SessionRef struct {
+	Name    string    `json:"name"`
+	Created time.Time `json:"created,omitzero"`
+}
+
+// SessionView is the per-session part of the view. Revealed marks a session
+// shown despite a hide rule.
+type SessionView struct {
+	SessionRef
+	Hidden    bool `json:"hidden,omitempty"`
+	Revealed  bool `json:"revealed,omitempty"`
+	Collapsed bool `json:"collapsed,omitempty"`
+}
+
+// IsZero reports whether v records nothing.
+func (v View) IsZero() bool {
+	return len(v.Sessions) == 0 && v.Focused.Name == "" && v.Detail.Name == "" && v.Cursor.Name == "" && v.Search == ""
+}
+
+// Dir returns the tmuxwatch state directory, honouring $XDG_STATE_HOME before
+// falling back to ~/.local/state.
+func Dir() (string, error) {
+	if xdg := os.Getenv("XDG_STATE_HOME"); xdg != "" {
+		return filepath.Join(xdg, "tmuxwatch"), nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("locate state dir: %w", err)
+	}
+	return filepath.Join(home, ".local", "state", "tmuxwatch"), nil
+}
+
+// SafeName keeps a session, window, or pane name usable as a file name
+// component in the state directory.
+func SafeName(s string) string {
+	s = strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '-', r == '_', r == '.':
+			return r
+		}
+		return '_'
+	}, s)
+	s = strings.Trim(s, ".")
+	if s == "" {
+		return "_"
+	}
+	return s
+}
+
+// Path returns the default state file location.
+func Path() (string, error) {
+	dir, err := Dir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "state.json"), nil
+}
+
+// Load reads the state file at path. A missing file yields an empty state.
+func Load(path string) (State, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return State{Version: CurrentVersion}, nil
+	}
+	if err != nil {
+		return State{}, fmt.Errorf("read state: %w", err)
+	}
+	var st State
+	if err := json.Unmarshal(data, &st); err != nil {
+		return State{}, fmt.Errorf("parse state %s: %w", path, err)
+	}
+	if st.Version > CurrentVersion {
+		return State{}, fmt.Errorf("parse state %s: unsupported version %d", path, st.Version)
+	}
+	st.Version = CurrentVersion
+	return st, nil
+}
+
+// Save writes st to path atomically, creating the parent directory.
+func Save(path string, st State) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("create state dir: %w", err)
+	}
+	st.Version = CurrentVersion
+	data, err := json.MarshalIndent(st, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encode state: %w", err)
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".state-*.json")
+	if err != nil {
+		return fmt.Errorf("write state: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(append(data, '\n')); err != nil {
+		tmp.Close()
+		return fmt.Errorf("write state: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("write state: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("write state: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,94 @@
+// File store_test.go covers loading and saving persisted UI state.
+package store
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// TestLoadMissingFile returns an empty state when nothing was saved yet.
+func TestLoadMissingFile(t *testing.T) {
+	t.Parallel()
+
+	st, err := Load(filepath.Join(t.TempDir(), "absent.json"))
+	if err != nil {
+		t.Fatalf("Load returned error: %v", err)
+	}
+	if st.Version != CurrentVersion || len(st.Pins) != 0 || len(st.Order) != 0 {
+		t.Fatalf("expected empty state, got %+v", st)
+	}
+}
+
+// TestSaveRoundTrips writes state that loads back unchanged.
+func TestSaveRoundTrips(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "nested", "state.json")
+	want := State{Version: CurrentVersion, Pins: []string{"prod-tail"}, Order: []string{"prod-tail", "ci", "scratch"}, View: View{
+		Sessions: []SessionView{{SessionRef: SessionRef{Name: "ci", Created: time.Date(2026, 10, 18, 9, 0, 0, 0, time.UTC)}, Hidden: true}},
+		Detail:   SessionRef{Name: "prod-tail"},
+		Search:   "api",
+	}, Commands: []string{"make test", "git status"}}
+	if err := Save(path, want); err != nil {
+		t.Fatalf("Save returned error: %v", err)
+	}
+	got, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load returned error: %v", err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("round trip = %+v, want %+v", got, want)
+	}
+}
+
+// TestLoadRejectsBadFiles surfaces malformed and future-version documents.
+func TestLoadRejectsBadFiles(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]string{
+		"syntax":  "{",
+		"version": `{"version": 99}`,
+	}
+	for name, doc := range tests {
+		path := filepath.Join(t.TempDir(), name+".json")
+		if err := os.WriteFile(path, []byte(doc), 0o600); err != nil {
+			t.Fatalf("write state: %v", err)
+		}
+		if _, err := Load(path); err == nil {
+			t.Fatalf("%s: expected error", name)
+		}
+	}
+}
+
+// TestDirHonoursXDG prefers $XDG_STATE_HOME when set.
+func TestDirHonoursXDG(t *testing.T) {
+	t.Setenv("XDG_STATE_HOME", "/tmp/state")
+	dir, err := Dir()
+	if err != nil {
+		t.Fatalf("Dir returned error: %v", err)
+	}
+	if dir != filepath.Join("/tmp/state", "tmuxwatch") {
+		t.Fatalf("Dir() = %q, want /tmp/state/tmuxwatch", dir)
+	}
+}
+
+// TestSafeName replaces path separators and other unsafe characters.
+func TestSafeName(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]string{
+		"api-server": "api-server",
+		"ci/../x":    "ci_.._x",
+		"%3":         "_3",
+		"..":         "_",
+		"":           "_",
+	}
+	for in, want := range tests {
+		if got := SafeName(in); got != want {
+			t.Fatalf("SafeName(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
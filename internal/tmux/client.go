@@ -5,19 +5,26 @@ package tmux
 import (
 	"context"
 	"fmt"
+	"os"
 	"os/exec"
 	"slices"
+	"strings"
+	"sync/atomic"
 	"time"
 )
 
 type commandRunner func(context.Context, string, ...string) ([]byte, error)
 
+// inputRunner is a commandRunner that also feeds the command's stdin.
+type inputRunner func(ctx context.Context, stdin, bin string, args ...string) ([]byte, error)
+
 type pathLookup func(string) (string, error)
 
 // Client wraps a tmux binary path and exposes high-level snapshot helpers.
 type Client struct {
-	bin string
-	run commandRunner
+	bin      string
+	run      commandRunner
+	runInput inputRunner
 }
 
 // NewClient constructs a Client using the provided tmux binary. When tmuxPath
@@ -34,13 +41,20 @@ func newClient(tmuxPath string, lookup pathLookup) (*Client, error) {
 			return nil, fmt.Errorf("tmux not found in PATH (install tmux >=3.1): %w", err)
 		}
 	}
-	return &Client{bin: tmuxPath, run: runCommand}, nil
+	return &Client{bin: tmuxPath, run: runCommand, runInput: runCommandInput}, nil
 }
 
 func runCommand(ctx context.Context, bin string, args ...string) ([]byte, error) {
 	return exec.CommandContext(ctx, bin, args...).Output()
 }
 
+// runCommandInput runs bin with stdin read from text.
+func runCommandInput(ctx context.Context, stdin, bin string, args ...string) ([]byte, error) {
+	cmd := exec.CommandContext(ctx, bin, args...)
+	cmd.Stdin = strings.NewReader(stdin)
+	return cmd.Output()
+}
+
 func (c *Client) runTmux(ctx context.Context, args ...string) ([]byte, error) {
 	runner := c.run
 	if runner == nil {
@@ -49,6 +63,15 @@ func (c *Client) runTmux(ctx context.Context, args ...string) ([]byte, error) {
 	return runner(ctx, c.bin, args...)
 }
 
+// runTmuxInput runs a tmux command that reads stdin, such as load-buffer.
+func (c *Client) runTmuxInput(ctx context.Context, stdin string, args ...string) ([]byte, error) {
+	runner := c.runInput
+	if runner == nil {
+		runner = runCommandInput
+	}
+	return runner(ctx, stdin, c.bin, args...)
+}
+
 // Snapshot queries tmux for sessions, windows, and panes and returns a unified
 // structure ready for presentation.
 func (c *Client) Snapshot(ctx context.Context) (Snapshot, error) {
@@ -115,6 +138,40 @@ func (c *Client) CapturePane(ctx context.Context, paneID string, lines int) (str
 	return string(out), nil
 }
 
+// CaptureHistory returns a pane's entire scrollback and visible screen,
+// used to archive sessions before they are killed.
+func (c *Client) CaptureHistory(ctx context.Context, paneID string) (string, error) {
+	if paneID == "" {
+		return "", fmt.Errorf("pane id cannot be empty")
+	}
+	out, err := c.runTmux(ctx, "capture-pane", "-p", "-J", "-S", "-", "-E", "-", "-t", paneID)
+	if err != nil {
+		return "", fmt.Errorf("capture-pane %s: %w", paneID, err)
+	}
+	return string(out), nil
+}
+
+// CaptureText returns a pane's visible screen, or with history its whole
+// scrollback, for exporting. With escapes the text keeps its colour and
+// attribute escape sequences.
+func (c *Client) CaptureText(ctx context.Context, paneID string, history, escapes bool) (string, error) {
+	if paneID == "" {
+		return "", fmt.Errorf("pane id cannot be empty")
+	}
+	args := []string{"capture-pane", "-p", "-J"}
+	if escapes {
+		args = append(args, "-e")
+	}
+	if history {
+		args = append(args, "-S", "-", "-E", "-")
+	}
+	out, err := c.runTmux(ctx, append(args, "-t", paneID)...)
+	if err != nil {
+		return "", fmt.Errorf("capture-pane %s: %w", paneID, err)
+	}
+	return string(out), nil
+}
+
 // SendKeys forwards key sequences to a tmux pane so the user can interact with
 // it through tmuxwatch.
 func (c *Client) SendKeys(ctx context.Context, paneID string, keys ...string) error {
@@ -129,6 +186,97 @@ func (c *Client) SendKeys(ctx context.Context, paneID string, keys ...string) er
 	return cmd.Run()
 }
 
+// SendLiteral types text into a tmux pane verbatim with send-keys -l, so key
+// names such as "Enter" inside it are not interpreted.
+func (c *Client) SendLiteral(ctx context.Context, paneID, text string) error {
+	if paneID == "" {
+		return fmt.Errorf("pane id cannot be empty")
+	}
+	if text == "" {
+		return nil
+	}
+	if _, err := c.runTmux(ctx, "send-keys", "-l", "-t", paneID, "--", text); err != nil {
+		return fmt.Errorf("send-keys %s: %w", paneID, err)
+	}
+	return nil
+}
+
+// pasteSeq numbers paste buffers so concurrent pastes never share one.
+var pasteSeq atomic.Uint64
+
+// pasteBuffer returns a tmux buffer name unique to this process and call.
+func pasteBuffer() string {
+	return fmt.Sprintf("tmuxwatch-paste-%d-%d", os.Getpid(), pasteSeq.Add(1))
+}
+
+// Paste loads text into a tmux buffer and pastes it into a pane with
+// paste-buffer -p, which wraps it in bracketed-paste sequences when the
+// pane's application asked for them. Each call uses its own buffer, which
+// is deleted afterwards.
+func (c *Client) Paste(ctx context.Context, paneID, text string) error {
+	if paneID == "" {
+		return fmt.Errorf("pane id cannot be empty")
+	}
+	if text == "" {
+		return nil
+	}
+	buffer := pasteBuffer()
+	if _, err := c.runTmuxInput(ctx, text, "load-buffer", "-b", buffer, "-"); err != nil {
+		return fmt.Errorf("load-buffer: %w", err)
+	}
+	if _, err := c.runTmux(ctx, "paste-buffer", "-p", "-d", "-b", buffer, "-t", paneID); err != nil {
+		return fmt.Errorf("paste-buffer %s: %w", paneID, err)
+	}
+	return nil
+}
+
+// Binary returns the path of the tmux binary the client runs.
+func (c *Client) Binary() string {
+	return c.bin
+}
+
+// ClientTTY returns the tty of the tmux client displaying paneID, or "" when
+// no client shows it.
+func (c *Client) ClientTTY(ctx context.Context, paneID string) (string, error) {
+	if paneID == "" {
+		return "", fmt.Errorf("pane id cannot be empty")
+	}
+	out, err := c.runTmux(ctx, "display-message", "-p", "-t", paneID, "#{client_tty}")
+	if err != nil {
+		return "", fmt.Errorf("display-message %s: %w", paneID, err)
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+// SelectPane makes windowID the current window of its session and paneID
+// the active pane in it.
+func (c *Client) SelectPane(ctx context.Context, windowID, paneID string) error {
+	if windowID == "" || paneID == "" {
+		return fmt.Errorf("window and pane ids cannot be empty")
+	}
+	if _, err := c.runTmux(ctx, "select-window", "-t", windowID, ";", "select-pane", "-t", paneID); err != nil {
+		return fmt.Errorf("select-pane %s: %w", paneID, err)
+	}
+	return nil
+}
+
+// SwitchClient moves a tmux client to sessionID. An empty clientTTY lets
+// tmux pick the current client.
+func (c *Client) SwitchClient(ctx context.Context, clientTTY, sessionID string) error {
+	if sessionID == "" {
+		return fmt.Errorf("session id cannot be empty")
+	}
+	args := []string{"switch-client"}
+	if clientTTY != "" {
+		args = append(args, "-c", clientTTY)
+	}
+	args = append(args, "-t", sessionID)
+	if _, err := c.runTmux(ctx, args...); err != nil {
+		return fmt.Errorf("switch-client %s: %w", sessionID, err)
+	}
+	return nil
+}
+
 // KillSession terminates a tmux session by id.
 func (c *Client) KillSession(ctx context.Context, sessionID string) error {
 	if sessionID == "" {
@@ -3,6 +3,7 @@ package tmux
 import (
 	"context"
 	"os/exec"
+	"slices"
 	"strings"
 	"testing"
 )
@@ -68,3 +69,143 @@ func TestListPanesNoServer(t *testing.T) {
 		t.Fatalf("expected no panes, got %d", len(panes))
 	}
 }
+
+func TestCaptureHistoryReadsWholeScrollback(t *testing.T) {
+	t.Parallel()
+
+	var got []string
+	c := &Client{bin: "tmux", run: func(_ context.Context, _ string, args ...string) ([]byte, error) {
+		got = args
+		return []byte("first\nlast\n"), nil
+	}}
+
+	out, err := c.CaptureHistory(context.Background(), "%3")
+	if err != nil {
+		t.Fatalf("CaptureHistory returned error: %v", err)
+	}
+	if out != "first\nlast\n" {
+		t.Fatalf("output = %q", out)
+	}
+	if want := "capture-pane -p -J -S - -E - -t %3"; strings.Join(got, " ") != want {
+		t.Fatalf("args = %q, want %q", strings.Join(got, " "), want)
+	}
+}
+
+func TestCaptureTextFlags(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		history, escapes bool
+		want             string
+	}{
+		{false, false, "capture-pane -p -J -t %3"},
+		{false, true, "capture-pane -p -J -e -t %3"},
+		{true, false, "capture-pane -p -J -S - -E - -t %3"},
+		{true, true, "capture-pane -p -J -e -S - -E - -t %3"},
+	}
+	for _, tt := range tests {
+		var got []string
+		c := &Client{bin: "tmux", run: func(_ context.Context, _ string, args ...string) ([]byte, error) {
+			got = args
+			return []byte("text\n"), nil
+		}}
+		if _, err := c.CaptureText(context.Background(), "%3", tt.history, tt.escapes); err != nil {
+			t.Fatalf("CaptureText returned error: %v", err)
+		}
+		if strings.Join(got, " ") != tt.want {
+			t.Fatalf("args = %q, want %q", strings.Join(got, " "), tt.want)
+		}
+	}
+}
+
+func TestJumpCommands(t *testing.T) {
+	t.Parallel()
+
+	var calls []string
+	c := &Client{bin: "tmux", run: func(_ context.Context, _ string, args ...string) ([]byte, error) {
+		calls = append(calls, strings.Join(args, " "))
+		return []byte("/dev/ttys004\n"), nil
+	}}
+	ctx := context.Background()
+
+	tty, err := c.ClientTTY(ctx, "%1")
+	if err != nil || tty != "/dev/ttys004" {
+		t.Fatalf("ClientTTY = %q, %v", tty, err)
+	}
+	if err := c.SelectPane(ctx, "@2", "%5"); err != nil {
+		t.Fatalf("SelectPane returned error: %v", err)
+	}
+	if err := c.SwitchClient(ctx, tty, "$3"); err != nil {
+		t.Fatalf("SwitchClient returned error: %v", err)
+	}
+	if err := c.SwitchClient(ctx, "", "$3"); err != nil {
+		t.Fatalf("SwitchClient returned error: %v", err)
+	}
+	want := []string{
+		"display-message -p -t %1 #{client_tty}",
+		"select-window -t @2 ; select-pane -t %5",
+		"switch-client -c /dev/ttys004 -t $3",
+		"switch-client -t $3",
+	}
+	if strings.Join(calls, "\n") != strings.Join(want, "\n") {
+		t.Fatalf("calls:\n%s\nwant:\n%s", strings.Join(calls, "\n"), strings.Join(want, "\n"))
+	}
+}
+
+func TestSendLiteralUsesLiteralFlag(t *testing.T) {
+	t.Parallel()
+
+	var got []string
+	c := &Client{bin: "tmux", run: func(_ context.Context, _ string, args ...string) ([]byte, error) {
+		got = args
+		return nil, nil
+	}}
+	if err := c.SendLiteral(context.Background(), "%1", "-rf Enter"); err != nil {
+		t.Fatalf("SendLiteral returned error: %v", err)
+	}
+	if want := "send-keys -l -t %1 -- -rf Enter"; strings.Join(got, " ") != want {
+		t.Fatalf("args = %q, want %q", strings.Join(got, " "), want)
+	}
+}
+
+func TestPasteUsesUniqueBuffers(t *testing.T) {
+	t.Parallel()
+
+	var calls []string
+	var stdin []string
+	c := &Client{
+		bin: "tmux",
+		run: func(_ context.Context, _ string, args ...string) ([]byte, error) {
+			calls = append(calls, strings.Join(args, " "))
+			return nil, nil
+		},
+		runInput: func(_ context.Context, text, _ string, args ...string) ([]byte, error) {
+			calls = append(calls, strings.Join(args, " "))
+			stdin = append(stdin, text)
+			return nil, nil
+		},
+	}
+	for _, text := range []string{"make\n", "go test\n"} {
+		if err := c.Paste(context.Background(), "%1", text); err != nil {
+			t.Fatalf("Paste returned error: %v", err)
+		}
+	}
+	if len(calls) != 4 || !slices.Equal(stdin, []string{"make\n", "go test\n"}) {
+		t.Fatalf("calls = %q, stdin = %q", calls, stdin)
+	}
+	var buffers []string
+	for i, call := range calls {
+		fields := strings.Fields(call)
+		want := []string{"load-buffer", "paste-buffer"}[i%2]
+		if fields[0] != want {
+			t.Fatalf("call %d = %q, want %s", i, call, want)
+		}
+		buffers = append(buffers, fields[slices.Index(fields, "-b")+1])
+	}
+	if buffers[0] != buffers[1] || buffers[2] != buffers[3] || buffers[0] == buffers[2] {
+		t.Fatalf("buffers = %q, want one per paste", buffers)
+	}
+	if calls[1] != "paste-buffer -p -d -b "+buffers[0]+" -t %1" {
+		t.Fatalf("paste = %q", calls[1])
+	}
+}
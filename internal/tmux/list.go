@@ -14,7 +14,17 @@ import (
 // listSessions shells out to tmux to enumerate sessions and translate them
 // into typed Session values.
 func (c *Client) listSessions(ctx context.Context) ([]Session, error) {
-	out, err := c.runTmux(ctx, "list-sessions", "-F", "#{session_id}\t#{session_name}\t#{session_attached}\t#{session_created}\t#{session_activity}")
+	format := strings.Join([]string{
+		"#{session_id}",
+		"#{session_name}",
+		"#{session_attached}",
+		"#{session_created}",
+		"#{session_activity}",
+		"#{session_last_attached}",
+		"#{" + ProtectOption + "}",
+		"#{" + StaleAfterOption + "}",
+	}, "\t")
+	out, err := c.runTmux(ctx, "list-sessions", "-F", format)
 	if err != nil {
 		if isNoServerError(err) {
 			return []Session{}, nil
@@ -29,7 +39,7 @@ func (c *Client) listSessions(ctx context.Context) ([]Session, error) {
 			continue
 		}
 		fields := strings.Split(line, "\t")
-		if len(fields) < 5 {
+		if len(fields) < 8 {
 			return nil, fmt.Errorf("list-sessions: malformed line %q", line)
 		}
 		attached := fields[2] == "1"
@@ -41,12 +51,19 @@ func (c *Client) listSessions(ctx context.Context) ([]Session, error) {
 		if err != nil {
 			return nil, fmt.Errorf("invalid session_activity %q: %w", fields[4], err)
 		}
+		lastAttached, err := parseUnix(fields[5])
+		if err != nil {
+			return nil, fmt.Errorf("invalid session_last_attached %q: %w", fields[5], err)
+		}
 		session := Session{
 			ID:           fields[0],
 			Name:         fields[1],
 			Attached:     attached,
 			CreatedAt:    time.Unix(createdUnix, 0),
 			LastActivity: lastActivity,
+			LastAttached: lastAttached,
+			Protected:    optionEnabled(fields[6]),
+			StaleAfter:   strings.TrimSpace(fields[7]),
 		}
 		sessions = append(sessions, session)
 	}
@@ -59,7 +76,18 @@ func (c *Client) listSessions(ctx context.Context) ([]Session, error) {
 // listWindows retrieves every window in every session so we can later nest
 // panes under them.
 func (c *Client) listWindows(ctx context.Context) ([]Window, error) {
-	out, err := c.runTmux(ctx, "list-windows", "-a", "-F", "#{session_id}\t#{window_id}\t#{window_index}\t#{window_name}\t#{window_active}\t#{window_last_flag}")
+	format := strings.Join([]string{
+		"#{session_id}",
+		"#{window_id}",
+		"#{window_index}",
+		"#{window_name}",
+		"#{window_active}",
+		"#{window_last_flag}",
+		"#{window_bell_flag}",
+		"#{window_activity_flag}",
+		"#{window_silence_flag}",
+	}, "\t")
+	out, err := c.runTmux(ctx, "list-windows", "-a", "-F", format)
 	if err != nil {
 		if isNoServerError(err) {
 			return []Window{}, nil
@@ -74,7 +102,7 @@ func (c *Client) listWindows(ctx context.Context) ([]Window, error) {
 			continue
 		}
 		fields := strings.Split(line, "\t")
-		if len(fields) < 6 {
+		if len(fields) < 9 {
 			return nil, fmt.Errorf("list-windows: malformed line %q", line)
 		}
 		index, err := strconv.Atoi(fields[2])
@@ -83,11 +111,14 @@ func (c *Client) listWindows(ctx context.Context) ([]Window, error) {
 		}
 		active := fields[4] == "1"
 		window := Window{
-			Session: fields[0],
-			ID:      fields[1],
-			Index:   index,
-			Name:    fields[3],
-			Active:  active,
+			Session:      fields[0],
+			ID:           fields[1],
+			Index:        index,
+			Name:         fields[3],
+			Active:       active,
+			BellFlag:     fields[6] == "1",
+			ActivityFlag: fields[7] == "1",
+			SilenceFlag:  fields[8] == "1",
 		}
 		windows = append(windows, window)
 	}
@@ -114,6 +145,9 @@ func (c *Client) listPanes(ctx context.Context) ([]Pane, error) {
 		"#{pane_tty}",
 		"#{pane_dead}",
 		"#{pane_dead_status}",
+		"#{pane_pid}",
+		"#{pane_current_path}",
+		"#{" + GroupOption + "}",
 	}, "\t")
 
 	out, err := c.runTmux(ctx, "list-panes", "-a", "-F", format)
@@ -131,7 +165,7 @@ func (c *Client) listPanes(ctx context.Context) ([]Pane, error) {
 			continue
 		}
 		fields := strings.Split(line, "\t")
-		if len(fields) < 13 {
+		if len(fields) < 16 {
 			return nil, fmt.Errorf("list-panes: malformed line %q", line)
 		}
 		active := fields[3] == "1"
@@ -164,12 +198,17 @@ func (c *Client) listPanes(ctx context.Context) ([]Pane, error) {
 			Height:       height,
 			TTY:          fields[10],
 			Dead:         fields[11] == "1",
+			CurrentPath:  fields[14],
+			Group:        strings.TrimSpace(fields[15]),
 		}
 		if status := strings.TrimSpace(fields[12]); status != "" {
 			if v, err := strconv.Atoi(status); err == nil {
 				pane.DeadStatus = v
 			}
 		}
+		if pid, err := strconv.Atoi(strings.TrimSpace(fields[13])); err == nil {
+			pane.PID = pid
+		}
 		panes = append(panes, pane)
 	}
 	if err := scanner.Err(); err != nil {
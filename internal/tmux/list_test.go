@@ -2,6 +2,8 @@
 package tmux
 
 import (
+	"context"
+	"strings"
 	"testing"
 	"time"
 )
@@ -41,3 +43,80 @@ func TestParseUnixInvalid(t *testing.T) {
 		t.Fatal("parseUnix expected error for invalid input")
 	}
 }
+
+// TestListPanesParsesGroupingFields reads the pid, working directory, and
+// @group option used for sorting and grouping.
+func TestListPanesParsesGroupingFields(t *testing.T) {
+	t.Parallel()
+
+	line := "$1\t@1\t%1\t1\tzsh\ttitle\t100\t90\t80\t24\t/dev/ttys001\t0\t\t4242\t/src/app\tbackend\n"
+	c := &Client{bin: "tmux", run: func(context.Context, string, ...string) ([]byte, error) {
+		return []byte(line), nil
+	}}
+	panes, err := c.listPanes(context.Background())
+	if err != nil {
+		t.Fatalf("listPanes returned error: %v", err)
+	}
+	if len(panes) != 1 {
+		t.Fatalf("expected one pane, got %d", len(panes))
+	}
+	got := panes[0]
+	if got.PID != 4242 || got.CurrentPath != "/src/app" || got.Group != "backend" {
+		t.Fatalf("pane = %+v, want pid 4242, path /src/app, group backend", got)
+	}
+}
+
+// TestListSessionsParsesPolicyFields reads the last attach time and the
+// protect and stale-after user options, and rejects lines missing them.
+func TestListSessionsParsesPolicyFields(t *testing.T) {
+	t.Parallel()
+
+	out := "$1\tdb\t0\t100\t200\t150\t1\t30m\n$2\tscratch\t1\t100\t200\t\t\t\n"
+	c := &Client{bin: "tmux", run: func(context.Context, string, ...string) ([]byte, error) {
+		return []byte(out), nil
+	}}
+	sessions, err := c.listSessions(context.Background())
+	if err != nil {
+		t.Fatalf("listSessions returned error: %v", err)
+	}
+	if len(sessions) != 2 {
+		t.Fatalf("expected two sessions, got %d", len(sessions))
+	}
+	db := sessions[0]
+	if !db.Protected || db.StaleAfter != "30m" || !db.LastAttached.Equal(time.Unix(150, 0)) {
+		t.Fatalf("db = %+v, want protected, 30m, attached at 150", db)
+	}
+	if sessions[1].Protected || sessions[1].StaleAfter != "" || !sessions[1].LastAttached.IsZero() {
+		t.Fatalf("scratch = %+v, want no policy fields", sessions[1])
+	}
+
+	out = "$3\told\t0\t100\t200\n"
+	if _, err := c.listSessions(context.Background()); err == nil || !strings.Contains(err.Error(), "malformed line") {
+		t.Fatalf("short line error = %v, want malformed line", err)
+	}
+}
+
+// TestListWindowsParsesAlertFlags reads tmux's bell, activity, and silence
+// window flags.
+func TestListWindowsParsesAlertFlags(t *testing.T) {
+	t.Parallel()
+
+	out := "$1\t@1\t0\teditor\t1\t0\t0\t0\t0\n$1\t@2\t1\tbuild\t0\t1\t1\t1\t0\n$1\t@3\t2\tlogs\t0\t0\t0\t0\t1\n"
+	c := &Client{bin: "tmux", run: func(context.Context, string, ...string) ([]byte, error) {
+		return []byte(out), nil
+	}}
+	windows, err := c.listWindows(context.Background())
+	if err != nil {
+		t.Fatalf("listWindows returned error: %v", err)
+	}
+	if len(windows) != 3 {
+		t.Fatalf("expected three windows, got %d", len(windows))
+	}
+	flags := func(w Window) [3]bool { return [3]bool{w.BellFlag, w.ActivityFlag, w.SilenceFlag} }
+	want := [][3]bool{{false, false, false}, {true, true, false}, {false, false, true}}
+	for i, w := range windows {
+		if flags(w) != want[i] {
+			t.Fatalf("window %s flags = %v, want %v", w.Name, flags(w), want[i])
+		}
+	}
+}
@@ -9,6 +9,50 @@ import (
 	"strings"
 )
 
+// User options tmuxwatch reads from sessions.
+const (
+	// ProtectOption marks a session that tmuxwatch must never kill.
+	ProtectOption = "@tmuxwatch-protect"
+	// StaleAfterOption overrides the stale threshold for one session.
+	StaleAfterOption = "@tmuxwatch-stale-after"
+	// GroupOption names the group a pane's session is listed under.
+	GroupOption = "@group"
+)
+
+// optionEnabled interprets a boolean user option value.
+func optionEnabled(value string) bool {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "1", "on", "yes", "true":
+		return true
+	}
+	return false
+}
+
+// SessionProtection reports a session's name and whether its
+// @tmuxwatch-protect option is set, read fresh from tmux.
+func (c *Client) SessionProtection(ctx context.Context, sessionID string) (string, bool, error) {
+	if sessionID == "" {
+		return "", false, fmt.Errorf("session id cannot be empty")
+	}
+	out, err := c.runTmux(ctx, "display-message", "-p", "-t", sessionID, "#{session_name}\t#{"+ProtectOption+"}")
+	if err != nil {
+		return "", false, fmt.Errorf("display-message %s: %w", sessionID, err)
+	}
+	name, value, _ := strings.Cut(strings.TrimRight(string(out), "\n"), "\t")
+	return name, optionEnabled(value), nil
+}
+
+// SetPaneOption sets a user option on one pane.
+func (c *Client) SetPaneOption(ctx context.Context, paneID, name, value string) error {
+	if paneID == "" {
+		return fmt.Errorf("pane id cannot be empty")
+	}
+	if _, err := c.runTmux(ctx, "set-option", "-p", "-t", paneID, name, value); err != nil {
+		return fmt.Errorf("set-option %s: %w", paneID, err)
+	}
+	return nil
+}
+
 // PaneVariables returns user-defined (@-prefixed) tmux options scoped to a pane.
 func (c *Client) PaneVariables(ctx context.Context, paneID string) (map[string]string, error) {
 	if paneID == "" {
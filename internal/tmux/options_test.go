@@ -1,7 +1,11 @@
 // File options_test.go checks parsing of tmux option lines.
 package tmux
 
-import "testing"
+import (
+	"context"
+	"slices"
+	"testing"
+)
 
 // TestParseOptionLine ensures options support quotes, comments, and blanks.
 func TestParseOptionLine(t *testing.T) {
@@ -32,3 +36,57 @@ func TestParseOptionLine(t *testing.T) {
 		}
 	}
 }
+
+// TestSessionProtection reads the protect option for one session and accepts
+// the usual spellings of a true value.
+func TestSessionProtection(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		out  string
+		want bool
+	}{
+		{out: "db\t1\n", want: true},
+		{out: "db\ton\n", want: true},
+		{out: "db\t\n", want: false},
+		{out: "db\t0\n", want: false},
+	}
+	for _, tt := range tests {
+		var args []string
+		c := &Client{bin: "tmux", run: func(_ context.Context, _ string, a ...string) ([]byte, error) {
+			args = a
+			return []byte(tt.out), nil
+		}}
+		name, protected, err := c.SessionProtection(context.Background(), "$3")
+		if err != nil {
+			t.Fatalf("SessionProtection returned error: %v", err)
+		}
+		if name != "db" || protected != tt.want {
+			t.Fatalf("SessionProtection(%q) = (%q, %v), want (db, %v)", tt.out, name, protected, tt.want)
+		}
+		if !slices.Contains(args, "$3") {
+			t.Fatalf("display-message args = %v, want target $3", args)
+		}
+	}
+}
+
+// TestSetPaneOption sets a pane-scoped user option.
+func TestSetPaneOption(t *testing.T) {
+	t.Parallel()
+
+	var args []string
+	c := &Client{bin: "tmux", run: func(_ context.Context, _ string, a ...string) ([]byte, error) {
+		args = a
+		return nil, nil
+	}}
+	if err := c.SetPaneOption(context.Background(), "%4", GroupOption, "deploy"); err != nil {
+		t.Fatalf("SetPaneOption returned error: %v", err)
+	}
+	want := []string{"set-option", "-p", "-t", "%4", "@group", "deploy"}
+	if !slices.Equal(args, want) {
+		t.Fatalf("args = %v, want %v", args, want)
+	}
+	if err := c.SetPaneOption(context.Background(), "", GroupOption, "x"); err == nil {
+		t.Fatal("expected an error for an empty pane id")
+	}
+}
@@ -16,7 +16,15 @@ type Session struct {
 	CreatedAt time.Time
 	// LastActivity records the most recent activity timestamp reported by tmux.
 	LastActivity time.Time
-	Windows      []Window
+	// LastAttached is when a client last attached to the session.
+	LastAttached time.Time
+	// Protected mirrors the @tmuxwatch-protect session option; protected
+	// sessions are never stale and never killed by tmuxwatch.
+	Protected bool
+	// StaleAfter holds the raw @tmuxwatch-stale-after option, a per-session
+	// stale threshold such as "30m".
+	StaleAfter string
+	Windows    []Window
 }
 
 // Window represents a tmux window and its panes.
@@ -28,6 +36,11 @@ type Window struct {
 	Index    int
 	LastPane time.Time
 	Panes    []Pane
+	// BellFlag, ActivityFlag, and SilenceFlag mirror tmux's window alert
+	// flags, set by bell-action, monitor-activity, and monitor-silence.
+	BellFlag     bool
+	ActivityFlag bool
+	SilenceFlag  bool
 }
 
 // Pane represents a tmux pane.
@@ -44,6 +57,12 @@ type Pane struct {
 	Width, Height int
 	Dead          bool
 	DeadStatus    int
+	// PID is the process ID of the pane's top-level process.
+	PID int
+	// CurrentPath is the working directory of the pane's foreground process.
+	CurrentPath string
+	// Group holds the optional @group user option used for grouping cards.
+	Group string
 }
 
 // Snapshot contains the state of the tmux server.
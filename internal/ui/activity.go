@@ -0,0 +1,155 @@
+// File activity.go keeps a rolling per-pane history of output volume and
+// renders it as header sparklines and the larger detail-view chart.
+package ui
+
+import (
+	"strings"
+
+	"github.com/steipete/tmuxwatch/internal/tmux"
+)
+
+const (
+	// activityBuckets is how many snapshot ticks of history each pane keeps.
+	activityBuckets = 120
+	// sparklineWidth is the number of ticks shown in a card header.
+	sparklineWidth = 12
+	// detailChartHeight is the number of rows the detail-view chart uses.
+	detailChartHeight = 4
+	// activityScaleFloor keeps a trickle of a few lines per tick from being
+	// drawn as full bars when nothing busier is in view.
+	activityScaleFloor = 10
+)
+
+// sparkLevels are the bar glyphs from empty to full.
+var sparkLevels = []rune("▁▂▃▄▅▆▇█")
+
+// activitySeries counts new output lines per snapshot tick, oldest first.
+// The last bucket is the tick in progress.
+type activitySeries struct {
+	buckets []int
+}
+
+// add counts lines of new output in the current tick.
+func (s *activitySeries) add(lines int) {
+	if len(s.buckets) == 0 {
+		s.buckets = append(s.buckets, 0)
+	}
+	s.buckets[len(s.buckets)-1] += lines
+}
+
+// advance starts a new tick, dropping the oldest once the history is full.
+func (s *activitySeries) advance() {
+	s.buckets = append(s.buckets, 0)
+	if over := len(s.buckets) - activityBuckets; over > 0 {
+		s.buckets = append(s.buckets[:0], s.buckets[over:]...)
+	}
+}
+
+// last returns the most recent n buckets, left-padded with zeros.
+func (s *activitySeries) last(n int) []int {
+	out := make([]int, n)
+	if s == nil {
+		return out
+	}
+	src := s.buckets
+	if len(src) > n {
+		src = src[len(src)-n:]
+	}
+	copy(out[n-len(src):], src)
+	return out
+}
+
+// advanceActivity closes the current tick for every pane in the snapshot and
+// forgets panes that disappeared.
+func (m *Model) advanceActivity(sessions []tmux.Session) {
+	seen := make(map[string]struct{})
+	for _, session := range sessions {
+		for _, window := range session.Windows {
+			for _, pane := range window.Panes {
+				seen[pane.ID] = struct{}{}
+				if m.activity == nil {
+					m.activity = make(map[string]*activitySeries)
+				}
+				series, ok := m.activity[pane.ID]
+				if !ok {
+					series = &activitySeries{}
+					m.activity[pane.ID] = series
+				}
+				series.advance()
+			}
+		}
+	}
+	for id := range m.activity {
+		if _, ok := seen[id]; !ok {
+			delete(m.activity, id)
+		}
+	}
+}
+
+// recordActivity adds newly printed lines to a pane's current tick.
+func (m *Model) recordActivity(paneID string, lines int) {
+	if lines <= 0 {
+		return
+	}
+	if m.activity == nil {
+		m.activity = make(map[string]*activitySeries)
+	}
+	series, ok := m.activity[paneID]
+	if !ok {
+		series = &activitySeries{}
+		m.activity[paneID] = series
+	}
+	series.add(lines)
+}
+
+// sparkline renders values as a single row of bar glyphs.
+func sparkline(values []int) string {
+	peak := activityScaleFloor
+	for _, v := range values {
+		peak = max(peak, v)
+	}
+	var b strings.Builder
+	for _, v := range values {
+		level := 0
+		if v > 0 {
+			level = max(1, (v*(len(sparkLevels)-1)+peak-1)/peak)
+		}
+		b.WriteRune(sparkLevels[min(level, len(sparkLevels)-1)])
+	}
+	return b.String()
+}
+
+// activityChart renders values as a bar chart height rows tall, top row
+// first, using eighth blocks for the partial top of each bar.
+func activityChart(values []int, height int) []string {
+	if height <= 0 {
+		return nil
+	}
+	peak := activityScaleFloor
+	for _, v := range values {
+		peak = max(peak, v)
+	}
+	steps := height * 8
+	rows := make([]strings.Builder, height)
+	for _, v := range values {
+		filled := 0
+		if v > 0 {
+			filled = max(1, (v*steps+peak-1)/peak)
+		}
+		for row := range height {
+			// row 0 is the top; level counts eighths filled within this row.
+			base := (height - 1 - row) * 8
+			level := min(max(filled-base, 0), 8)
+			if level == 0 {
+				rows[row].WriteByte(' ')
+				continue
+			}
+			rows[row].WriteRune(sparkLevels[level-1])
+		}
+	}
+	out := make([]string, height)
+	for i := range rows {
+		out[i] = rows[i].String()
+	}
+	return out
+}
@@ -0,0 +1,91 @@
+// File activity_test.go covers the output-volume history and its sparkline
+// and chart rendering.
+package ui
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/steipete/tmuxwatch/internal/tmux"
+)
+
+// TestActivitySeriesRolls keeps a bounded history and pads short ones.
+func TestActivitySeriesRolls(t *testing.T) {
+	t.Parallel()
+
+	var s activitySeries
+	for i := range activityBuckets + 5 {
+		s.add(i)
+		s.advance()
+	}
+	if len(s.buckets) != activityBuckets {
+		t.Fatalf("len = %d, want %d", len(s.buckets), activityBuckets)
+	}
+	if got := s.last(3); !slices.Equal(got, []int{activityBuckets + 3, activityBuckets + 4, 0}) {
+		t.Fatalf("last(3) = %v", got)
+	}
+
+	var short activitySeries
+	short.add(2)
+	if got := short.last(3); !slices.Equal(got, []int{0, 0, 2}) {
+		t.Fatalf("padded last(3) = %v, want [0 0 2]", got)
+	}
+	if got := (*activitySeries)(nil).last(2); !slices.Equal(got, []int{0, 0}) {
+		t.Fatalf("nil last(2) = %v, want zeros", got)
+	}
+}
+
+// TestSparkline scales against the busiest tick but never below the floor,
+// so quiet, steady, and bursty panes look different.
+func TestSparkline(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		values []int
+		want   string
+	}{
+		{name: "stalled", values: []int{0, 0, 0, 0}, want: "▁▁▁▁"},
+		{name: "trickle", values: []int{1, 1, 1, 1}, want: "▂▂▂▂"},
+		{name: "hot loop", values: []int{200, 200, 200, 200}, want: "████"},
+		{name: "bursty", values: []int{0, 100, 0, 50}, want: "▁█▁▅"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			if got := sparkline(tc.values); got != tc.want {
+				t.Fatalf("sparkline(%v) = %q, want %q", tc.values, got, tc.want)
+			}
+		})
+	}
+}
+
+// TestActivityChart stacks eighth blocks from the bottom row up.
+func TestActivityChart(t *testing.T) {
+	t.Parallel()
+
+	got := activityChart([]int{0, 10, 5}, 2)
+	want := []string{" █ ", " ██"}
+	if !slices.Equal(got, want) {
+		t.Fatalf("activityChart = %q, want %q", got, want)
+	}
+}
+
+// TestAdvanceActivityTracksSnapshotPanes adds a tick per snapshot and drops
+// panes that disappeared.
+func TestAdvanceActivityTracksSnapshotPanes(t *testing.T) {
+	t.Parallel()
+
+	m := &Model{}
+	m.recordActivity("%9", 4)
+	sessions := []tmux.Session{{ID: "$1", Windows: []tmux.Window{{Panes: []tmux.Pane{{ID: "%1"}}}}}}
+	m.advanceActivity(sessions)
+	m.recordActivity("%1", 3)
+	m.advanceActivity(sessions)
+	if _, ok := m.activity["%9"]; ok {
+		t.Fatal("pane missing from the snapshot should be dropped")
+	}
+	if got := m.activity["%1"].last(3); !slices.Equal(got, []int{0, 3, 0}) {
+		t.Fatalf("history = %v, want [0 3 0]", got)
+	}
+}
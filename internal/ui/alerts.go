@@ -0,0 +1,366 @@
+// File alerts.go runs regex alert rules over new pane output and tracks the
+// resulting alerts until they are acknowledged.
+package ui
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+
+	"github.com/steipete/tmuxwatch/internal/config"
+)
+
+// alertRule is a compiled config.AlertRule.
+type alertRule struct {
+	name     string
+	re       *regexp.Regexp
+	session  string
+	pane     string
+	cooldown time.Duration
+	actions  []string
+}
+
+// alert is an unacknowledged rule match. Repeats from the same rule and pane
+// update the existing alert instead of adding a new one.
+type alert struct {
+	rule      string
+	sessionID string
+	paneID    string
+	line      string
+	at        time.Time
+	count     int
+}
+
+// maxAlertLineWidth trims matched lines shown in toasts and the palette.
+const maxAlertLineWidth = 80
+
+// compileAlertRules turns config rules into matchers. Config validation has
+// already checked the patterns, so errors here indicate a programming bug.
+func compileAlertRules(rules []config.AlertRule) ([]alertRule, error) {
+	out := make([]alertRule, 0, len(rules))
+	for i, rule := range rules {
+		re, err := regexp.Compile(rule.Pattern)
+		if err != nil {
+			return nil, fmt.Errorf("alerts[%d]: %w", i, err)
+		}
+		actions := rule.Actions
+		if len(actions) == 0 {
+			actions = config.DefaultAlertActions
+		}
+		cooldown := time.Duration(rule.Cooldown)
+		if cooldown == 0 {
+			cooldown = config.DefaultAlertCooldown
+		}
+		out = append(out, alertRule{
+			name:     rule.DisplayName(),
+			re:       re,
+			session:  rule.Session,
+			pane:     rule.Pane,
+			cooldown: cooldown,
+			actions:  slices.Clone(actions),
+		})
+	}
+	return out, nil
+}
+
+// newLines returns the lines in next that were not already present in prev.
+// Captures are a sliding window over the pane, so the longest suffix of prev
+// that starts next marks the overlap. A trailing line that was still being
+// written in prev is allowed to differ.
+func newLines(prev, next string) []string {
+	if next == "" {
+		return nil
+	}
+	nextLines := strings.Split(next, "\n")
+	if prev == "" {
+		return nextLines
+	}
+	prevLines := strings.Split(prev, "\n")
+	if n := overlap(prevLines, nextLines); n > 0 {
+		return nextLines[n:]
+	}
+	if len(prevLines) > 1 {
+		if n := overlap(prevLines[:len(prevLines)-1], nextLines); n > 0 {
+			return nextLines[n:]
+		}
+	}
+	return nextLines
+}
+
+// overlap returns the length of the longest suffix of prev that is a prefix
+// of next.
+func overlap(prev, next []string) int {
+	for start := range prev {
+		suffix := prev[start:]
+		if len(suffix) > len(next) {
+			continue
+		}
+		if slices.Equal(suffix, next[:len(suffix)]) {
+			return len(suffix)
+		}
+	}
+	return 0
+}
+
+// scanAlerts checks freshly printed lines against every rule in scope and
+// performs the configured actions.
+func (m *Model) scanAlerts(sessionID, paneID string, lines []string) tea.Cmd {
+	if len(m.alertRules) == 0 || len(lines) == 0 {
+		return nil
+	}
+	session, ok := m.sessionByID(sessionID)
+	if !ok {
+		return nil
+	}
+	paneLabel := ""
+	if pane, ok := m.paneFor(sessionID); ok {
+		paneLabel = pane.TitleOrCmd()
+	}
+	now := time.Now()
+	var cmds []tea.Cmd
+	for _, rule := range m.alertRules {
+		if !globMatches(rule.session, session.Name) || !globMatches(rule.pane, paneLabel) {
+			continue
+		}
+		idx := slices.IndexFunc(lines, rule.re.MatchString)
+		if idx < 0 {
+			continue
+		}
+		key := rule.name + "\x00" + paneID
+		if last, ok := m.alertFired[key]; ok && now.Sub(last) < rule.cooldown {
+			continue
+		}
+		if m.alertFired == nil {
+			m.alertFired = make(map[string]time.Time)
+		}
+		m.alertFired[key] = now
+		line := strings.TrimSpace(lines[idx])
+		m.recordAlert(rule.name, sessionID, paneID, line, now)
+		m.logEvent(eventAlert, sessionID, rule.name+": "+line)
+		cmds = append(cmds, m.runAlertActions(rule, session.Name, sessionID, line)...)
+	}
+	return tea.Batch(cmds...)
+}
+
+// globMatches treats an empty pattern as a match-all.
+func globMatches(pattern, value string) bool {
+	if pattern == "" {
+		return true
+	}
+	ok, _ := path.Match(pattern, value)
+	return ok
+}
+
+// recordAlert adds or refreshes the alert for rule on paneID.
+func (m *Model) recordAlert(rule, sessionID, paneID, line string, at time.Time) {
+	for i := range m.alerts {
+		if m.alerts[i].rule == rule && m.alerts[i].paneID == paneID {
+			m.alerts[i].line = line
+			m.alerts[i].at = at
+			m.alerts[i].count++
+			return
+		}
+	}
+	m.alerts = append(m.alerts, alert{rule: rule, sessionID: sessionID, paneID: paneID, line: line, at: at, count: 1})
+}
+
+// runAlertActions applies a rule's actions and returns any commands they need.
+func (m *Model) runAlertActions(rule alertRule, sessionName, sessionID, line string) []tea.Cmd {
+	var cmds []tea.Cmd
+	for _, act := range rule.actions {
+		switch act {
+		case "highlight":
+			if m.highlighted == nil {
+				m.highlighted = make(map[string]struct{})
+			}
+			m.highlighted[sessionID] = struct{}{}
+		case "unread":
+			if sessionID != m.focusedSession {
+				if m.unread == nil {
+					m.unread = make(map[string]struct{})
+				}
+				m.unread[sessionID] = struct{}{}
+			}
+		case "bell":
+			cmds = append(cmds, tea.Raw("\a"))
+		case "toast":
+			m.showToast(fmt.Sprintf("%s in %s: %s", rule.name, sessionName, truncate(line, maxAlertLineWidth)))
+		}
+	}
+	return cmds
+}
+
+// isHighlighted reports whether an alert highlights the session's card.
+func (m *Model) isHighlighted(sessionID string) bool {
+	_, ok := m.highlighted[sessionID]
+	return ok
+}
+
+// isUnread reports whether the session has output the user has not looked at.
+func (m *Model) isUnread(sessionID string) bool {
+	_, ok := m.unread[sessionID]
+	return ok
+}
+
+// markRead clears the unread badge once the user focuses a session.
+func (m *Model) markRead(sessionID string) {
+	delete(m.unread, sessionID)
+}
+
+// acknowledgeAlerts clears alerts for sessionID, or every alert when
+// sessionID is empty, and returns how many were cleared.
+func (m *Model) acknowledgeAlerts(sessionID string) int {
+	kept := m.alerts[:0]
+	cleared := 0
+	for _, a := range m.alerts {
+		if sessionID != "" && a.sessionID != sessionID {
+			kept = append(kept, a)
+			continue
+		}
+		cleared++
+	}
+	m.alerts = kept
+	if sessionID == "" {
+		m.highlighted = nil
+		m.unread = nil
+	} else {
+		delete(m.highlighted, sessionID)
+		delete(m.unread, sessionID)
+	}
+	return cleared
+}
+
+// acknowledgeAlert clears a single alert, dropping the card highlight once
+// its session has no alerts left.
+func (m *Model) acknowledgeAlert(rule, paneID string) {
+	var sessionID string
+	m.alerts = slices.DeleteFunc(m.alerts, func(a alert) bool {
+		if a.rule == rule && a.paneID == paneID {
+			sessionID = a.sessionID
+			return true
+		}
+		return false
+	})
+	if sessionID == "" {
+		return
+	}
+	if !slices.ContainsFunc(m.alerts, func(a alert) bool { return a.sessionID == sessionID }) {
+		delete(m.highlighted, sessionID)
+		delete(m.unread, sessionID)
+	}
+}
+
+// acknowledgeCmd acknowledges the cursor session's alerts, or all alerts when
+// the cursor session has none.
+func (m *Model) acknowledgeCmd() tea.Cmd {
+	target := m.focusedSession
+	if target == "" {
+		target = m.cursorSession
+	}
+	if !slices.ContainsFunc(m.alerts, func(a alert) bool { return a.sessionID == target }) {
+		target = ""
+	}
+	if n := m.acknowledgeAlerts(target); n > 0 {
+		return showStatusMessage(fmt.Sprintf("Acknowledged %d alert(s)", n))
+	}
+	return nil
+}
+
+// pruneAlerts drops alert state for sessions that no longer exist.
+func (m *Model) pruneAlerts() {
+	m.alerts = slices.DeleteFunc(m.alerts, func(a alert) bool {
+		return !m.sessionExists(a.sessionID)
+	})
+	for id := range m.highlighted {
+		if !m.sessionExists(id) {
+			delete(m.highlighted, id)
+		}
+	}
+	for id := range m.unread {
+		if !m.sessionExists(id) {
+			delete(m.unread, id)
+		}
+	}
+}
+
+// maxSummaryRules caps how many rules the title bar names before folding the
+// rest into a count.
+const maxSummaryRules = 3
+
+// alertSummary describes active alerts for the title bar: the single alert
+// with its session, or every firing rule, newest first, with its alert count.
+func (m *Model) alertSummary() string {
+	if len(m.alerts) == 0 {
+		return ""
+	}
+	if len(m.alerts) == 1 {
+		a := m.alerts[0]
+		name := sessionLabel(a.sessionID)
+		if session, ok := m.sessionByID(a.sessionID); ok {
+			name = session.Name
+		}
+		return fmt.Sprintf("⚠ %s @ %s", a.rule, name)
+	}
+	newest := slices.Clone(m.alerts)
+	slices.SortStableFunc(newest, func(a, b alert) int { return b.at.Compare(a.at) })
+	var rules []string
+	counts := make(map[string]int)
+	for _, a := range newest {
+		if counts[a.rule] == 0 {
+			rules = append(rules, a.rule)
+		}
+		counts[a.rule]++
+	}
+	parts := make([]string, 0, maxSummaryRules)
+	for _, rule := range rules[:min(len(rules), maxSummaryRules)] {
+		if n := counts[rule]; n > 1 {
+			rule += fmt.Sprintf(" ×%d", n)
+		}
+		parts = append(parts, rule)
+	}
+	summary := "⚠ " + strings.Join(parts, " · ")
+	if extra := len(rules) - maxSummaryRules; extra > 0 {
+		summary += fmt.Sprintf(" +%d more", extra)
+	}
+	return summary
+}
+
+// alertPaletteCommands offers acknowledging each alert or all of them.
+func (m *Model) alertPaletteCommands() []commandItem {
+	if len(m.alerts) == 0 {
+		return nil
+	}
+	items := []commandItem{{
+		label:   fmt.Sprintf("Acknowledge all alerts (%d)", len(m.alerts)),
+		enabled: true,
+		run: func(m *Model) tea.Cmd {
+			m.acknowledgeAlerts("")
+			return nil
+		},
+	}}
+	for _, a := range m.alerts {
+		name := sessionLabel(a.sessionID)
+		if session, ok := m.sessionByID(a.sessionID); ok {
+			name = session.Name
+		}
+		label := fmt.Sprintf("Acknowledge %s @ %s: %s", a.rule, name, truncate(a.line, 40))
+		if a.count > 1 {
+			label += fmt.Sprintf(" (×%d)", a.count)
+		}
+		rule, paneID := a.rule, a.paneID
+		items = append(items, commandItem{
+			label:   label,
+			enabled: true,
+			run: func(m *Model) tea.Cmd {
+				m.acknowledgeAlert(rule, paneID)
+				return nil
+			},
+		})
+	}
+	return items
+}
@@ -0,0 +1,204 @@
+// File alerts_test.go covers alert rule matching, cooldowns, and
+// acknowledgement.
+package ui
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+
+	"github.com/steipete/tmuxwatch/internal/config"
+	"github.com/steipete/tmuxwatch/internal/tmux"
+)
+
+// alertRules compiles rules for a test.
+func alertRules(t *testing.T, rules ...config.AlertRule) []alertRule {
+	t.Helper()
+	compiled, err := compileAlertRules(rules)
+	if err != nil {
+		t.Fatalf("compileAlertRules: %v", err)
+	}
+	return compiled
+}
+
+// TestNewLines returns only output that was not in the previous capture.
+func TestNewLines(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name string
+		prev string
+		next string
+		want []string
+	}{
+		{name: "first capture", prev: "", next: "a\nb", want: []string{"a", "b"}},
+		{name: "appended", prev: "a\nb", next: "a\nb\nc", want: []string{"c"}},
+		{name: "scrolled", prev: "a\nb\nc", next: "b\nc\nd\ne", want: []string{"d", "e"}},
+		{name: "partial last line", prev: "a\nb\n$ ma", next: "b\n$ make\nok", want: []string{"$ make", "ok"}},
+		{name: "cleared", prev: "a\nb", next: "x\ny", want: []string{"x", "y"}},
+		{name: "unchanged", prev: "a\nb", next: "a\nb", want: []string{}},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			got := newLines(tc.prev, tc.next)
+			if !slices.Equal(got, tc.want) {
+				t.Fatalf("newLines(%q, %q) = %q, want %q", tc.prev, tc.next, got, tc.want)
+			}
+		})
+	}
+}
+
+// TestScanAlertsRespectsCooldown fires once per rule and pane until the
+// cooldown has elapsed, counting nothing in between.
+func TestScanAlertsRespectsCooldown(t *testing.T) {
+	t.Parallel()
+
+	m := testModel(
+		lifecycleSession("$1", "ci-main", tmux.Pane{ID: "%1", Title: "build", Active: true}),
+		lifecycleSession("$2", "notes", tmux.Pane{ID: "%2", Title: "vim", Active: true}),
+	)
+	m.alertRules = alertRules(t, config.AlertRule{Name: "panic", Pattern: `panic:`, Actions: []string{"highlight", "toast"}})
+	m.scanAlerts("$1", "%1", []string{"ok", "panic: boom"})
+	if len(m.alerts) != 1 || m.alerts[0].line != "panic: boom" {
+		t.Fatalf("alerts = %+v, want one panic alert", m.alerts)
+	}
+	if !m.isHighlighted("$1") {
+		t.Fatal("highlight action should mark the card")
+	}
+	if m.toast == nil || m.toast.text == "" {
+		t.Fatal("toast action should show a toast")
+	}
+
+	m.scanAlerts("$1", "%1", []string{"panic: again"})
+	if m.alerts[0].count != 1 {
+		t.Fatalf("count = %d, want 1 within cooldown", m.alerts[0].count)
+	}
+
+	m.alertFired["panic\x00%1"] = time.Now().Add(-time.Hour)
+	m.scanAlerts("$1", "%1", []string{"panic: again"})
+	if m.alerts[0].count != 2 || m.alerts[0].line != "panic: again" {
+		t.Fatalf("alert = %+v, want refreshed after cooldown", m.alerts[0])
+	}
+}
+
+// TestScanAlertsScopes limits rules to matching session and pane globs.
+func TestScanAlertsScopes(t *testing.T) {
+	t.Parallel()
+
+	m := testModel(
+		lifecycleSession("$1", "ci-main", tmux.Pane{ID: "%1", Title: "build", Active: true}),
+		lifecycleSession("$2", "notes", tmux.Pane{ID: "%2", Title: "vim", Active: true}),
+	)
+	m.alertRules = alertRules(
+		t,
+		config.AlertRule{Name: "ci", Pattern: `FAIL`, Session: "ci-*"},
+		config.AlertRule{Name: "editor", Pattern: `FAIL`, Pane: "vim"},
+	)
+	m.scanAlerts("$1", "%1", []string{"FAIL"})
+	m.scanAlerts("$2", "%2", []string{"FAIL"})
+	var got []string
+	for _, a := range m.alerts {
+		got = append(got, a.rule+"@"+a.sessionID)
+	}
+	if !slices.Equal(got, []string{"ci@$1", "editor@$2"}) {
+		t.Fatalf("alerts = %v, want [ci@$1 editor@$2]", got)
+	}
+}
+
+// TestAcknowledgeAlerts clears one session's alerts, then the rest.
+func TestAcknowledgeAlerts(t *testing.T) {
+	t.Parallel()
+
+	m := testModel(
+		lifecycleSession("$1", "ci-main", tmux.Pane{ID: "%1", Title: "build", Active: true}),
+		lifecycleSession("$2", "notes", tmux.Pane{ID: "%2", Title: "vim", Active: true}),
+	)
+	m.alertRules = alertRules(t, config.AlertRule{Pattern: `error`, Actions: []string{"highlight", "unread"}})
+	m.scanAlerts("$1", "%1", []string{"error 1"})
+	m.scanAlerts("$2", "%2", []string{"error 2"})
+
+	m.cursorSession = "$2"
+	if cmd := m.acknowledgeCmd(); cmd == nil {
+		t.Fatal("expected a status message")
+	}
+	if m.isHighlighted("$2") || m.isUnread("$2") {
+		t.Fatal("cursor session should be cleared")
+	}
+	if !m.isHighlighted("$1") || len(m.alerts) != 1 {
+		t.Fatalf("other session should keep its alert, got %+v", m.alerts)
+	}
+
+	m.acknowledgeAlert("error", "%1")
+	if len(m.alerts) != 0 || m.isHighlighted("$1") {
+		t.Fatal("acknowledging the last alert should drop the highlight")
+	}
+}
+
+// TestUnreadClearedOnFocus skips the focused session and clears the badge
+// once a card is focused.
+func TestUnreadClearedOnFocus(t *testing.T) {
+	t.Parallel()
+
+	m := testModel(
+		lifecycleSession("$1", "ci-main", tmux.Pane{ID: "%1", Title: "build", Active: true}),
+		lifecycleSession("$2", "notes", tmux.Pane{ID: "%2", Title: "vim", Active: true}),
+	)
+	m.alertRules = alertRules(t, config.AlertRule{Pattern: `done`, Actions: []string{"unread"}})
+	m.focusedSession = "$2"
+	m.scanAlerts("$1", "%1", []string{"done"})
+	m.scanAlerts("$2", "%2", []string{"done"})
+	if !m.isUnread("$1") || m.isUnread("$2") {
+		t.Fatal("only the unfocused session should be unread")
+	}
+
+	m.cursorSession = "$1"
+	m.handleGlobalKey(tea.KeyPressMsg{Code: tea.KeyEnter})
+	if m.isUnread("$1") {
+		t.Fatal("focusing should mark the session read")
+	}
+}
+
+// TestAlertSummaryListsRules names a single alert's session and otherwise
+// every firing rule, newest first, with counts and an overflow tail.
+func TestAlertSummaryListsRules(t *testing.T) {
+	t.Parallel()
+
+	at := time.Unix(1_000, 0)
+	alertAt := func(rule, pane string, minute int) alert {
+		return alert{rule: rule, sessionID: "$1", paneID: pane, at: at.Add(time.Duration(minute) * time.Minute)}
+	}
+	tests := []struct {
+		name   string
+		alerts []alert
+		want   string
+	}{
+		{name: "none", want: ""},
+		{name: "single", alerts: []alert{alertAt("error", "%1", 0)}, want: "⚠ error @ api"},
+		{
+			name:   "counts per rule",
+			alerts: []alert{alertAt("error", "%1", 0), alertAt("panic", "%1", 1), alertAt("error", "%2", 2)},
+			want:   "⚠ error ×2 · panic",
+		},
+		{
+			name: "overflow",
+			alerts: []alert{
+				alertAt("a", "%1", 0), alertAt("b", "%1", 1), alertAt("c", "%1", 2),
+				alertAt("d", "%1", 3), alertAt("e", "%1", 4),
+			},
+			want: "⚠ e · d · c +2 more",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			m := testModel(tmux.Session{ID: "$1", Name: "api"})
+			m.alerts = tt.alerts
+			if got := m.alertSummary(); got != tt.want {
+				t.Fatalf("alertSummary() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
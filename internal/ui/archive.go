@@ -0,0 +1,195 @@
+// File archive.go implements the archive browser, which lists sessions whose
+// scrollback was saved before they were killed and shows their final output.
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+
+	"github.com/steipete/tmuxwatch/internal/archive"
+)
+
+// archiveRows is how many archives or output lines the browser shows at once.
+const archiveRows = 16
+
+// archiveReader shows one archived pane's output.
+type archiveReader struct {
+	entry  archive.Entry
+	pane   int
+	lines  []string
+	offset int
+	err    error
+}
+
+// openArchive loads the archive list and shows the browser.
+func (m *Model) openArchive() {
+	m.closePalette()
+	m.closeTimeline()
+	if m.archiveDir == "" {
+		m.showToast("Archiving is disabled")
+		return
+	}
+	entries, err := archive.List(m.archiveDir)
+	if err != nil {
+		m.showToast(err.Error())
+		return
+	}
+	m.archiveEntries = entries
+	m.archiveIndex = 0
+	m.archiveReader = nil
+	m.archiveOpen = true
+}
+
+// closeArchive hides the browser.
+func (m *Model) closeArchive() {
+	m.archiveOpen = false
+	m.archiveReader = nil
+}
+
+// openArchivePane reads pane i of entry and shows it from the end, where
+// the session's final output is.
+func (m *Model) openArchivePane(entry archive.Entry, i int) {
+	r := &archiveReader{entry: entry, pane: i}
+	out, err := entry.ReadPane(i)
+	if err != nil {
+		r.err = err
+	} else {
+		r.lines = strings.Split(strings.TrimRight(out, "\n"), "\n")
+	}
+	r.offset = max(len(r.lines)-archiveRows, 0)
+	m.archiveReader = r
+}
+
+// handleArchiveKey navigates the archive list, and inside an archive scrolls
+// the output and switches panes with tab.
+func (m *Model) handleArchiveKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if _, ok := msg.(tea.KeyPressMsg); !ok {
+		return m, nil
+	}
+	key := msg.String()
+	if r := m.archiveReader; r != nil {
+		last := max(len(r.lines)-archiveRows, 0)
+		panes := len(r.entry.Meta.Panes)
+		switch key {
+		case "esc", "backspace":
+			m.archiveReader = nil
+		case "up", "k":
+			r.offset = max(r.offset-1, 0)
+		case "down", "j":
+			r.offset = min(r.offset+1, last)
+		case "pgup":
+			r.offset = max(r.offset-archiveRows, 0)
+		case "pgdown", "space":
+			r.offset = min(r.offset+archiveRows, last)
+		case "g", "home":
+			r.offset = 0
+		case "G", "end":
+			r.offset = last
+		case "tab":
+			m.openArchivePane(r.entry, (r.pane+1)%panes)
+		case "shift+tab":
+			m.openArchivePane(r.entry, (r.pane+panes-1)%panes)
+		}
+		return m, nil
+	}
+	if m.keymap().lookup(scopeGlobal, key) == actionArchive {
+		m.closeArchive()
+		return m, nil
+	}
+	switch key {
+	case "esc", "q":
+		m.closeArchive()
+	case "up", "k":
+		m.archiveIndex = max(m.archiveIndex-1, 0)
+	case "down", "j":
+		m.archiveIndex = min(m.archiveIndex+1, max(len(m.archiveEntries)-1, 0))
+	case "enter":
+		if m.archiveIndex < len(m.archiveEntries) && len(m.archiveEntries[m.archiveIndex].Meta.Panes) > 0 {
+			m.openArchivePane(m.archiveEntries[m.archiveIndex], 0)
+		}
+	}
+	return m, nil
+}
+
+// renderArchive draws the archive list or the selected pane's output.
+func (m *Model) renderArchive(width int) string {
+	th := m.colors()
+	inner := max(width-6, 20)
+	title := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color(th.overlayText))
+	muted := lipgloss.NewStyle().Foreground(lipgloss.Color(th.overlayMuted))
+	text := lipgloss.NewStyle().Foreground(lipgloss.Color(th.overlayText))
+
+	var header string
+	var lines []string
+	if r := m.archiveReader; r != nil {
+		meta := r.entry.Meta
+		pane := meta.Panes[r.pane]
+		header = title.Render(meta.Session) + muted.Render(fmt.Sprintf(
+			"  killed %s · pane %d/%d %s %s · tab switches panes · esc back",
+			meta.KilledAt.Local().Format("2006-01-02 15:04"), r.pane+1, len(meta.Panes), pane.Window, archivePaneLabel(pane),
+		))
+		switch {
+		case r.err != nil:
+			lines = append(lines, lipgloss.NewStyle().Foreground(lipgloss.Color(th.errorText)).Render(r.err.Error()))
+		default:
+			end := min(r.offset+archiveRows, len(r.lines))
+			for _, line := range r.lines[r.offset:end] {
+				lines = append(lines, text.Render(truncate(line, inner)))
+			}
+		}
+	} else {
+		header = title.Render("archive") + muted.Render("  enter opens · esc closes")
+		if len(m.archiveEntries) == 0 {
+			lines = append(lines, muted.Render("no archived sessions"))
+		}
+		start := 0
+		if m.archiveIndex >= archiveRows {
+			start = m.archiveIndex - archiveRows + 1
+		}
+		for i := start; i < len(m.archiveEntries) && i < start+archiveRows; i++ {
+			meta := m.archiveEntries[i].Meta
+			marker, style := "  ", text
+			if i == m.archiveIndex {
+				marker, style = "▸ ", text.Bold(true)
+			}
+			var panes []string
+			for _, pane := range meta.Panes {
+				panes = append(panes, archivePaneLabel(pane))
+			}
+			line := fmt.Sprintf("%s  %-16s  %s", meta.KilledAt.Local().Format("2006-01-02 15:04"), truncate(meta.Session, 16), strings.Join(panes, ", "))
+			lines = append(lines, marker+style.Render(truncate(line, inner-2)))
+		}
+	}
+	return paletteStyle(th).
+		MarginTop(0).
+		Padding(0, 1).
+		Width(width).
+		Render(lipgloss.JoinVertical(lipgloss.Left, header, strings.Join(lines, "\n")))
+}
+
+// archivePaneLabel names an archived pane by command, noting how it exited.
+func archivePaneLabel(pane archive.Pane) string {
+	label := pane.Command
+	if label == "" {
+		label = pane.ID
+	}
+	if pane.Dead {
+		label += fmt.Sprintf(" (exit %d)", pane.ExitCode)
+	}
+	return label
+}
+
+// archivePaletteCommands offers the archive browser when archiving is on.
+func (m *Model) archivePaletteCommands() []commandItem {
+	return []commandItem{{
+		label:   "Browse archived sessions",
+		enabled: m.archiveDir != "",
+		run: func(m *Model) tea.Cmd {
+			m.openArchive()
+			return nil
+		},
+	}}
+}
@@ -0,0 +1,81 @@
+// File archive_test.go covers browsing archived sessions.
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+
+	"github.com/steipete/tmuxwatch/internal/archive"
+)
+
+// TestArchiveBrowserShowsFinalOutput lists archives newest first, opens one
+// at the end of its output, and switches panes with tab.
+func TestArchiveBrowserShowsFinalOutput(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	at := time.Date(2026, 10, 18, 14, 0, 0, 0, time.UTC)
+	var long []string
+	for i := 1; i <= 40; i++ {
+		long = append(long, fmt.Sprintf("line %d", i))
+	}
+	for _, meta := range []archive.Meta{
+		{Session: "old", KilledAt: at.Add(-time.Hour), Panes: []archive.Pane{{ID: "%1", Window: "main", Output: "bye\n"}}},
+		{Session: "build", KilledAt: at, Panes: []archive.Pane{
+			{ID: "%2", Window: "make", Command: "make", Dead: true, ExitCode: 2, Output: strings.Join(long, "\n") + "\n"},
+			{ID: "%3", Window: "logs", Command: "tail", Output: "tail output\n"},
+		}},
+	} {
+		if _, err := archive.Write(dir, meta); err != nil {
+			t.Fatalf("Write returned error: %v", err)
+		}
+	}
+
+	m := &Model{archiveDir: dir}
+	m.openArchive()
+	if !m.archiveOpen || len(m.archiveEntries) != 2 || m.archiveEntries[0].Meta.Session != "build" {
+		t.Fatalf("entries = %+v", m.archiveEntries)
+	}
+	if view := m.renderArchive(100); !strings.Contains(view, "make (exit 2), tail") {
+		t.Fatalf("list view missing pane summary:\n%s", view)
+	}
+
+	m.handleArchiveKey(tea.KeyPressMsg{Code: tea.KeyEnter})
+	r := m.archiveReader
+	if r == nil || r.pane != 0 {
+		t.Fatal("enter should open the first pane")
+	}
+	view := m.renderArchive(100)
+	if !strings.Contains(view, "line 40") || strings.Contains(view, "line 24\n") {
+		t.Fatalf("reader should start at the final output:\n%s", view)
+	}
+
+	m.handleArchiveKey(tea.KeyPressMsg{Code: tea.KeyTab})
+	if m.archiveReader.pane != 1 || !strings.Contains(m.renderArchive(100), "tail output") {
+		t.Fatalf("tab should switch to the second pane")
+	}
+
+	m.handleArchiveKey(tea.KeyPressMsg{Code: tea.KeyEscape})
+	if m.archiveReader != nil || !m.archiveOpen {
+		t.Fatal("esc should return to the list")
+	}
+	m.handleArchiveKey(tea.KeyPressMsg{Code: tea.KeyEscape})
+	if m.archiveOpen {
+		t.Fatal("esc should close the browser")
+	}
+}
+
+// TestArchiveDisabled refuses to open the browser without an archive dir.
+func TestArchiveDisabled(t *testing.T) {
+	t.Parallel()
+
+	m := &Model{toast: &toastState{}}
+	m.openArchive()
+	if m.archiveOpen {
+		t.Fatal("browser should stay closed when archiving is disabled")
+	}
+}
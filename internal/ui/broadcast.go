@@ -0,0 +1,233 @@
+// File broadcast.go implements broadcast mode, which types every key into
+// the active pane of each selected card, or of every card matching the
+// filter when nothing is selected, like tmux's synchronize-panes across
+// sessions. Dead panes are skipped and delivery errors are kept per pane.
+package ui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+
+	"github.com/steipete/tmuxwatch/internal/tmux"
+)
+
+// broadcastTarget is one pane receiving broadcast input.
+type broadcastTarget struct {
+	sessionID string
+	session   string
+	paneID    string
+}
+
+// broadcastTargets lists the live panes broadcast input goes to and counts
+// the dead ones skipped. Targets are resolved on every key, so panes that
+// exit mid-broadcast drop out.
+func (m *Model) broadcastTargets() ([]broadcastTarget, int) {
+	var sessions []tmux.Session
+	if ids := m.selectedIDs(); len(ids) > 0 {
+		for _, id := range ids {
+			if session, ok := m.sessionByID(id); ok {
+				sessions = append(sessions, session)
+			}
+		}
+	} else {
+		sessions = m.orderedSessions()
+	}
+	var targets []broadcastTarget
+	dead := 0
+	for _, session := range sessions {
+		pane, ok := m.paneFor(session.ID)
+		switch {
+		case !ok:
+		case pane.Dead:
+			dead++
+		default:
+			targets = append(targets, broadcastTarget{sessionID: session.ID, session: session.Name, paneID: pane.ID})
+		}
+	}
+	return targets, dead
+}
+
+// startBroadcast enters broadcast mode when there is at least one target.
+func (m *Model) startBroadcast() tea.Cmd {
+	targets, _ := m.broadcastTargets()
+	if len(targets) == 0 {
+		return showStatusMessage("No live panes to broadcast to")
+	}
+	m.broadcasting = true
+	m.broadcastErrs = nil
+	m.lastEsc = time.Time{}
+	m.leaveInsertMode()
+	m.resetCtrlC()
+	for _, target := range targets {
+		m.logEvent(eventAction, target.sessionID, "started broadcast")
+	}
+	return nil
+}
+
+// stopBroadcast leaves broadcast mode.
+func (m *Model) stopBroadcast() {
+	m.broadcasting = false
+	m.broadcastErrs = nil
+	m.lastEsc = time.Time{}
+}
+
+// handleBroadcastKey sends a key to every target. Pressing the back key twice
+// in quick succession stops broadcasting; the first press is still sent.
+func (m *Model) handleBroadcastKey(msg tea.KeyMsg) tea.Cmd {
+	if m.keymap().lookup(scopeGlobal, msg.String()) == actionBack {
+		now := time.Now()
+		if !m.lastEsc.IsZero() && now.Sub(m.lastEsc) < quitChordWindow {
+			m.stopBroadcast()
+			return nil
+		}
+		m.lastEsc = now
+	} else {
+		m.lastEsc = time.Time{}
+	}
+	key, ok := tmuxKeyFrom(msg)
+	if !ok {
+		return nil
+	}
+	return m.broadcast(func(ctx context.Context, client *tmux.Client, paneID string) error {
+		if key.literal {
+			return client.SendLiteral(ctx, paneID, key.name)
+		}
+		return client.SendKeys(ctx, paneID, key.name)
+	})
+}
+
+// broadcastPaste pastes text into every target.
+func (m *Model) broadcastPaste(text string) tea.Cmd {
+	if text == "" {
+		return nil
+	}
+	return m.broadcast(func(ctx context.Context, client *tmux.Client, paneID string) error {
+		return client.Paste(ctx, paneID, text)
+	})
+}
+
+// broadcastSend delivers one key or paste to one pane.
+type broadcastSend func(ctx context.Context, client *tmux.Client, paneID string) error
+
+// broadcastJob is one queued key or paste and the panes it goes to.
+type broadcastJob struct {
+	paneIDs []string
+	send    broadcastSend
+}
+
+// broadcast queues send for every target pane. Only one delivery command
+// runs at a time, so each pane receives keys in the order they were typed.
+func (m *Model) broadcast(send broadcastSend) tea.Cmd {
+	targets, _ := m.broadcastTargets()
+	if len(targets) == 0 {
+		return nil
+	}
+	panes := make([]string, 0, len(targets))
+	for _, target := range targets {
+		panes = append(panes, target.paneID)
+	}
+	m.broadcastQueue = append(m.broadcastQueue, broadcastJob{paneIDs: panes, send: send})
+	if m.broadcastBusy {
+		return nil
+	}
+	return m.nextBroadcast()
+}
+
+// nextBroadcast starts delivering everything queued so far, or marks the
+// queue idle when it is empty.
+func (m *Model) nextBroadcast() tea.Cmd {
+	if len(m.broadcastQueue) == 0 {
+		m.broadcastBusy = false
+		return nil
+	}
+	jobs := m.broadcastQueue
+	m.broadcastQueue = nil
+	m.broadcastBusy = true
+	return broadcastCmd(m.client, jobs)
+}
+
+// broadcastCmd runs the jobs in order and reports each pane's last result.
+func broadcastCmd(client *tmux.Client, jobs []broadcastJob) tea.Cmd {
+	return func() tea.Msg {
+		results := make(map[string]error)
+		for _, job := range jobs {
+			for _, paneID := range job.paneIDs {
+				ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+				results[paneID] = job.send(ctx, client, paneID)
+				cancel()
+			}
+		}
+		return broadcastMsg{results: results}
+	}
+}
+
+// handleBroadcastResult records delivery errors, clears them once a pane
+// accepts input again, and starts the keys queued in the meantime.
+func (m *Model) handleBroadcastResult(msg broadcastMsg) tea.Cmd {
+	for paneID, err := range msg.results {
+		if err == nil {
+			delete(m.broadcastErrs, paneID)
+			continue
+		}
+		if m.broadcastErrs == nil {
+			m.broadcastErrs = make(map[string]error)
+		}
+		m.broadcastErrs[paneID] = err
+	}
+	return m.nextBroadcast()
+}
+
+// renderBroadcastBanner draws the full-width banner shown while
+// broadcasting, naming the targets and any panes that failed.
+func (m *Model) renderBroadcastBanner(width int) string {
+	th := m.colors()
+	targets, dead := m.broadcastTargets()
+	names := make([]string, 0, len(targets))
+	for _, target := range targets {
+		names = append(names, target.session)
+	}
+	text := fmt.Sprintf("BROADCAST to %d panes: %s", len(targets), strings.Join(names, ", "))
+	if dead > 0 {
+		text += fmt.Sprintf(" · %d dead skipped", dead)
+	}
+	text += " · esc esc stops"
+	banner := lipgloss.NewStyle().
+		Bold(true).
+		Width(width).
+		Padding(0, 2).
+		Foreground(lipgloss.Color(th.accentText)).
+		Background(lipgloss.Color(th.borderExitFail))
+	lines := []string{banner.Render(truncate(text, max(width-4, 1)))}
+	var failures []string
+	for _, target := range targets {
+		if err, ok := m.broadcastErrs[target.paneID]; ok {
+			failures = append(failures, fmt.Sprintf("%s %s: %v", target.session, target.paneID, err))
+		}
+	}
+	if len(failures) > 0 {
+		lines = append(lines, lipgloss.NewStyle().
+			Padding(0, 2).
+			Foreground(lipgloss.Color(th.errorText)).
+			Render(truncate("failed: "+strings.Join(failures, "; "), max(width-4, 1))))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// broadcastPaletteCommands offers broadcast mode to the current targets.
+func (m *Model) broadcastPaletteCommands() []commandItem {
+	targets, _ := m.broadcastTargets()
+	scope := "matching"
+	if len(m.selectedIDs()) > 0 {
+		scope = "selected"
+	}
+	return []commandItem{{
+		label:   fmt.Sprintf("Broadcast input to %d %s panes", len(targets), scope),
+		enabled: len(targets) > 0,
+		run:     func(m *Model) tea.Cmd { return m.startBroadcast() },
+	}}
+}
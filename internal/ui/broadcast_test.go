@@ -0,0 +1,106 @@
+// File broadcast_test.go covers broadcast mode.
+package ui
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+
+	"github.com/steipete/tmuxwatch/internal/tmux"
+)
+
+// TestBroadcastTargets prefers the selection, falls back to the cards
+// matching the filter, and skips dead panes.
+func TestBroadcastTargets(t *testing.T) {
+	t.Parallel()
+
+	m := testModel(selectionTestSessions()...)
+	m.searchQuery = "api"
+	targets, dead := m.broadcastTargets()
+	if len(targets) != 2 || dead != 0 {
+		t.Fatalf("targets = %+v, dead = %d, want the two matching cards", targets, dead)
+	}
+
+	m.searchQuery = ""
+	m.toggleSelected("$2")
+	m.toggleSelected("$3")
+	targets, dead = m.broadcastTargets()
+	if len(targets) != 1 || targets[0].paneID != "%2" || dead != 1 {
+		t.Fatalf("targets = %+v, dead = %d, want the selected live pane", targets, dead)
+	}
+}
+
+// TestBroadcastMode sends keys to every target, shows per-pane errors in the
+// banner, and stops on esc esc.
+func TestBroadcastMode(t *testing.T) {
+	t.Parallel()
+
+	m := testModel(selectionTestSessions()...)
+	m.sessions = append(m.sessions, lifecycleSession("$4", "api-cron", tmux.Pane{ID: "%4"}))
+	m.searchQuery = "api"
+	m.Update(tea.KeyPressMsg{Code: 'B', Text: "B"})
+	if !m.broadcasting || m.inputMode() != modeBroadcast {
+		t.Fatal("B should start broadcasting")
+	}
+	if _, cmd := m.Update(tea.KeyPressMsg{Code: 'q', Text: "q"}); cmd == nil || !m.broadcasting {
+		t.Fatal("q should be broadcast instead of quitting")
+	}
+
+	m.Update(broadcastMsg{results: map[string]error{"%1": nil, "%2": errors.New("can't find pane"), "%4": nil}})
+	banner := m.renderBroadcastBanner(200)
+	if !strings.Contains(banner, "BROADCAST to 3 panes: api-server, api-worker, api-cron") ||
+		!strings.Contains(banner, "failed: api-worker %2: can't find pane") {
+		t.Fatalf("banner = %q", banner)
+	}
+	m.Update(broadcastMsg{results: map[string]error{"%2": nil}})
+	if strings.Contains(m.renderBroadcastBanner(200), "failed") {
+		t.Fatal("a delivered key should clear the pane's error")
+	}
+
+	if _, cmd := m.Update(tea.KeyPressMsg{Code: tea.KeyEscape}); cmd == nil || !m.broadcasting {
+		t.Fatal("the first esc should be broadcast")
+	}
+	m.Update(tea.KeyPressMsg{Code: tea.KeyEscape})
+	if m.broadcasting {
+		t.Fatal("esc esc should stop broadcasting")
+	}
+}
+
+// TestBroadcastQueuesKeys runs one delivery at a time, queues keys typed
+// meanwhile, and delivers them to each pane in the order they were typed.
+func TestBroadcastQueuesKeys(t *testing.T) {
+	t.Parallel()
+
+	m := testModel(selectionTestSessions()...)
+	m.searchQuery = "api"
+	var sent []string
+	typed := func(key string) broadcastSend {
+		return func(_ context.Context, _ *tmux.Client, paneID string) error {
+			sent = append(sent, paneID+":"+key)
+			return nil
+		}
+	}
+	first := m.broadcast(typed("a"))
+	if first == nil || !m.broadcastBusy {
+		t.Fatal("the first key should start a delivery")
+	}
+	if m.broadcast(typed("b")) != nil || m.broadcast(typed("c")) != nil || len(m.broadcastQueue) != 2 {
+		t.Fatalf("keys typed during a delivery should queue, queue = %d", len(m.broadcastQueue))
+	}
+
+	_, next := m.Update(first())
+	if next == nil || len(m.broadcastQueue) != 0 {
+		t.Fatal("a finished delivery should start the queued keys")
+	}
+	if _, cmd := m.Update(next()); cmd != nil || m.broadcastBusy {
+		t.Fatal("the queue should be idle once drained")
+	}
+	want := []string{"%1:a", "%2:a", "%1:b", "%2:b", "%1:c", "%2:c"}
+	if !slices.Equal(sent, want) {
+		t.Fatalf("sent = %q, want %q", sent, want)
+	}
+}
@@ -0,0 +1,145 @@
+// File cardmenu.go implements the card context menu, opened by right-clicking
+// a card or with the card-menu key, which lists the actions for that card.
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+	zone "github.com/steipete/tmuxwatch/internal/zone"
+)
+
+// cardMenu is an open context menu. x and y place it at the pointer; a
+// menu opened from the keyboard has x < 0 and is centred.
+type cardMenu struct {
+	sessionID string
+	items     []commandItem
+	index     int
+	x, y      int
+}
+
+// openCardMenu shows the context menu for a card.
+func (m *Model) openCardMenu(sessionID string, x, y int) {
+	if !m.sessionExists(sessionID) {
+		return
+	}
+	m.closePalette()
+	m.cardMenu = &cardMenu{sessionID: sessionID, items: m.cardMenuItems(sessionID), x: x, y: y}
+}
+
+// closeCardMenu hides the context menu.
+func (m *Model) closeCardMenu() {
+	m.cardMenu = nil
+}
+
+// cardMenuItems lists a card's actions: exports first, then recording,
+// jumping to tmux, and hiding.
+func (m *Model) cardMenuItems(sessionID string) []commandItem {
+	items := m.exportCommands(sessionID, "")
+	record := "Record as asciicast"
+	if m.isRecording(sessionID) {
+		record = "Stop recording"
+	}
+	return append(
+		items,
+		commandItem{
+			label:   record,
+			enabled: m.recordDir != "",
+			run:     func(m *Model) tea.Cmd { return m.toggleRecording(sessionID) },
+		},
+		commandItem{
+			label:   "Jump to session in tmux",
+			enabled: true,
+			run:     func(m *Model) tea.Cmd { return m.jumpToTmux(sessionID) },
+		},
+		commandItem{
+			label:   "Hide card",
+			enabled: true,
+			run: func(m *Model) tea.Cmd {
+				m.hideSession(sessionID)
+				m.updatePreviewDimensions(m.filteredSessionCount())
+				return nil
+			},
+		},
+	)
+}
+
+// runCardMenuItem closes the menu and runs item i when it is enabled.
+func (m *Model) runCardMenuItem(i int) tea.Cmd {
+	item := m.cardMenu.items[i]
+	m.closeCardMenu()
+	if !item.enabled || item.run == nil {
+		return nil
+	}
+	return item.run(m)
+}
+
+// handleCardMenuKey moves through the menu, runs the selected item with
+// enter, and closes with esc.
+func (m *Model) handleCardMenuKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	menu := m.cardMenu
+	switch msg.String() {
+	case "esc", "q":
+		m.closeCardMenu()
+	case "up", "k":
+		menu.index = (menu.index - 1 + len(menu.items)) % len(menu.items)
+	case "down", "j":
+		menu.index = (menu.index + 1) % len(menu.items)
+	case "enter":
+		return m, m.runCardMenuItem(menu.index)
+	}
+	return m, nil
+}
+
+// handleCardMenuMouse follows the pointer, runs a clicked item, and closes
+// the menu on a click anywhere else.
+func (m *Model) handleCardMenuMouse(msg tea.MouseMsg) (tea.Model, tea.Cmd) {
+	for i := range m.cardMenu.items {
+		info := zone.Get(fmt.Sprintf("%smenu:%d", m.zonePrefix, i))
+		if info == nil || !info.InBounds(msg) {
+			continue
+		}
+		switch msg.(type) {
+		case tea.MouseMotionMsg:
+			m.cardMenu.index = i
+		case tea.MouseClickMsg:
+			if msg.Mouse().Button == tea.MouseLeft {
+				return m, m.runCardMenuItem(i)
+			}
+		}
+		return m, nil
+	}
+	if _, click := msg.(tea.MouseClickMsg); click {
+		m.closeCardMenu()
+	}
+	return m, nil
+}
+
+// renderCardMenu draws the context menu.
+func (m *Model) renderCardMenu() string {
+	menu := m.cardMenu
+	th := m.colors()
+	title := sessionLabel(menu.sessionID)
+	if session, ok := m.sessionByID(menu.sessionID); ok {
+		title = session.Name
+	}
+	lines := []string{lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color(th.overlayText)).Render(title)}
+	for i, item := range menu.items {
+		marker := "  "
+		style := lipgloss.NewStyle().Foreground(lipgloss.Color(th.overlayText))
+		if i == menu.index {
+			marker = "▸ "
+			style = style.Bold(true)
+		}
+		if !item.enabled {
+			style = style.Foreground(lipgloss.Color(th.overlayDisabled))
+		}
+		lines = append(lines, zone.Mark(fmt.Sprintf("%smenu:%d", m.zonePrefix, i), marker+style.Render(item.label)))
+	}
+	return paletteStyle(th).
+		MarginTop(0).
+		Padding(0, 1).
+		Render(strings.Join(lines, "\n"))
+}
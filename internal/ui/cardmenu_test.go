@@ -0,0 +1,46 @@
+// File cardmenu_test.go covers the card context menu.
+package ui
+
+import (
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+)
+
+// TestCardMenuKeys opens the menu with e, moves with j and k, runs the
+// selected item with enter, and closes with esc.
+func TestCardMenuKeys(t *testing.T) {
+	t.Parallel()
+
+	m := testModel(selectionTestSessions()...)
+	m.Update(tea.KeyPressMsg{Code: 'e', Text: "e"})
+	if m.cardMenu == nil || m.cardMenu.sessionID != "$1" || m.cardMenu.x >= 0 {
+		t.Fatalf("menu = %+v, want a centred menu for the cursor card", m.cardMenu)
+	}
+	if m.cardMenu.items[0].label != "Copy screen" {
+		t.Fatalf("first item = %q, want the exports first", m.cardMenu.items[0].label)
+	}
+	m.Update(tea.KeyPressMsg{Code: 'k', Text: "k"})
+	if last := len(m.cardMenu.items) - 1; m.cardMenu.index != last || m.cardMenu.items[last].label != "Hide card" {
+		t.Fatalf("index = %d, want k to wrap to the last item", m.cardMenu.index)
+	}
+	m.Update(tea.KeyPressMsg{Code: tea.KeyEnter})
+	if m.cardMenu != nil || !m.isHidden("$1") {
+		t.Fatal("enter should run Hide card and close the menu")
+	}
+
+	m.openCardMenu("$2", 10, 4)
+	m.Update(tea.KeyPressMsg{Code: 'j', Text: "j"})
+	if m.cardMenu.index != 1 {
+		t.Fatalf("index = %d after j", m.cardMenu.index)
+	}
+	m.Update(tea.KeyPressMsg{Code: tea.KeyEscape})
+	if m.cardMenu != nil || m.isHidden("$2") {
+		t.Fatal("esc should close the menu without running anything")
+	}
+
+	m.openCardMenu("$9", 0, 0)
+	if m.cardMenu != nil {
+		t.Fatal("unknown sessions have no menu")
+	}
+}
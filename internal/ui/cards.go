@@ -12,27 +12,30 @@ import (
 	"github.com/steipete/tmuxwatch/internal/tmux"
 )
 
-func decorateControl(label string) string {
+func decorateControl(th theme, label string) string {
 	return lipgloss.NewStyle().
-		Foreground(lipgloss.Color(headerColorFocus)).
+		Foreground(lipgloss.Color(th.headerFocus)).
 		Render(label)
 }
 
 // renderSessionPreviews lays out each visible session card with consistent
-// sizing and mouse hit-test metadata.
+// sizing and mouse hit-test metadata. When grouping is active every group
+// gets a clickable header and starts on a fresh row.
 func (m *Model) renderSessionPreviews(offset int) string {
-	sessions := m.filteredSessions()
+	groups := m.displayGroups()
 	m.cardLayout = m.cardLayout[:0]
-	if len(sessions) == 0 {
+	m.groupLayout = m.groupLayout[:0]
+	if len(groups) == 0 && !m.replayVisible() {
 		m.cursorSession = ""
 		return ""
 	}
 
 	cols := max(1, m.cardCols)
-	m.ensureCursor(sessions)
+	m.ensureCursor(m.filteredSessions())
+	th := m.colors()
 	baseStyle := lipgloss.NewStyle().
 		Border(lipgloss.RoundedBorder()).
-		BorderForeground(lipgloss.Color(borderColorBase)).
+		BorderForeground(lipgloss.Color(th.borderBase)).
 		Padding(0, cardPadding)
 
 	innerWidth := m.cardInnerWidth
@@ -45,132 +48,223 @@ func (m *Model) renderSessionPreviews(offset int) string {
 		innerHeight = 0
 	}
 
-	grid := make([][]string, 0)
 	now := time.Now()
-
-	for idx, session := range sessions {
-		window, ok := activeWindow(session)
-		if !ok {
-			continue
-		}
-		pane, ok := activePane(window)
-		if !ok {
-			continue
+	grouped := m.grouped()
+	var rendered []string
+	if m.replayVisible() {
+		rendered = append(rendered, m.renderReplayCard(th, baseStyle, innerWidth, innerHeight))
+	}
+	for _, group := range groups {
+		if grouped {
+			rendered = append(rendered, m.renderGroupHeader(th, group))
 		}
-		preview, ok := m.previews[session.ID]
-		if !ok {
+		if group.collapsed {
 			continue
 		}
-
-		if preview.viewport.Width() != innerWidth {
-			preview.viewport.SetWidth(innerWidth)
+		grid := make([][]string, 0)
+		for idx, session := range group.sessions {
+			cardContent, ok := m.renderCard(th, baseStyle, session, innerWidth, innerHeight, now)
+			if !ok {
+				continue
+			}
+			rowIdx := idx / cols
+			for len(grid) <= rowIdx {
+				grid = append(grid, []string{})
+			}
+			grid[rowIdx] = append(grid[rowIdx], cardContent)
 		}
-		if preview.viewport.Height() != innerHeight {
-			preview.viewport.SetHeight(innerHeight)
+		for _, row := range grid {
+			if len(row) == 0 {
+				continue
+			}
+			padded := make([]string, 0, len(row))
+			for _, card := range row {
+				padded = append(padded, lipgloss.NewStyle().Width(cellWidth).Render(card))
+			}
+			rendered = append(rendered, lipgloss.JoinHorizontal(lipgloss.Left, padded...))
 		}
+	}
 
-		pulsing := now.Sub(preview.lastChanged) < pulseDuration
-		stale := m.isStale(session.ID)
-		focused := session.ID == m.focusedSession
-		cursor := session.ID == m.cursorSession
-		hovered := session.ID == m.hoveredSession
-
-		cardID := fmt.Sprintf("%scard:%s", m.zonePrefix, session.ID)
-		closeID := fmt.Sprintf("%sclose:%s", m.zonePrefix, session.ID)
-		maxID := fmt.Sprintf("%smax:%s", m.zonePrefix, session.ID)
-		collapseID := fmt.Sprintf("%scollapse:%s", m.zonePrefix, session.ID)
-		showCollapse := m.viewMode != viewModeDetail || m.detailSession != session.ID
-
-		maxLabel := maximizeLabel
-		if m.viewMode == viewModeDetail && m.detailSession == session.ID {
-			maxLabel = restoreLabel
-		}
-		collapseDisplay := collapseLabel
-		if m.isCollapsed(session.ID) {
-			collapseDisplay = expandLabel
-		}
+	return lipgloss.JoinVertical(lipgloss.Left, rendered...)
+}
 
-		maxContent := maxLabel
-		collapseContent := collapseDisplay
-		closeContent := closeLabel
-		if m.hoveredControl == maxID {
-			maxContent = decorateControl(maxLabel)
-		}
-		if showCollapse && m.hoveredControl == collapseID {
-			collapseContent = decorateControl(collapseDisplay)
-		}
-		if m.hoveredControl == closeID {
-			closeContent = decorateControl(closeLabel)
-		}
-		controlSegments := []string{zone.Mark(maxID, maxContent)}
-		if showCollapse {
-			controlSegments = append(controlSegments, zone.Mark(collapseID, collapseContent))
-		} else {
-			collapseID = ""
-		}
-		controlSegments = append(controlSegments, zone.Mark(closeID, closeContent))
-		controls := strings.Join(controlSegments, " ")
+// renderGroupHeader draws the collapsible header line above a card group.
+func (m *Model) renderGroupHeader(th theme, group sessionGroup) string {
+	marker := "▾"
+	if group.collapsed {
+		marker = "▸"
+	}
+	label := fmt.Sprintf("%s %s (%d)", marker, group.label, len(group.sessions))
+	zoneID := fmt.Sprintf("%sgroup:%s", m.zonePrefix, group.key)
+	m.groupLayout = append(m.groupLayout, groupBounds{key: group.key, zoneID: zoneID})
+	header := lipgloss.NewStyle().
+		Bold(true).
+		Padding(0, 1).
+		Foreground(lipgloss.Color(th.accent)).
+		Render(label)
+	return zone.Mark(zoneID, header)
+}
 
-		header := lipgloss.NewStyle().Render(formatHeader(innerWidth, session, window, pane, focused, pulsing, stale, cursor, controls, m.hostname))
-		body := preview.viewport.View()
-		if m.isCollapsed(session.ID) {
-			body = ""
-		}
+// renderCard draws a single session card and records its hit-test zones. It
+// reports false when the session has no pane or preview to show.
+func (m *Model) renderCard(th theme, baseStyle lipgloss.Style, session tmux.Session, innerWidth, innerHeight int, now time.Time) (string, bool) {
+	window, ok := activeWindow(session)
+	if !ok {
+		return "", false
+	}
+	pane, ok := activePane(window)
+	if !ok {
+		return "", false
+	}
+	preview, ok := m.previews[session.ID]
+	if !ok {
+		return "", false
+	}
 
-		borderStyle := baseStyle
-		switch {
-		case pane.Dead && pane.DeadStatus != 0:
-			borderStyle = borderStyle.BorderForeground(lipgloss.Color(borderColorExitFail))
-		case pane.Dead:
-			borderStyle = borderStyle.BorderForeground(lipgloss.Color(borderColorExitOK))
-		case focused:
-			borderStyle = borderStyle.BorderForeground(lipgloss.Color(borderColorFocus))
-		case cursor:
-			borderStyle = borderStyle.BorderForeground(lipgloss.Color(borderColorCursor))
-		case hovered:
-			borderStyle = borderStyle.BorderForeground(lipgloss.Color(borderColorHover))
-		case stale:
-			borderStyle = borderStyle.BorderForeground(lipgloss.Color(borderColorStale))
-		case pulsing:
-			borderStyle = borderStyle.BorderForeground(lipgloss.Color(borderColorPulse))
-		}
+	if preview.viewport.Width() != innerWidth {
+		preview.viewport.SetWidth(innerWidth)
+	}
+	if preview.viewport.Height() != innerHeight {
+		preview.viewport.SetHeight(innerHeight)
+	}
 
-		cardContent := borderStyle.Render(lipgloss.JoinVertical(lipgloss.Left, header, body))
-		cardContent = zone.Mark(cardID, cardContent)
+	state := cardState{
+		focused:   session.ID == m.focusedSession,
+		pulsing:   now.Sub(preview.lastChanged) < pulseDuration,
+		stale:     m.isStale(session.ID),
+		why:       m.staleReason(session.ID),
+		protect:   m.isProtected(session),
+		cursor:    session.ID == m.cursorSession,
+		pinned:    m.isPinned(session.ID),
+		alerted:   m.isHighlighted(session.ID),
+		unread:    m.isUnread(session.ID),
+		watched:   m.isWatched(session.ID),
+		selected:  m.isSelected(session.ID),
+		recording: m.isRecording(session.ID),
+		monitors:  m.monitorLabels(session.ID),
+		spark:     sparkline(m.activity[preview.paneID].last(sparklineWidth)),
+	}
+	hovered := session.ID == m.hoveredSession
 
-		rowIdx := idx / cols
-		for len(grid) <= rowIdx {
-			grid = append(grid, []string{})
-		}
-		grid[rowIdx] = append(grid[rowIdx], cardContent)
+	cardID := fmt.Sprintf("%scard:%s", m.zonePrefix, session.ID)
+	closeID := fmt.Sprintf("%sclose:%s", m.zonePrefix, session.ID)
+	maxID := fmt.Sprintf("%smax:%s", m.zonePrefix, session.ID)
+	collapseID := fmt.Sprintf("%scollapse:%s", m.zonePrefix, session.ID)
+	jumpID := fmt.Sprintf("%sjump:%s", m.zonePrefix, session.ID)
+	showCollapse := m.viewMode != viewModeDetail || m.detailSession != session.ID
 
-		m.cardLayout = append(m.cardLayout, cardBounds{
-			sessionID:      session.ID,
-			zoneID:         cardID,
-			closeZoneID:    closeID,
-			maximizeZoneID: maxID,
-			collapseZoneID: collapseID,
-		})
+	maxLabel := maximizeLabel
+	if m.viewMode == viewModeDetail && m.detailSession == session.ID {
+		maxLabel = restoreLabel
+	}
+	collapseDisplay := collapseLabel
+	if m.isCollapsed(session.ID) {
+		collapseDisplay = expandLabel
 	}
 
-	var rendered []string
-	for _, row := range grid {
-		if len(row) == 0 {
-			continue
-		}
-		padded := make([]string, 0, len(row))
-		for _, card := range row {
-			padded = append(padded, lipgloss.NewStyle().Width(cellWidth).Render(card))
-		}
-		rendered = append(rendered, lipgloss.JoinHorizontal(lipgloss.Left, padded...))
+	maxContent := maxLabel
+	collapseContent := collapseDisplay
+	closeContent := closeLabel
+	jumpContent := jumpLabel
+	if m.hoveredControl == jumpID {
+		jumpContent = decorateControl(th, jumpLabel)
 	}
+	if m.hoveredControl == maxID {
+		maxContent = decorateControl(th, maxLabel)
+	}
+	if showCollapse && m.hoveredControl == collapseID {
+		collapseContent = decorateControl(th, collapseDisplay)
+	}
+	if m.hoveredControl == closeID {
+		closeContent = decorateControl(th, closeLabel)
+	}
+	controlSegments := []string{zone.Mark(jumpID, jumpContent), zone.Mark(maxID, maxContent)}
+	if showCollapse {
+		controlSegments = append(controlSegments, zone.Mark(collapseID, collapseContent))
+	} else {
+		collapseID = ""
+	}
+	controlSegments = append(controlSegments, zone.Mark(closeID, closeContent))
+	controls := strings.Join(controlSegments, " ")
 
-	return lipgloss.JoinVertical(lipgloss.Left, rendered...)
+	header := lipgloss.NewStyle().Render(formatHeader(th, innerWidth, session, window, pane, state, controls, m.hostname))
+	var body string
+	switch {
+	case m.isCollapsed(session.ID):
+	case maxLabel == restoreLabel && innerHeight > detailChartHeight*3:
+		// The detail view trades a few preview rows for a larger chart.
+		preview.viewport.SetHeight(innerHeight - detailChartHeight)
+		chart := lipgloss.NewStyle().
+			Foreground(lipgloss.Color(th.accent)).
+			Render(strings.Join(activityChart(m.activity[preview.paneID].last(innerWidth), detailChartHeight), "\n"))
+		body = lipgloss.JoinVertical(lipgloss.Left, chart, preview.viewport.View())
+	default:
+		body = preview.viewport.View()
+	}
+
+	borderStyle := baseStyle
+	if state.selected {
+		borderStyle = borderStyle.Border(lipgloss.ThickBorder())
+	}
+	switch {
+	case pane.Dead && pane.DeadStatus != 0:
+		borderStyle = borderStyle.BorderForeground(lipgloss.Color(th.borderExitFail))
+	case state.alerted:
+		borderStyle = borderStyle.BorderForeground(lipgloss.Color(th.borderAlert))
+	case pane.Dead:
+		borderStyle = borderStyle.BorderForeground(lipgloss.Color(th.borderExitOK))
+	case state.focused:
+		borderStyle = borderStyle.BorderForeground(lipgloss.Color(th.borderFocus))
+	case state.cursor:
+		borderStyle = borderStyle.BorderForeground(lipgloss.Color(th.borderCursor))
+	case hovered:
+		borderStyle = borderStyle.BorderForeground(lipgloss.Color(th.borderHover))
+	case state.stale:
+		borderStyle = borderStyle.BorderForeground(lipgloss.Color(th.borderStale))
+	case state.pulsing:
+		borderStyle = borderStyle.BorderForeground(lipgloss.Color(th.borderPulse))
+	}
+
+	cardContent := borderStyle.Render(lipgloss.JoinVertical(lipgloss.Left, header, body))
+	cardContent = zone.Mark(cardID, cardContent)
+
+	m.cardLayout = append(m.cardLayout, cardBounds{
+		sessionID:      session.ID,
+		zoneID:         cardID,
+		closeZoneID:    closeID,
+		maximizeZoneID: maxID,
+		collapseZoneID: collapseID,
+		jumpZoneID:     jumpID,
+	})
+	return cardContent, true
+}
+
+// cardState carries the per-card flags that affect header and border styling.
+type cardState struct {
+	focused bool
+	pulsing bool
+	stale   bool
+	// why explains the stale mark, e.g. "idle 2h ≥ 1h".
+	why     string
+	protect bool
+	cursor  bool
+	pinned  bool
+	alerted bool
+	unread  bool
+	watched bool
+	// selected marks a card chosen for bulk actions.
+	selected bool
+	// recording marks a card being recorded as an asciicast.
+	recording bool
+	// monitors labels the silence and activity monitors enabled on the card.
+	monitors []string
+	// spark is the output-volume sparkline shown after the title.
+	spark string
 }
 
 // formatHeader builds the label line for a session card, colouring it based on
 // status and focus state.
-func formatHeader(width int, session tmux.Session, window tmux.Window, pane tmux.Pane, focused, pulsing, stale, cursor bool, controls string, host string) string {
+func formatHeader(th theme, width int, session tmux.Session, window tmux.Window, pane tmux.Pane, state cardState, controls string, host string) string {
 	var meta []string
 	if pane.Dead {
 		meta = append(meta, pane.StatusString())
@@ -187,9 +281,36 @@ func formatHeader(width int, session tmux.Session, window tmux.Window, pane tmux
 		titleParts = append(titleParts, paneLabel)
 	}
 	label := strings.Join(titleParts, " · ")
-	if stale {
-		meta = append(meta, "stale")
+	if state.spark != "" {
+		label += " " + state.spark
+	}
+	if state.pinned {
+		label = pinMarker + " " + label
+	}
+	if state.watched {
+		label = watchMarker + " " + label
+	}
+	if state.unread {
+		label = unreadMarker + " " + label
+	}
+	if state.selected {
+		label = selectedMarker + " " + label
+	}
+	if state.recording {
+		label = recordMarker + " " + label
+	}
+	if state.stale {
+		if state.why != "" {
+			meta = append(meta, "stale: "+state.why)
+		} else {
+			meta = append(meta, "stale")
+		}
+	}
+	if state.protect {
+		meta = append(meta, "protected")
 	}
+	meta = append(meta, windowFlagLabels(session)...)
+	meta = append(meta, state.monitors...)
 
 	if len(meta) > 0 {
 		label += " · " + strings.Join(meta, " · ")
@@ -211,19 +332,43 @@ func formatHeader(width int, session tmux.Session, window tmux.Window, pane tmux
 	style := lipgloss.NewStyle()
 	switch {
 	case pane.Dead && pane.DeadStatus != 0:
-		style = style.Foreground(lipgloss.Color(headerColorExitFail))
+		style = style.Foreground(lipgloss.Color(th.headerExitFail))
 	case pane.Dead:
-		style = style.Foreground(lipgloss.Color(headerColorExitOK))
-	case focused:
-		style = style.Foreground(lipgloss.Color(headerColorFocus))
-	case cursor:
-		style = style.Foreground(lipgloss.Color(headerColorCursor))
-	case stale:
-		style = style.Foreground(lipgloss.Color(headerColorStale))
-	case pulsing:
-		style = style.Foreground(lipgloss.Color(headerColorPulse))
+		style = style.Foreground(lipgloss.Color(th.headerExitOK))
+	case state.alerted:
+		style = style.Foreground(lipgloss.Color(th.headerAlert))
+	case state.focused:
+		style = style.Foreground(lipgloss.Color(th.headerFocus))
+	case state.cursor:
+		style = style.Foreground(lipgloss.Color(th.headerCursor))
+	case state.stale:
+		style = style.Foreground(lipgloss.Color(th.headerStale))
+	case state.pulsing:
+		style = style.Foreground(lipgloss.Color(th.headerPulse))
 	default:
-		style = style.Foreground(lipgloss.Color(headerColorBase))
+		style = style.Foreground(lipgloss.Color(th.headerBase))
 	}
 	return style.Render(header)
 }
+
+// windowFlagLabels lists the tmux alert flags raised on any of the session's
+// windows.
+func windowFlagLabels(session tmux.Session) []string {
+	var bell, activity, silence bool
+	for _, window := range session.Windows {
+		bell = bell || window.BellFlag
+		activity = activity || window.ActivityFlag
+		silence = silence || window.SilenceFlag
+	}
+	var labels []string
+	if bell {
+		labels = append(labels, "bell")
+	}
+	if activity {
+		labels = append(labels, "activity")
+	}
+	if silence {
+		labels = append(labels, "silent")
+	}
+	return labels
+}
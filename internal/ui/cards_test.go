@@ -20,7 +20,7 @@ func TestFormatHeaderOmitsHost(t *testing.T) {
 		LastActivity: time.Now().Add(-time.Minute),
 	}
 
-	got := formatHeader(80, session, window, pane, false, false, false, false, "[x]", "dev-host")
+	got := formatHeader(themeDefault, 80, session, window, pane, cardState{}, "[x]", "dev-host")
 	if strings.Contains(got, "dev-host") {
 		t.Fatalf("formatHeader should omit host when title matches, got %q", got)
 	}
@@ -37,7 +37,7 @@ func TestFormatHeaderKeepsCustomTitle(t *testing.T) {
 		LastActivity: time.Now().Add(-time.Minute),
 	}
 
-	got := formatHeader(80, session, window, pane, false, false, false, false, "[x]", "dev-host")
+	got := formatHeader(themeDefault, 80, session, window, pane, cardState{}, "[x]", "dev-host")
 	if !strings.Contains(got, "npm run dev") {
 		t.Fatalf("formatHeader should keep custom title, got %q", got)
 	}
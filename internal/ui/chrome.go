@@ -12,17 +12,17 @@ import (
 )
 
 // renderSearchBar prints the interactive search prompt and input box.
-func renderSearchBar(input textinput.Model) string {
-	label := lipgloss.NewStyle().Padding(0, 1).Foreground(lipgloss.Color("62")).Render("Search")
+func renderSearchBar(th theme, input textinput.Model) string {
+	label := lipgloss.NewStyle().Padding(0, 1).Foreground(lipgloss.Color(th.accent)).Render("Search")
 	return lipgloss.JoinHorizontal(lipgloss.Left, label, input.View())
 }
 
 // renderSearchSummary shows the current filter query when the search box is
 // closed.
-func renderSearchSummary(query string) string {
+func renderSearchSummary(th theme, query string) string {
 	return lipgloss.NewStyle().
 		Padding(0, 2).
-		Foreground(lipgloss.Color("62")).
+		Foreground(lipgloss.Color(th.accent)).
 		Render(fmt.Sprintf("Filter: %s (press / to edit, esc to clear)", query))
 }
 
@@ -30,9 +30,10 @@ func renderSearchSummary(query string) string {
 func renderTitleBar(m *Model, width int) string {
 	width = max(width, 1)
 
+	th := m.colors()
 	base := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("231")).
-		Background(lipgloss.Color("62"))
+		Foreground(lipgloss.Color(th.accentText)).
+		Background(lipgloss.Color(th.accent))
 	name := base.Bold(true).Padding(0, 2).Render("tmuxwatch")
 
 	totalSessions := len(m.sessions)
@@ -53,14 +54,38 @@ func renderTitleBar(m *Model, width int) string {
 	if m.searchQuery != "" {
 		metaParts = append(metaParts, fmt.Sprintf("filter %q", m.searchQuery))
 	}
+	if hidden := m.hiddenSummary(); hidden != "" {
+		metaParts = append(metaParts, hidden)
+	}
+	if selected := m.selectionSummary(); selected != "" {
+		metaParts = append(metaParts, selected)
+	}
+	if mode := m.currentSort(); mode != sortTmux {
+		metaParts = append(metaParts, "sort "+mode.label())
+	}
+	if mode := m.currentGroup(); mode != groupNone {
+		metaParts = append(metaParts, "group "+mode.label())
+	}
+	if m.leaderArmed {
+		metaParts = append(metaParts, "leader "+m.keymap().leader+" …")
+	}
 	content := name
 	if len(metaParts) > 0 {
 		meta := base.
 			Padding(0, 2).
-			Foreground(lipgloss.Color("249")).
+			Foreground(lipgloss.Color(th.titleMeta)).
 			Render(strings.Join(metaParts, " • "))
 		content = lipgloss.JoinHorizontal(lipgloss.Left, content, meta)
 	}
+	if summary := m.alertSummary(); summary != "" {
+		badge := lipgloss.NewStyle().
+			Bold(true).
+			Padding(0, 1).
+			Foreground(lipgloss.Color(th.accentText)).
+			Background(lipgloss.Color(th.alertText)).
+			Render(summary)
+		content = lipgloss.JoinHorizontal(lipgloss.Left, content, badge)
+	}
 
 	remaining := width - lipgloss.Width(content)
 	if remaining > 0 {
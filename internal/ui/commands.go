@@ -3,10 +3,14 @@ package ui
 
 import (
 	"context"
+	"fmt"
+	"strings"
 	"time"
 
 	tea "charm.land/bubbletea/v2"
 
+	"github.com/steipete/tmuxwatch/internal/archive"
+	"github.com/steipete/tmuxwatch/internal/proc"
 	"github.com/steipete/tmuxwatch/internal/tmux"
 )
 
@@ -58,6 +62,41 @@ func fetchPaneVarsCmd(client *tmux.Client, sessionID, paneID string) tea.Cmd {
 	}
 }
 
+// fetchCPUCmd samples CPU use for each session's pane process trees.
+func fetchCPUCmd(roots map[string][]int) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+		defer cancel()
+		var pids []int
+		for _, ids := range roots {
+			pids = append(pids, ids...)
+		}
+		byPID, err := proc.TreeCPU(ctx, pids)
+		if err != nil {
+			return cpuMsg{err: err}
+		}
+		usage := make(map[string]float64, len(roots))
+		for sessionID, ids := range roots {
+			for _, pid := range ids {
+				usage[sessionID] += byPID[pid]
+			}
+		}
+		return cpuMsg{usage: usage}
+	}
+}
+
+// resolveRepoRootsCmd finds the git work tree of each directory off the
+// update loop.
+func resolveRepoRootsCmd(dirs []string) tea.Cmd {
+	return func() tea.Msg {
+		roots := make(map[string]string, len(dirs))
+		for _, dir := range dirs {
+			roots[dir] = findRepoRoot(dir)
+		}
+		return repoRootsMsg{roots: roots}
+	}
+}
+
 // showStatusMessage emits a statusMsg for later handling in the update loop.
 func showStatusMessage(msg string) tea.Cmd {
 	return func() tea.Msg {
@@ -77,20 +116,230 @@ func sendKeysCmd(client *tmux.Client, paneID string, keys ...string) tea.Cmd {
 	}
 }
 
+// switchClientCmd selects the window and pane, then switches the tmux client
+// displaying callerPane to the session. Without a caller pane tmux picks the
+// client.
+func switchClientCmd(client *tmux.Client, callerPane, sessionID, windowID, paneID string) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+		defer cancel()
+		if windowID != "" && paneID != "" {
+			if err := client.SelectPane(ctx, windowID, paneID); err != nil {
+				return errMsg{err: err}
+			}
+		}
+		var tty string
+		if callerPane != "" {
+			tty, _ = client.ClientTTY(ctx, callerPane)
+		}
+		if err := client.SwitchClient(ctx, tty, sessionID); err != nil {
+			return errMsg{err: err}
+		}
+		return nil
+	}
+}
+
+// selectPaneCmd makes the window and pane current, before tmuxwatch exits to
+// attach or when moving between panes. Failures are ignored; the next
+// snapshot shows whichever pane is current.
+func selectPaneCmd(client *tmux.Client, windowID, paneID string) tea.Cmd {
+	return func() tea.Msg {
+		if windowID == "" || paneID == "" {
+			return nil
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+		defer cancel()
+		_ = client.SelectPane(ctx, windowID, paneID)
+		return nil
+	}
+}
+
+// panesCmd runs send on every pane, carrying on past failures and reporting
+// the first one.
+func panesCmd(client *tmux.Client, paneIDs []string, send func(ctx context.Context, client *tmux.Client, paneID string) error) tea.Cmd {
+	return func() tea.Msg {
+		var first error
+		for _, paneID := range paneIDs {
+			ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+			err := send(ctx, client, paneID)
+			cancel()
+			if err != nil && first == nil {
+				first = err
+			}
+		}
+		if first != nil {
+			return errMsg{err: first}
+		}
+		return nil
+	}
+}
+
+// sendTmuxKeyCmd forwards one translated key press, typing literal text
+// with send-keys -l.
+func sendTmuxKeyCmd(client *tmux.Client, paneID string, key tmuxKey) tea.Cmd {
+	if !key.literal {
+		return sendKeysCmd(client, paneID, key.name)
+	}
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+		defer cancel()
+		if err := client.SendLiteral(ctx, paneID, key.name); err != nil {
+			return errMsg{err: err}
+		}
+		return nil
+	}
+}
+
+// pasteCmd pastes text into a pane through a tmux buffer so bracketed paste
+// reaches applications that enabled it.
+func pasteCmd(client *tmux.Client, paneID, text string) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+		defer cancel()
+		if err := client.Paste(ctx, paneID, text); err != nil {
+			return errMsg{err: err}
+		}
+		return nil
+	}
+}
+
+// killResult records what happened to one session in a kill request.
+// archive is the directory holding the session's saved scrollback.
+type killResult struct {
+	id      string
+	name    string
+	refused bool
+	archive string
+	err     error
+}
+
+// killOptions carries the settings a kill request applies to every session.
+// When archiveDir is set, each session's panes, taken from sessions, are
+// captured into a new archive before the kill.
+type killOptions struct {
+	protect    []string
+	archiveDir string
+	sessions   map[string]tmux.Session
+}
+
 // killSessionsCmd terminates one or more tmux sessions and triggers a refresh.
-func killSessionsCmd(client *tmux.Client, sessionIDs []string) tea.Cmd {
+// Sessions whose @tmuxwatch-protect option is set, checked against tmux right
+// before the kill, or whose name matches a protect pattern are refused. A
+// session whose archive cannot be written is left running. A failure never
+// stops the rest of the batch; every session gets a result.
+func killSessionsCmd(client *tmux.Client, sessionIDs []string, opts killOptions) tea.Cmd {
 	ids := append([]string(nil), sessionIDs...)
+	opts.protect = append([]string(nil), opts.protect...)
 	return func() tea.Msg {
 		if len(ids) == 0 {
 			return nil
 		}
-		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
-		defer cancel()
+		var msg killSessionsMsg
 		for _, id := range ids {
-			if err := client.KillSession(ctx, id); err != nil {
-				return errMsg{err: err}
+			result := killSession(client, id, opts)
+			if result.err == nil && !result.refused {
+				msg.ids = append(msg.ids, id)
 			}
+			msg.results = append(msg.results, result)
+		}
+		return msg
+	}
+}
+
+// archiveCaptureTimeout bounds capturing one pane's full scrollback.
+const archiveCaptureTimeout = 10 * time.Second
+
+// killSession checks protection, archives, and kills one session. The
+// protect check and the kill share a short deadline; archiving gives each
+// pane its own, so large scrollbacks never cost the kill.
+func killSession(client *tmux.Client, id string, opts killOptions) killResult {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	result := killResult{id: id, name: sessionLabel(id)}
+	name, protected, err := client.SessionProtection(ctx, id)
+	switch {
+	case err != nil:
+		result.err = err
+		return result
+	case protected || matchesAny(opts.protect, name):
+		result.name, result.refused = name, true
+		return result
+	}
+	result.name = name
+	if session, ok := opts.sessions[id]; ok && opts.archiveDir != "" {
+		result.archive, result.err = archiveSession(client, opts.archiveDir, name, session, time.Now())
+		if result.err != nil {
+			result.err = fmt.Errorf("archive: %w", result.err)
+			return result
 		}
-		return killSessionsMsg{ids: ids}
 	}
+	killCtx, cancelKill := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancelKill()
+	result.err = client.KillSession(killCtx, id)
+	return result
+}
+
+// archiveSession captures every pane's full scrollback and writes it, with
+// the panes' metadata, to a new directory under root. Each capture gets
+// archiveCaptureTimeout.
+func archiveSession(client *tmux.Client, root, name string, session tmux.Session, now time.Time) (string, error) {
+	meta := archive.Meta{Session: name, KilledAt: now}
+	for _, window := range session.Windows {
+		for _, pane := range window.Panes {
+			ctx, cancel := context.WithTimeout(context.Background(), archiveCaptureTimeout)
+			out, err := client.CaptureHistory(ctx, pane.ID)
+			cancel()
+			if err != nil {
+				return "", err
+			}
+			meta.Panes = append(meta.Panes, archive.Pane{
+				ID:       pane.ID,
+				Window:   window.Name,
+				Title:    pane.Title,
+				Command:  pane.CurrentCmd,
+				Path:     pane.CurrentPath,
+				Dead:     pane.Dead,
+				ExitCode: pane.DeadStatus,
+				Output:   out,
+			})
+		}
+	}
+	if len(meta.Panes) == 0 {
+		return "", nil
+	}
+	return archive.Write(root, meta)
+}
+
+// killSummary describes a kill request's outcome for the toast, naming
+// every session that was refused or failed.
+func killSummary(results []killResult) string {
+	var killed, refused, failed []string
+	archived := 0
+	for _, r := range results {
+		switch {
+		case r.refused:
+			refused = append(refused, r.name)
+		case r.err != nil:
+			failed = append(failed, fmt.Sprintf("%s (%v)", r.name, r.err))
+		default:
+			killed = append(killed, r.name)
+			if r.archive != "" {
+				archived++
+			}
+		}
+	}
+	var parts []string
+	if len(killed) > 0 {
+		parts = append(parts, "Killed "+strings.Join(killed, ", "))
+	}
+	if archived > 0 {
+		parts = append(parts, fmt.Sprintf("archived %d", archived))
+	}
+	if len(refused) > 0 {
+		parts = append(parts, "refused protected "+strings.Join(refused, ", "))
+	}
+	if len(failed) > 0 {
+		parts = append(parts, "failed "+strings.Join(failed, ", "))
+	}
+	return strings.Join(parts, " · ")
 }
@@ -0,0 +1,254 @@
+// File composer.go implements the command composer, a line under the title
+// bar where a full command is edited with history and completion and then
+// typed into the target panes followed by Enter, and the snippet library
+// offered in the command palette.
+package ui
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"charm.land/bubbles/v2/key"
+	"charm.land/bubbles/v2/textinput"
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+
+	"github.com/steipete/tmuxwatch/internal/tmux"
+)
+
+// commandHistoryLimit caps the saved composer history.
+const commandHistoryLimit = 200
+
+// composer holds the command being edited and the history position.
+type composer struct {
+	input textinput.Model
+	// history indexes commandHistory while browsing with up and down; it
+	// equals len(commandHistory) on the draft line.
+	history int
+	draft   string
+}
+
+// composerTargets returns the sessions a composed command goes to: the
+// selection, otherwise the detail, focused, or cursor session.
+func (m *Model) composerTargets() []string {
+	if ids := m.selectedIDs(); len(ids) > 0 {
+		return ids
+	}
+	for _, id := range []string{m.detailIfOpen(), m.focusedSession, m.cursorSession} {
+		if id != "" && m.sessionExists(id) {
+			return []string{id}
+		}
+	}
+	return nil
+}
+
+// detailIfOpen returns the detail session while the detail view is open.
+func (m *Model) detailIfOpen() string {
+	if m.viewMode == viewModeDetail {
+		return m.detailSession
+	}
+	return ""
+}
+
+// openComposer shows the composer with text prefilled.
+func (m *Model) openComposer(text string) tea.Cmd {
+	if len(m.composerTargets()) == 0 {
+		return showStatusMessage("Nothing to send a command to")
+	}
+	m.closePalette()
+	ti := textinput.New()
+	ti.Placeholder = "command · {session} {window} {pane} {cwd} are filled in"
+	ti.CharLimit = 4096
+	ti.Prompt = "$ "
+	ti.ShowSuggestions = true
+	ti.KeyMap.NextSuggestion = key.NewBinding(key.WithKeys("ctrl+n"))
+	ti.KeyMap.PrevSuggestion = key.NewBinding(key.WithKeys("ctrl+p"))
+	ti.SetSuggestions(m.composerSuggestions())
+	ti.SetValue(text)
+	ti.CursorEnd()
+	m.composer = &composer{input: ti, history: len(m.commandHistory)}
+	return m.composer.input.Focus()
+}
+
+// closeComposer hides the composer.
+func (m *Model) closeComposer() {
+	m.composer = nil
+}
+
+// composerSuggestions completes from history, newest first, then snippets.
+func (m *Model) composerSuggestions() []string {
+	var out []string
+	for i := len(m.commandHistory) - 1; i >= 0; i-- {
+		if !slices.Contains(out, m.commandHistory[i]) {
+			out = append(out, m.commandHistory[i])
+		}
+	}
+	for _, snippet := range m.snippets {
+		if !slices.Contains(out, snippet.Command) {
+			out = append(out, snippet.Command)
+		}
+	}
+	return out
+}
+
+// handleComposerKey sends with enter and keeps the composer open for the
+// next command, browses history with up and down, completes with tab, and
+// closes with esc.
+func (m *Model) handleComposerKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	c := m.composer
+	switch msg.String() {
+	case "esc":
+		m.closeComposer()
+		return m, nil
+	case "enter":
+		text := c.input.Value()
+		if strings.TrimSpace(text) == "" {
+			return m, nil
+		}
+		cmd := m.sendComposed(text)
+		c.input.SetValue("")
+		c.input.SetSuggestions(m.composerSuggestions())
+		c.history = len(m.commandHistory)
+		c.draft = ""
+		return m, cmd
+	case "up":
+		if c.history > 0 {
+			if c.history == len(m.commandHistory) {
+				c.draft = c.input.Value()
+			}
+			c.history--
+			c.input.SetValue(m.commandHistory[c.history])
+			c.input.CursorEnd()
+		}
+		return m, nil
+	case "down":
+		if c.history < len(m.commandHistory) {
+			c.history++
+			if c.history == len(m.commandHistory) {
+				c.input.SetValue(c.draft)
+			} else {
+				c.input.SetValue(m.commandHistory[c.history])
+			}
+			c.input.CursorEnd()
+		}
+		return m, nil
+	}
+	var cmd tea.Cmd
+	c.input, cmd = c.input.Update(msg)
+	return m, cmd
+}
+
+// handleComposerPaste types pasted text into the composer.
+func (m *Model) handleComposerPaste(msg tea.PasteMsg) (tea.Model, tea.Cmd) {
+	var cmd tea.Cmd
+	m.composer.input, cmd = m.composer.input.Update(msg)
+	return m, cmd
+}
+
+// sendComposed types text, with placeholders filled in per pane, into the
+// active pane of every target followed by Enter, and records it in history.
+func (m *Model) sendComposed(text string) tea.Cmd {
+	commands := make(map[string]string)
+	var panes []string
+	for _, id := range m.composerTargets() {
+		session, ok := m.sessionByID(id)
+		if !ok {
+			continue
+		}
+		window, ok := activeWindow(session)
+		if !ok {
+			continue
+		}
+		pane, ok := activePane(window)
+		if !ok || pane.Dead {
+			continue
+		}
+		panes = append(panes, pane.ID)
+		commands[pane.ID] = expandPlaceholders(text, session, window, pane)
+		m.logEvent(eventAction, id, "sent command "+commands[pane.ID])
+	}
+	m.recordCommand(text)
+	if len(panes) == 0 {
+		return showStatusMessage("No target pane can receive input")
+	}
+	send := func(ctx context.Context, client *tmux.Client, paneID string) error {
+		if err := client.SendLiteral(ctx, paneID, commands[paneID]); err != nil {
+			return err
+		}
+		return client.SendKeys(ctx, paneID, "Enter")
+	}
+	status := "Sent to " + m.composerLabel()
+	return tea.Batch(panesCmd(m.client, panes, send), showStatusMessage(status), m.saveStateCmd())
+}
+
+// recordCommand appends text to the history, moving a repeat to the end.
+func (m *Model) recordCommand(text string) {
+	m.commandHistory = slices.DeleteFunc(m.commandHistory, func(s string) bool { return s == text })
+	m.commandHistory = append(m.commandHistory, text)
+	if over := len(m.commandHistory) - commandHistoryLimit; over > 0 {
+		m.commandHistory = slices.Delete(m.commandHistory, 0, over)
+	}
+}
+
+// expandPlaceholders fills in {session}, {window}, {pane}, and {cwd}.
+func expandPlaceholders(text string, session tmux.Session, window tmux.Window, pane tmux.Pane) string {
+	return strings.NewReplacer(
+		"{session}", session.Name,
+		"{window}", window.Name,
+		"{pane}", pane.ID,
+		"{cwd}", pane.CurrentPath,
+	).Replace(text)
+}
+
+// composerLabel names the composer's targets.
+func (m *Model) composerLabel() string {
+	ids := m.composerTargets()
+	switch {
+	case len(ids) == 1:
+		if session, ok := m.sessionByID(ids[0]); ok {
+			return session.Name
+		}
+		return sessionLabel(ids[0])
+	case len(m.selectedIDs()) > 0:
+		return fmt.Sprintf("%d selected", len(ids))
+	default:
+		return fmt.Sprintf("%d sessions", len(ids))
+	}
+}
+
+// renderComposer draws the composer line.
+func (m *Model) renderComposer(width int) string {
+	th := m.colors()
+	label := lipgloss.NewStyle().
+		Padding(0, 1).
+		Foreground(lipgloss.Color(th.accent)).
+		Render("Send to " + m.composerLabel())
+	hint := lipgloss.NewStyle().
+		Padding(0, 1).
+		Foreground(lipgloss.Color(th.helpText)).
+		Render("enter sends · ↑/↓ history · tab completes · esc closes")
+	m.composer.input.SetWidth(max(width-lipgloss.Width(label)-lipgloss.Width(hint)-4, 10))
+	return lipgloss.JoinHorizontal(lipgloss.Left, label, m.composer.input.View(), hint)
+}
+
+// composerPaletteCommands offers the composer and one entry per snippet,
+// which opens the composer with the snippet filled in.
+func (m *Model) composerPaletteCommands() []commandItem {
+	enabled := len(m.composerTargets()) > 0
+	items := []commandItem{{
+		label:   "Compose a command",
+		enabled: enabled,
+		run:     func(m *Model) tea.Cmd { return m.openComposer("") },
+	}}
+	for _, snippet := range m.snippets {
+		command := snippet.Command
+		items = append(items, commandItem{
+			label:   fmt.Sprintf("Snippet: %s (%s)", snippet.Name, command),
+			enabled: enabled,
+			run:     func(m *Model) tea.Cmd { return m.openComposer(command) },
+		})
+	}
+	return items
+}
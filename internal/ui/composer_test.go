@@ -0,0 +1,104 @@
+// File composer_test.go covers the command composer and snippets.
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+
+	"github.com/steipete/tmuxwatch/internal/config"
+	"github.com/steipete/tmuxwatch/internal/tmux"
+)
+
+// TestComposerSendsWithPlaceholders fills in placeholders per target pane,
+// records history, and browses it with up and down.
+func TestComposerSendsWithPlaceholders(t *testing.T) {
+	t.Parallel()
+
+	m := testModel(selectionTestSessions()...)
+	m.sessions[0].Windows[0].Panes[0].CurrentPath = "/srv/api"
+	m.commandHistory = []string{"git status"}
+	m.Update(tea.KeyPressMsg{Code: ':', Text: ":"})
+	if m.composer == nil || m.composerLabel() != "api-server" {
+		t.Fatal(": should open the composer for the cursor session")
+	}
+	m.Update(tea.PasteMsg{Content: "ls {cwd} # {session}"})
+	if _, cmd := m.Update(tea.KeyPressMsg{Code: tea.KeyEnter}); cmd == nil {
+		t.Fatal("enter should send the command")
+	}
+	if last := m.timeline[len(m.timeline)-1]; last.text != "sent command ls /srv/api # api-server" {
+		t.Fatalf("timeline = %q", last.text)
+	}
+	if m.composer == nil || m.composer.input.Value() != "" {
+		t.Fatal("the composer should stay open with an empty line")
+	}
+	if strings.Join(m.commandHistory, "|") != "git status|ls {cwd} # {session}" {
+		t.Fatalf("history = %q", m.commandHistory)
+	}
+
+	m.Update(tea.KeyPressMsg{Code: 'x', Text: "x"})
+	m.Update(tea.KeyPressMsg{Code: tea.KeyUp})
+	m.Update(tea.KeyPressMsg{Code: tea.KeyUp})
+	if got := m.composer.input.Value(); got != "git status" {
+		t.Fatalf("up twice = %q", got)
+	}
+	m.Update(tea.KeyPressMsg{Code: tea.KeyDown})
+	m.Update(tea.KeyPressMsg{Code: tea.KeyDown})
+	if got := m.composer.input.Value(); got != "x" {
+		t.Fatalf("down past the newest entry should restore the draft, got %q", got)
+	}
+	m.Update(tea.KeyPressMsg{Code: tea.KeyEscape})
+	if m.composer != nil {
+		t.Fatal("esc should close the composer")
+	}
+}
+
+// TestComposerTargetsSelection sends to every selected live pane.
+func TestComposerTargetsSelection(t *testing.T) {
+	t.Parallel()
+
+	m := testModel(selectionTestSessions()...)
+	m.toggleSelected("$2")
+	m.toggleSelected("$3")
+	m.openComposer("make")
+	if m.composerLabel() != "2 selected" {
+		t.Fatalf("label = %q", m.composerLabel())
+	}
+	m.sendComposed("make")
+	var sent []string
+	for _, ev := range m.timeline {
+		sent = append(sent, ev.sessionID)
+	}
+	if strings.Join(sent, ",") != "$2" {
+		t.Fatalf("sent to %v, want only the live selected pane", sent)
+	}
+}
+
+// TestSnippetPalette opens the composer with the chosen snippet and offers
+// snippets as completions.
+func TestSnippetPalette(t *testing.T) {
+	t.Parallel()
+
+	m := &Model{
+		sessions:      []tmux.Session{lifecycleSession("$1", "api", tmux.Pane{ID: "%1"})},
+		cursorSession: "$1",
+		snippets:      []config.Snippet{{Name: "tests", Command: "go test ./..."}},
+	}
+	var snippet commandItem
+	for _, item := range m.composerPaletteCommands() {
+		if strings.HasPrefix(item.label, "Snippet: tests") {
+			snippet = item
+		}
+	}
+	if snippet.run == nil || !snippet.enabled {
+		t.Fatal("the palette should offer the snippet")
+	}
+	snippet.run(m)
+	if m.composer == nil || m.composer.input.Value() != "go test ./..." {
+		t.Fatal("a snippet should open the composer prefilled")
+	}
+	if got := m.composerSuggestions(); len(got) != 1 || got[0] != "go test ./..." {
+		t.Fatalf("suggestions = %q", got)
+	}
+}
@@ -0,0 +1,220 @@
+// File dialog.go implements the modal confirmation dialog used by every
+// destructive action, and the kill confirmation built on it.
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+
+	"github.com/steipete/tmuxwatch/internal/tmux"
+	zone "github.com/steipete/tmuxwatch/internal/zone"
+)
+
+const (
+	// dialogMaxSessions caps how many sessions a kill dialog lists.
+	dialogMaxSessions = 8
+	// dialogTailLines is how many trailing output lines show per session.
+	dialogTailLines = 2
+	// dialogMaxWidth bounds the dialog's text width.
+	dialogMaxWidth = 72
+)
+
+// dialog is a modal confirmation prompt. Cancel is focused by default so an
+// accidental enter never confirms.
+type dialog struct {
+	title        string
+	lines        []dialogLine
+	confirmLabel string
+	onConfirm    func(m *Model) tea.Cmd
+	focusConfirm bool
+}
+
+// dialogLine is one row of the dialog body.
+type dialogLine struct {
+	text  string
+	muted bool
+}
+
+// openDialog shows d above everything else.
+func (m *Model) openDialog(d *dialog) {
+	m.closePalette()
+	m.closeTimeline()
+	m.closeArchive()
+	m.closeHiddenManager()
+	m.closePrompt()
+	m.dialog = d
+}
+
+// closeDialog dismisses the dialog without running it.
+func (m *Model) closeDialog() {
+	m.dialog = nil
+}
+
+// confirmDialog closes the dialog and runs its action.
+func (m *Model) confirmDialog() tea.Cmd {
+	d := m.dialog
+	m.dialog = nil
+	if d == nil || d.onConfirm == nil {
+		return nil
+	}
+	return d.onConfirm(m)
+}
+
+// handleDialogKey confirms with y or enter on the confirm button, cancels
+// with n, esc, or enter on cancel, and moves focus with tab or the arrows.
+func (m *Model) handleDialogKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if _, ok := msg.(tea.KeyPressMsg); !ok {
+		return m, nil
+	}
+	switch msg.String() {
+	case "y", "Y":
+		return m, m.confirmDialog()
+	case "n", "N", "esc", "q":
+		m.closeDialog()
+	case "tab", "shift+tab", "left", "right", "h", "l":
+		m.dialog.focusConfirm = !m.dialog.focusConfirm
+	case "enter", "space":
+		if m.dialog.focusConfirm {
+			return m, m.confirmDialog()
+		}
+		m.closeDialog()
+	}
+	return m, nil
+}
+
+// handleDialogMouse runs the button under a left click and swallows every
+// other mouse event while the dialog is open.
+func (m *Model) handleDialogMouse(msg tea.MouseMsg) (tea.Model, tea.Cmd) {
+	click, ok := msg.(tea.MouseClickMsg)
+	if !ok || click.Button != tea.MouseLeft {
+		return m, nil
+	}
+	if info := zone.Get(m.zonePrefix + "dialog-confirm"); info != nil && info.InBounds(msg) {
+		return m, m.confirmDialog()
+	}
+	if info := zone.Get(m.zonePrefix + "dialog-cancel"); info != nil && info.InBounds(msg) {
+		m.closeDialog()
+	}
+	return m, nil
+}
+
+// renderDialog draws the dialog box with zone-marked buttons.
+func (m *Model) renderDialog(width int) string {
+	d := m.dialog
+	th := m.colors()
+	textWidth := min(max(width-8, 20), dialogMaxWidth)
+	text := lipgloss.NewStyle().Foreground(lipgloss.Color(th.overlayText))
+	muted := lipgloss.NewStyle().Foreground(lipgloss.Color(th.overlayMuted))
+
+	rows := []string{text.Bold(true).Render(d.title), ""}
+	for _, line := range d.lines {
+		style := text
+		if line.muted {
+			style = muted
+		}
+		rows = append(rows, style.Render(truncate(line.text, textWidth)))
+	}
+	rows = append(rows, "")
+
+	button := lipgloss.NewStyle().Padding(0, 1)
+	confirm := button.Foreground(lipgloss.Color(th.errorText))
+	cancel := button.Foreground(lipgloss.Color(th.overlayText))
+	if d.focusConfirm {
+		confirm = confirm.Reverse(true).Bold(true)
+	} else {
+		cancel = cancel.Reverse(true).Bold(true)
+	}
+	rows = append(rows,
+		zone.Mark(m.zonePrefix+"dialog-confirm", confirm.Render("y "+d.confirmLabel))+"  "+
+			zone.Mark(m.zonePrefix+"dialog-cancel", cancel.Render("n cancel")))
+
+	return paletteStyle(th).
+		MarginTop(0).
+		BorderForeground(lipgloss.Color(th.borderExitFail)).
+		Render(strings.Join(rows, "\n"))
+}
+
+// confirmKillSessions asks before killing ids, listing each session with its
+// stale reason and last output lines. Protected sessions are listed as
+// skipped.
+func (m *Model) confirmKillSessions(ids []string) tea.Cmd {
+	if len(ids) == 0 {
+		return nil
+	}
+	noun := "session"
+	if len(ids) > 1 {
+		noun = "sessions"
+	}
+	d := &dialog{
+		title:        fmt.Sprintf("Kill %d %s?", len(ids), noun),
+		confirmLabel: fmt.Sprintf("kill %d %s", len(ids), noun),
+		onConfirm: func(m *Model) tea.Cmd {
+			m.resetCtrlC()
+			return killSessionsCmd(m.client, ids, m.killOptions(ids))
+		},
+	}
+	for i, id := range ids {
+		if i == dialogMaxSessions {
+			d.lines = append(d.lines, dialogLine{text: fmt.Sprintf("… and %d more", len(ids)-i), muted: true})
+			break
+		}
+		session, ok := m.sessionByID(id)
+		if !ok {
+			d.lines = append(d.lines, dialogLine{text: "• " + sessionLabel(id)})
+			continue
+		}
+		label := "• " + session.Name
+		switch {
+		case m.isProtected(session):
+			label += " (protected, will be skipped)"
+		case m.staleReason(id) != "":
+			label += " (" + m.staleReason(id) + ")"
+		}
+		d.lines = append(d.lines, dialogLine{text: label})
+		for _, line := range m.tailLines(id, dialogTailLines) {
+			d.lines = append(d.lines, dialogLine{text: "    " + line, muted: true})
+		}
+	}
+	if m.archiveDir != "" {
+		d.lines = append(d.lines, dialogLine{text: "", muted: true}, dialogLine{text: "Scrollback is archived first (A to browse).", muted: true})
+	}
+	m.openDialog(d)
+	return nil
+}
+
+// killOptions snapshots the protect patterns, archive directory, and target
+// sessions for a kill request.
+func (m *Model) killOptions(ids []string) killOptions {
+	opts := killOptions{protect: m.staleProtect, archiveDir: m.archiveDir}
+	if opts.archiveDir == "" {
+		return opts
+	}
+	opts.sessions = make(map[string]tmux.Session, len(ids))
+	for _, id := range ids {
+		if session, ok := m.sessionByID(id); ok {
+			opts.sessions[id] = session
+		}
+	}
+	return opts
+}
+
+// tailLines returns the last n non-blank lines of a session's preview.
+func (m *Model) tailLines(sessionID string, n int) []string {
+	preview, ok := m.previews[sessionID]
+	if !ok || preview == nil {
+		return nil
+	}
+	lines := strings.Split(preview.lastContent, "\n")
+	var out []string
+	for i := len(lines) - 1; i >= 0 && len(out) < n; i-- {
+		line := strings.TrimSpace(lines[i])
+		if line == "" {
+			continue
+		}
+		out = append([]string{line}, out...)
+	}
+	return out
+}
@@ -0,0 +1,146 @@
+// File dialog_test.go covers the confirmation dialog's keys, mouse buttons,
+// and the kill confirmation contents.
+package ui
+
+import (
+	"errors"
+	"image"
+	"strings"
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+	"github.com/charmbracelet/x/cellbuf"
+
+	"github.com/steipete/tmuxwatch/internal/tmux"
+	zone "github.com/steipete/tmuxwatch/internal/zone"
+)
+
+// TestConfirmKillSessionsListsTargets shows each session with its reason and
+// last output before anything is killed.
+func TestConfirmKillSessionsListsTargets(t *testing.T) {
+	t.Parallel()
+
+	m := testModel(tmux.Session{ID: "$1", Name: "build"}, tmux.Session{ID: "$2", Name: "db", Protected: true})
+	m.previews["$1"] = &sessionPreview{lastContent: "compiling\nok  pkg\n\nFAIL pkg/api\n\n"}
+	m.staleWhy = map[string]string{"$1": "idle 2h ≥ 1h"}
+	if cmd := m.confirmKillSessions([]string{"$1", "$2"}); cmd != nil {
+		t.Fatal("opening the dialog should not kill anything yet")
+	}
+	if m.dialog == nil {
+		t.Fatal("expected a dialog")
+	}
+	var texts []string
+	for _, line := range m.dialog.lines {
+		texts = append(texts, line.text)
+	}
+	got := strings.Join(texts, "\n")
+	want := "• build (idle 2h ≥ 1h)\n    ok  pkg\n    FAIL pkg/api\n• db (protected, will be skipped)"
+	if got != want {
+		t.Fatalf("dialog lines:\n%s\nwant:\n%s", got, want)
+	}
+	if m.dialog.title != "Kill 2 sessions?" {
+		t.Fatalf("title = %q", m.dialog.title)
+	}
+}
+
+// TestDialogKeys cancels by default on enter and confirms with y or with
+// enter once focus moves to the confirm button.
+func TestDialogKeys(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		keys    []tea.KeyPressMsg
+		confirm bool
+	}{
+		{name: "enter cancels", keys: []tea.KeyPressMsg{{Code: tea.KeyEnter}}},
+		{name: "esc cancels", keys: []tea.KeyPressMsg{{Code: tea.KeyEscape}}},
+		{name: "y confirms", keys: []tea.KeyPressMsg{{Code: 'y', Text: "y"}}, confirm: true},
+		{name: "tab then enter confirms", keys: []tea.KeyPressMsg{{Code: tea.KeyTab}, {Code: tea.KeyEnter}}, confirm: true},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			m := &Model{}
+			confirmed := false
+			m.openDialog(&dialog{title: "Kill?", onConfirm: func(*Model) tea.Cmd {
+				confirmed = true
+				return nil
+			}})
+			for _, key := range tc.keys {
+				m.handleDialogKey(key)
+			}
+			if m.dialog != nil {
+				t.Fatal("dialog should be closed")
+			}
+			if confirmed != tc.confirm {
+				t.Fatalf("confirmed = %v, want %v", confirmed, tc.confirm)
+			}
+		})
+	}
+}
+
+// TestDialogMouseButtons marks both buttons as zones and confirms or cancels
+// on a click there.
+func TestDialogMouseButtons(t *testing.T) {
+	zone.NewGlobal()
+
+	tests := []struct {
+		name    string
+		zone    string
+		cells   string
+		confirm bool
+	}{
+		{name: "confirm", zone: "dialog-confirm", cells: " y kill 1 session ", confirm: true},
+		{name: "cancel", zone: "dialog-cancel", cells: " n cancel "},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			m := &Model{zonePrefix: zone.NewPrefix()}
+			confirmed := false
+			m.openDialog(&dialog{title: "Kill 1 session?", confirmLabel: "kill 1 session", onConfirm: func(*Model) tea.Cmd {
+				confirmed = true
+				return nil
+			}})
+			box := zone.Scan(m.renderDialog(80))
+			info := zone.Get(m.zonePrefix + tc.zone)
+			if info == nil {
+				t.Fatalf("zone %s was not marked", tc.zone)
+			}
+			buf := cellbuf.NewBuffer(lipgloss.Width(box), countLines(box))
+			cellbuf.SetContentRect(buf, box, image.Rect(0, 0, lipgloss.Width(box), countLines(box)))
+			var cells strings.Builder
+			for x := info.StartX; x <= info.EndX; x++ {
+				if cell := buf.Cell(x, info.StartY); cell != nil {
+					cells.WriteRune(cell.Rune)
+				}
+			}
+			if got := cells.String(); got != tc.cells {
+				t.Fatalf("zone cells = %q, want %q", got, tc.cells)
+			}
+
+			m.handleDialogMouse(tea.MouseClickMsg{X: info.StartX + 1, Y: info.StartY, Button: tea.MouseLeft})
+			if confirmed != tc.confirm || m.dialog != nil {
+				t.Fatalf("confirmed = %v, dialog open = %v", confirmed, m.dialog != nil)
+			}
+		})
+	}
+}
+
+// TestKillSummaryReportsEverySession names killed, refused, and failed
+// sessions in one line.
+func TestKillSummaryReportsEverySession(t *testing.T) {
+	t.Parallel()
+
+	got := killSummary([]killResult{
+		{id: "$1", name: "build", archive: "/tmp/archive/build"},
+		{id: "$2", name: "db", refused: true},
+		{id: "$3", name: "web", err: errors.New("no such session")},
+		{id: "$4", name: "docs"},
+	})
+	want := "Killed build, docs · archived 1 · refused protected db · failed web (no such session)"
+	if got != want {
+		t.Fatalf("killSummary = %q, want %q", got, want)
+	}
+}
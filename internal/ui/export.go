@@ -0,0 +1,156 @@
+// File export.go gets text out of a card: the pane's visible screen or whole
+// scrollback is saved to a file in the export directory or copied to the
+// system clipboard with OSC 52. Colours are stripped unless kept with the
+// export.ansi option or its palette toggle.
+package ui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+
+	"github.com/steipete/tmuxwatch/internal/export"
+	"github.com/steipete/tmuxwatch/internal/tmux"
+)
+
+// exportScope says how much of a pane is exported.
+type exportScope int
+
+const (
+	exportScreen exportScope = iota
+	exportScrollback
+)
+
+// String names the scope in file names and messages.
+func (s exportScope) String() string {
+	if s == exportScrollback {
+		return "scrollback"
+	}
+	return "screen"
+}
+
+// exportRequest describes one export for exportCmd.
+type exportRequest struct {
+	sessionID string
+	session   string
+	paneID    string
+	scope     exportScope
+	ansi      bool
+	clipboard bool
+	dir       string
+}
+
+// exportPane captures the card's active pane and saves it to a file or
+// copies it to the clipboard.
+func (m *Model) exportPane(sessionID string, scope exportScope, clipboard bool) tea.Cmd {
+	session, ok := m.sessionByID(sessionID)
+	if !ok {
+		return nil
+	}
+	pane, ok := m.paneFor(sessionID)
+	if !ok {
+		return nil
+	}
+	if !clipboard && m.exportDir == "" {
+		return showStatusMessage("Saving exports is unavailable")
+	}
+	m.resetCtrlC()
+	return exportCmd(m.client, exportRequest{
+		sessionID: sessionID,
+		session:   session.Name,
+		paneID:    pane.ID,
+		scope:     scope,
+		ansi:      m.exportANSI,
+		clipboard: clipboard,
+		dir:       m.exportDir,
+	})
+}
+
+// exportCmd captures the pane and, unless it goes to the clipboard, writes
+// the text to a file.
+func exportCmd(client *tmux.Client, req exportRequest) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		msg := exportMsg{sessionID: req.sessionID, scope: req.scope, clipboard: req.clipboard}
+		text, err := client.CaptureText(ctx, req.paneID, req.scope == exportScrollback, req.ansi)
+		if err != nil {
+			msg.err = err
+			return msg
+		}
+		text = strings.TrimRight(text, "\n") + "\n"
+		msg.lines = strings.Count(text, "\n")
+		if req.clipboard {
+			msg.text = text
+			return msg
+		}
+		msg.path, msg.err = export.Write(req.dir, req.session, req.paneID, req.scope.String(), time.Now(), text)
+		return msg
+	}
+}
+
+// handleExport reports a finished export and hands clipboard text to the
+// terminal.
+func (m *Model) handleExport(msg exportMsg) tea.Cmd {
+	if msg.err != nil {
+		m.showToast("Export failed: " + msg.err.Error())
+		return nil
+	}
+	if msg.clipboard {
+		m.logEvent(eventAction, msg.sessionID, fmt.Sprintf("copied %s (%d lines)", msg.scope, msg.lines))
+		m.showToast(fmt.Sprintf("Copied %d lines of %s to the clipboard", msg.lines, msg.scope))
+		return tea.SetClipboard(msg.text)
+	}
+	m.logEvent(eventAction, msg.sessionID, fmt.Sprintf("saved %s to %s", msg.scope, msg.path))
+	m.showToast(fmt.Sprintf("Saved %d lines to %s", msg.lines, msg.path))
+	return nil
+}
+
+// toggleExportANSI switches between keeping and stripping colours.
+func (m *Model) toggleExportANSI() tea.Cmd {
+	m.exportANSI = !m.exportANSI
+	if m.exportANSI {
+		return showStatusMessage("Exports keep ANSI colours")
+	}
+	return showStatusMessage("Exports strip ANSI colours")
+}
+
+// exportCommands lists the export actions for a card; suffix is appended to
+// each label.
+func (m *Model) exportCommands(sessionID, suffix string) []commandItem {
+	ansi := "off"
+	if m.exportANSI {
+		ansi = "on"
+	}
+	item := func(label string, scope exportScope, clipboard bool) commandItem {
+		return commandItem{
+			label:   label + suffix,
+			enabled: clipboard || m.exportDir != "",
+			run:     func(m *Model) tea.Cmd { return m.exportPane(sessionID, scope, clipboard) },
+		}
+	}
+	return []commandItem{
+		item("Copy screen", exportScreen, true),
+		item("Copy scrollback", exportScrollback, true),
+		item("Save screen to file", exportScreen, false),
+		item("Save scrollback to file", exportScrollback, false),
+		{
+			label:   "Keep ANSI colours in exports: " + ansi,
+			enabled: true,
+			run:     func(m *Model) tea.Cmd { return m.toggleExportANSI() },
+		},
+	}
+}
+
+// exportPaletteCommands offers exporting the focused or cursor card.
+func (m *Model) exportPaletteCommands() []commandItem {
+	target := m.jumpSession()
+	session, ok := m.sessionByID(target)
+	if !ok {
+		return nil
+	}
+	return m.exportCommands(target, " of "+session.Name)
+}
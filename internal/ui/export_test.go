@@ -0,0 +1,90 @@
+// File export_test.go covers exporting pane text to files and the clipboard.
+package ui
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+
+	"github.com/steipete/tmuxwatch/internal/tmux"
+)
+
+// TestHandleExport copies clipboard exports through OSC 52, reports saved
+// files, and surfaces capture errors.
+func TestHandleExport(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		msg     exportMsg
+		wantCmd bool
+		toast   string
+		event   string
+	}{
+		{
+			name:    "clipboard",
+			msg:     exportMsg{sessionID: "$1", scope: exportScreen, clipboard: true, text: "a\nb\n", lines: 2},
+			wantCmd: true,
+			toast:   "Copied 2 lines of screen to the clipboard",
+			event:   "copied screen (2 lines)",
+		},
+		{
+			name:  "file",
+			msg:   exportMsg{sessionID: "$1", scope: exportScrollback, path: "/tmp/x.txt", lines: 40},
+			toast: "Saved 40 lines to /tmp/x.txt",
+			event: "saved scrollback to /tmp/x.txt",
+		},
+		{
+			name:  "error",
+			msg:   exportMsg{sessionID: "$1", err: errors.New("no pane")},
+			toast: "Export failed: no pane",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			m := testModel(lifecycleSession("$1", "ci", tmux.Pane{ID: "%1"}))
+			_, cmd := m.Update(tt.msg)
+			if (cmd != nil) != tt.wantCmd {
+				t.Fatalf("cmd = %v, want a command: %v", cmd, tt.wantCmd)
+			}
+			if m.toast == nil || m.toast.text != tt.toast {
+				t.Fatalf("toast = %+v, want %q", m.toast, tt.toast)
+			}
+			if tt.event != "" && (len(m.timeline) != 1 || m.timeline[0].text != tt.event) {
+				t.Fatalf("timeline = %+v, want %q", m.timeline, tt.event)
+			}
+		})
+	}
+}
+
+// TestExportKeysAndOptions binds y and Y to clipboard exports, refuses file
+// exports without a directory, and toggles keeping colours.
+func TestExportKeysAndOptions(t *testing.T) {
+	t.Parallel()
+
+	m := testModel(lifecycleSession("$1", "ci", tmux.Pane{ID: "%1"}))
+	for _, key := range []tea.KeyPressMsg{{Code: 'y', Text: "y"}, {Code: 'Y', Text: "Y"}} {
+		if _, cmd := m.Update(key); cmd == nil {
+			t.Fatalf("%s should start a clipboard export", key)
+		}
+	}
+	items := m.exportPaletteCommands()
+	if len(items) != 5 || items[0].label != "Copy screen of ci" || items[2].enabled {
+		t.Fatalf("palette = %+v, want file exports disabled without a directory", items)
+	}
+	if cmd := m.exportPane("$1", exportScreen, false); cmd == nil {
+		t.Fatal("a file export without a directory should report it")
+	}
+
+	m.exportDir = t.TempDir()
+	if !m.exportPaletteCommands()[3].enabled {
+		t.Fatal("file exports should be enabled with a directory")
+	}
+	m.toggleExportANSI()
+	if !m.exportANSI || !strings.HasSuffix(m.exportPaletteCommands()[4].label, ": on") {
+		t.Fatal("the toggle should keep colours")
+	}
+}
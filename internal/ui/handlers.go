@@ -14,82 +14,141 @@ import (
 	"github.com/steipete/tmuxwatch/internal/tmux"
 )
 
+// handleLeaderKey implements the optional tmux-style prefix, which runs a
+// tmuxwatch command from insert mode without leaving it. Pressing the leader
+// twice forwards it to the pane.
+func (m *Model) handleLeaderKey(msg tea.KeyMsg) (bool, tea.Cmd) {
+	leader := m.keymap().leader
+	if leader == "" {
+		return false, nil
+	}
+	key := msg.String()
+	if m.leaderArmed {
+		m.leaderArmed = false
+		if key == leader {
+			if preview, ok := m.previews[m.focusedSession]; ok && preview.paneID != "" {
+				if key, ok := tmuxKeyFrom(msg); ok {
+					m.logKeys(m.focusedSession, []string{key.name})
+					return true, sendTmuxKeyCmd(m.client, preview.paneID, key)
+				}
+			}
+			return true, nil
+		}
+		if handled, cmd := m.handleGlobalKey(msg); handled {
+			return true, cmd
+		}
+		if handled, cmd := m.runPaneAction(m.keymap().lookup(scopePane, key)); handled {
+			return true, cmd
+		}
+		return true, nil
+	}
+	if key == leader {
+		m.leaderArmed = true
+		m.resetCtrlC()
+		return true, nil
+	}
+	return false, nil
+}
+
 // handleGlobalKey processes keys that apply regardless of focus.
 func (m *Model) handleGlobalKey(msg tea.KeyMsg) (bool, tea.Cmd) {
 	if _, ok := msg.(tea.KeyPressMsg); !ok {
 		return false, nil
 	}
-	if msg.String() != "esc" {
+	key := msg.String()
+	act := m.keymap().lookup(scopeGlobal, key)
+	if act != actionBack {
 		m.lastEsc = time.Time{}
 	}
-	switch msg.String() {
-	case "shift+left":
+	if act == actionNone && m.focusedSession == "" {
+		act = m.keymap().lookup(scopeOverview, key)
+	}
+	switch act {
+	case actionPrevTab:
 		m.shiftActiveTab(-1)
 		m.updatePreviewDimensions(m.filteredSessionCount())
 		return true, nil
-	case "shift+right":
+	case actionNextTab:
 		m.shiftActiveTab(1)
 		m.updatePreviewDimensions(m.filteredSessionCount())
 		return true, nil
-	case "left":
-		if m.focusedSession != "" {
-			return false, nil
-		}
-		if m.moveCursorLeft() {
-			return true, nil
-		}
+	case actionCursorLeft:
+		m.moveCursorLeft()
 		return true, nil
-	case "right":
-		if m.focusedSession != "" {
-			return false, nil
-		}
-		if m.moveCursorRight() {
-			return true, nil
-		}
+	case actionCursorRight:
+		m.moveCursorRight()
 		return true, nil
-	case "up":
-		if m.focusedSession != "" {
-			return false, nil
-		}
-		if m.moveCursorUp() {
-			return true, nil
-		}
+	case actionCursorUp:
+		m.moveCursorUp()
 		return true, nil
-	case "down":
-		if m.focusedSession != "" {
-			return false, nil
-		}
-		if m.moveCursorDown() {
+	case actionCursorDown:
+		m.moveCursorDown()
+		return true, nil
+	case actionCycleSort:
+		mode := m.cycleSort()
+		m.updatePreviewDimensions(m.filteredSessionCount())
+		return true, m.sortChangedCmd(mode)
+	case actionCycleGroup:
+		mode := m.cycleGroup()
+		m.updatePreviewDimensions(m.filteredSessionCount())
+		return true, m.groupChangedCmd(mode)
+	case actionToggleGroup:
+		if !m.grouped() {
 			return true, nil
 		}
+		if key, ok := m.groupOfSession(m.cursorSession); ok {
+			m.toggleGroup(key)
+		}
 		return true, nil
-	case "enter":
+	case actionTogglePin:
+		return true, m.pinCmd(m.cursorSession)
+	case actionToggleWatch:
+		return true, m.toggleWatch(m.cursorSession)
+	case actionMonSilence:
+		return true, m.toggleSilenceMonitor(m.cursorSession)
+	case actionMonActivity:
+		return true, m.toggleActivityMonitor(m.cursorSession)
+	case actionMoveLeft:
+		return true, m.moveCursorCard(-1, true)
+	case actionMoveRight:
+		return true, m.moveCursorCard(1, true)
+	case actionMoveUp:
+		return true, m.moveCursorCard(-1, false)
+	case actionMoveDown:
+		return true, m.moveCursorCard(1, false)
+	case actionSelect:
+		m.toggleSelected(m.cursorSession)
+		return true, nil
+	case actionSelectAll:
+		if n := m.selectMatching(); n > 0 {
+			return true, showStatusMessage(fmt.Sprintf("Selected %d sessions", n))
+		}
+		return true, showStatusMessage("Selection cleared")
+	case actionFocus:
 		if m.cursorSession == "" {
 			return true, nil
 		}
-		if m.focusedSession != m.cursorSession {
-			m.focusedSession = m.cursorSession
-			m.resetCtrlC()
-			if preview, ok := m.previews[m.focusedSession]; ok {
-				preview.viewport.GotoBottom()
-				if preview.paneID != "" {
-					return true, fetchPaneVarsCmd(m.client, m.focusedSession, preview.paneID)
-				}
-			}
+		if m.focusedSession == m.cursorSession {
+			m.enterInsertMode()
+			return true, nil
 		}
-		return true, nil
-	case "/", "ctrl+f":
+		return true, m.focusCard(m.cursorSession)
+	case actionSearch:
 		m.resetCtrlC()
 		m.searching = true
 		m.searchInput.SetValue(m.searchQuery)
 		m.searchInput.CursorEnd()
 		return true, nil
-	case "esc":
+	case actionBack:
 		if m.viewMode == viewModeDetail && m.activeTab == 1 {
 			m.leaveDetail(false)
 			m.updatePreviewDimensions(m.filteredSessionCount())
 			return true, nil
 		}
+		if m.focusedSession == "" && len(m.selected) > 0 {
+			m.clearSelection()
+			return true, nil
+		}
 		if m.searchQuery != "" {
 			m.resetCtrlC()
 			m.searchQuery = ""
@@ -110,31 +169,64 @@ func (m *Model) handleGlobalKey(msg tea.KeyMsg) (bool, tea.Cmd) {
 		}
 		m.lastEsc = now
 		return true, nil
-	case "ctrl+p":
+	case actionPalette:
 		if m.paletteOpen {
 			m.closePalette()
 		} else {
 			m.openCommandPalette()
 		}
 		return true, nil
-	case "H":
-		if len(m.hidden) > 0 {
-			m.resetCtrlC()
-			m.hidden = make(map[string]struct{})
-			m.updatePreviewDimensions(m.filteredSessionCount())
-		}
+	case actionShowHidden:
+		m.openHiddenManager()
+		m.resetCtrlC()
 		return true, nil
-	case "ctrl+x":
+	case actionKillAllStale:
 		ids := m.staleSessionIDs()
 		if len(ids) == 0 {
 			return true, nil
 		}
 		m.resetCtrlC()
-		return true, killSessionsCmd(m.client, ids)
-	case "q":
+		return true, m.confirmKillSessions(ids)
+	case actionQuit:
 		m.resetCtrlC()
 		return true, tea.Quit
-	case "X":
+	case actionAckAlerts:
+		return true, m.acknowledgeCmd()
+	case actionTimeline:
+		m.openTimeline()
+		m.resetCtrlC()
+		return true, nil
+	case actionJump:
+		m.resetCtrlC()
+		return true, m.jumpToTmux(m.jumpSession())
+	case actionCompose:
+		m.resetCtrlC()
+		return true, m.openComposer("")
+	case actionRecord:
+		m.resetCtrlC()
+		return true, m.toggleRecording(m.jumpSession())
+	case actionCopyScreen:
+		return true, m.exportPane(m.jumpSession(), exportScreen, true)
+	case actionCopyScrollback:
+		return true, m.exportPane(m.jumpSession(), exportScrollback, true)
+	case actionCardMenu:
+		m.resetCtrlC()
+		m.openCardMenu(m.jumpSession(), -1, -1)
+		return true, nil
+	case actionReplay:
+		m.resetCtrlC()
+		if m.replay != nil && !m.replayActive() {
+			m.focusReplay()
+			return true, nil
+		}
+		return true, m.promptReplay()
+	case actionBroadcast:
+		return true, m.startBroadcast()
+	case actionArchive:
+		m.openArchive()
+		m.resetCtrlC()
+		return true, nil
+	case actionKillStale:
 		if m.focusedSession == "" {
 			return true, nil
 		}
@@ -142,13 +234,14 @@ func (m *Model) handleGlobalKey(msg tea.KeyMsg) (bool, tea.Cmd) {
 			return true, nil
 		}
 		m.resetCtrlC()
-		return true, killSessionsCmd(m.client, []string{m.focusedSession})
+		return true, m.confirmKillSessions([]string{m.focusedSession})
 	}
 	return false, nil
 }
 
-// handleFocusedKey forwards navigation and control keys to the focused pane or
-// local viewport.
+// handleFocusedKey runs pane actions for the focused card in navigation
+// mode. Other keys are not sent to the pane, except ctrl+c, which always
+// interrupts it.
 func (m *Model) handleFocusedKey(msg tea.KeyMsg) (bool, tea.Cmd) {
 	if _, ok := msg.(tea.KeyPressMsg); !ok {
 		return false, nil
@@ -156,57 +249,55 @@ func (m *Model) handleFocusedKey(msg tea.KeyMsg) (bool, tea.Cmd) {
 	if m.focusedSession == "" {
 		return false, nil
 	}
+	if _, ok := m.previews[m.focusedSession]; !ok {
+		return false, nil
+	}
+	if msg.String() == "ctrl+c" {
+		return m.forwardKey(msg)
+	}
+	return m.runPaneAction(m.keymap().lookup(scopePane, msg.String()))
+}
+
+// runPaneAction executes viewport and card actions for the focused session.
+func (m *Model) runPaneAction(act action) (bool, tea.Cmd) {
 	preview, ok := m.previews[m.focusedSession]
 	if !ok {
 		return false, nil
 	}
-	pane, paneOK := m.paneFor(m.focusedSession)
-	switch msg.String() {
-	case "up":
+	switch act {
+	case actionScrollUp:
 		m.resetCtrlC()
 		preview.viewport.ScrollUp(1)
 		return true, nil
-	case "down":
+	case actionScrollDown:
 		m.resetCtrlC()
 		preview.viewport.ScrollDown(1)
 		return true, nil
-	case "pgup":
+	case actionPageUp:
 		m.resetCtrlC()
 		preview.viewport.PageUp()
 		return true, nil
-	case "pgdown":
+	case actionPageDown:
 		m.resetCtrlC()
 		preview.viewport.PageDown()
 		return true, nil
-	case "ctrl+u":
+	case actionHalfPageUp:
 		m.resetCtrlC()
 		preview.viewport.ScrollUp(scrollStep)
 		return true, nil
-	case "ctrl+d":
+	case actionHalfPageDown:
 		m.resetCtrlC()
 		preview.viewport.ScrollDown(scrollStep)
 		return true, nil
-	case "g":
+	case actionScrollTop:
 		m.resetCtrlC()
 		preview.viewport.GotoTop()
 		return true, nil
-	case "G":
+	case actionScrollBottom:
 		m.resetCtrlC()
 		preview.viewport.GotoBottom()
 		return true, nil
-	case "ctrl+c":
-		now := time.Now()
-		if !paneOK || pane.Dead || preview.paneID == "" {
-			return true, tea.Quit
-		}
-		cmd := sendKeysCmd(m.client, preview.paneID, "C-c")
-		if !m.lastCtrlC.IsZero() && now.Sub(m.lastCtrlC) < quitChordWindow {
-			m.resetCtrlC()
-			return true, tea.Batch(cmd, tea.Quit)
-		}
-		m.lastCtrlC = now
-		return true, cmd
-	case "ctrl+m":
+	case actionToggleDetail:
 		target := m.focusedSession
 		if target == "" {
 			target = m.cursorSession
@@ -216,62 +307,146 @@ func (m *Model) handleFocusedKey(msg tea.KeyMsg) (bool, tea.Cmd) {
 			m.updatePreviewDimensions(m.filteredSessionCount())
 		}
 		return true, nil
-	case "z":
-		if m.focusedSession != "" {
-			m.toggleCollapsed(m.focusedSession)
-			m.updatePreviewDimensions(m.filteredSessionCount())
-			return true, nil
-		}
-	case "Z":
+	case actionCollapse:
+		m.toggleCollapsed(m.focusedSession)
+		m.updatePreviewDimensions(m.filteredSessionCount())
+		return true, nil
+	case actionExpandAll:
 		m.clearCollapsed()
 		m.updatePreviewDimensions(m.filteredSessionCount())
 		return true, nil
+	case actionInsert:
+		m.enterInsertMode()
+		return true, nil
+	case actionPrevPane:
+		return true, m.cyclePane(-1)
+	case actionNextPane:
+		return true, m.cyclePane(1)
+	case actionCardLeft:
+		return true, m.focusNeighbor(-1, true)
+	case actionCardRight:
+		return true, m.focusNeighbor(1, true)
+	case actionCardUp:
+		return true, m.focusNeighbor(-1, false)
+	case actionCardDown:
+		return true, m.focusNeighbor(1, false)
+	}
+	return false, nil
+}
+
+// focusCard focuses sessionID in navigation mode, moving the cursor with it,
+// and scrolls its card to the latest output.
+func (m *Model) focusCard(sessionID string) tea.Cmd {
+	m.cursorSession = sessionID
+	if m.focusedSession == sessionID {
+		return nil
+	}
+	m.focusedSession = sessionID
+	m.markRead(sessionID)
+	m.resetCtrlC()
+	if preview, ok := m.previews[sessionID]; ok {
+		preview.viewport.GotoBottom()
+		if preview.paneID != "" {
+			return fetchPaneVarsCmd(m.client, sessionID, preview.paneID)
+		}
+	}
+	return nil
+}
+
+// focusNeighbor moves focus to the card delta columns (horizontal) or rows
+// away from the focused one, so navigation mode can walk the grid without
+// unfocusing first.
+func (m *Model) focusNeighbor(delta int, horizontal bool) tea.Cmd {
+	next, ok := m.cardNeighbor(m.focusedSession, delta, horizontal)
+	if !ok {
+		return nil
+	}
+	return m.focusCard(next)
+}
+
+// forwardKey sends a key to the focused pane. ctrl+c is forwarded as C-c and
+// quits tmuxwatch when pressed twice in quick succession or when the pane can
+// no longer receive input.
+func (m *Model) forwardKey(msg tea.KeyMsg) (bool, tea.Cmd) {
+	preview, ok := m.previews[m.focusedSession]
+	if !ok {
+		return false, nil
+	}
+	if msg.String() == "ctrl+c" {
+		pane, paneOK := m.paneFor(m.focusedSession)
+		now := time.Now()
+		if !paneOK || pane.Dead || preview.paneID == "" {
+			return true, tea.Quit
+		}
+		cmd := sendKeysCmd(m.client, preview.paneID, "C-c")
+		m.logKeys(m.focusedSession, []string{"C-c"})
+		if !m.lastCtrlC.IsZero() && now.Sub(m.lastCtrlC) < quitChordWindow {
+			m.resetCtrlC()
+			return true, tea.Batch(cmd, tea.Quit)
+		}
+		m.lastCtrlC = now
+		return true, cmd
 	}
 
-	keys, ok := tmuxKeysFrom(msg)
+	key, ok := tmuxKeyFrom(msg)
 	if !ok || preview.paneID == "" {
 		m.resetCtrlC()
 		return false, nil
 	}
 	m.resetCtrlC()
-	return true, sendKeysCmd(m.client, preview.paneID, keys...)
+	m.logKeys(m.focusedSession, []string{key.name})
+	return true, sendTmuxKeyCmd(m.client, preview.paneID, key)
 }
 
-// tmuxKeysFrom converts Bubble Tea key messages into tmux key strings.
-func tmuxKeysFrom(msg tea.KeyMsg) ([]string, bool) {
-	press, ok := msg.(tea.KeyPressMsg)
-	if !ok {
-		return nil, false
-	}
-	key := press.Key()
-	switch key.Code {
-	case tea.KeyEnter:
-		return []string{"Enter"}, true
-	case tea.KeyTab:
-		return []string{"Tab"}, true
-	case tea.KeySpace:
-		return []string{" "}, true
-	case tea.KeyBackspace:
-		return []string{"BSpace"}, true
-	case tea.KeyDelete:
-		return []string{"Delete"}, true
-	case tea.KeyEsc:
-		return []string{"Escape"}, true
-	}
-	if key.Mod&tea.ModAlt != 0 {
-		return nil, false
-	}
-	if key.Text == "" {
-		return nil, false
-	}
-	return []string{key.Text}, true
+// handlePaste types pasted text into the search box, prompt, or composer and
+// otherwise pastes it into the broadcast targets or the focused pane. Other
+// overlays ignore pastes.
+func (m *Model) handlePaste(msg tea.PasteMsg) (tea.Model, tea.Cmd) {
+	if m.searching {
+		var cmd tea.Cmd
+		m.searchInput, cmd = m.searchInput.Update(msg)
+		m.searchQuery = strings.TrimSpace(m.searchInput.Value())
+		m.updatePreviewDimensions(m.filteredSessionCount())
+		return m, cmd
+	}
+	if m.prompt != nil && m.dialog == nil {
+		return m.handlePromptPaste(msg)
+	}
+	if m.composer != nil && m.dialog == nil {
+		return m.handleComposerPaste(msg)
+	}
+	if m.dialog != nil || m.timelineOpen || m.archiveOpen || m.hiddenOpen || m.paletteOpen {
+		return m, nil
+	}
+	if m.broadcasting {
+		return m, m.broadcastPaste(msg.Content)
+	}
+	preview, ok := m.previews[m.focusedSession]
+	if !ok || preview.paneID == "" || msg.Content == "" {
+		return m, nil
+	}
+	m.resetCtrlC()
+	m.logEvent(eventAction, m.focusedSession, fmt.Sprintf("pasted %d bytes", len(msg.Content)))
+	return m, pasteCmd(m.client, preview.paneID, msg.Content)
 }
 
 // handleMouse wires up focus toggles, pane hiding, and scroll gestures.
 func (m *Model) handleMouse(msg tea.MouseMsg) (tea.Model, tea.Cmd) {
+	if m.dialog != nil {
+		return m.handleDialogMouse(msg)
+	}
+	if m.cardMenu != nil {
+		return m.handleCardMenuMouse(msg)
+	}
 	if handled, cmd := m.handleTabMouse(msg); handled {
 		return m, cmd
 	}
+	if m.handleGroupMouse(msg) {
+		return m, nil
+	}
+	if m.handleReplayMouse(msg) {
+		return m, nil
+	}
 	if len(m.cardLayout) == 0 {
 		if _, motion := msg.(tea.MouseMotionMsg); motion {
 			m.hoveredSession = ""
@@ -281,6 +456,15 @@ func (m *Model) handleMouse(msg tea.MouseMsg) (tea.Model, tea.Cmd) {
 	}
 	card, ok := m.cardAt(msg)
 	m.logMouseEvent(msg, card, ok)
+	if _, release := msg.(tea.MouseReleaseMsg); release {
+		dragged := m.dragSession
+		m.dragSession = ""
+		if ok && m.moveCardTo(dragged, card.sessionID) {
+			m.cursorSession = dragged
+			return m, m.saveStateCmd()
+		}
+		return m, nil
+	}
 	if !ok {
 		if _, motion := msg.(tea.MouseMotionMsg); motion {
 			m.hoveredSession = ""
@@ -306,8 +490,17 @@ func (m *Model) handleMouse(msg tea.MouseMsg) (tea.Model, tea.Cmd) {
 			preview.viewport.ScrollUp(scrollStep)
 			m.hoveredSession = card.sessionID
 		}
+	case tea.MouseRight:
+		if _, click := msg.(tea.MouseClickMsg); click {
+			m.hoveredControl = ""
+			m.openCardMenu(card.sessionID, mouse.X, mouse.Y)
+		}
 	case tea.MouseLeft:
 		if _, click := msg.(tea.MouseClickMsg); click {
+			if info := zone.Get(card.jumpZoneID); info != nil && info.InBounds(msg) {
+				m.hoveredControl = ""
+				return m, m.jumpToTmux(card.sessionID)
+			}
 			if info := zone.Get(card.maximizeZoneID); info != nil && info.InBounds(msg) {
 				m.hoveredControl = ""
 				m.handleDetailToggle(card.sessionID)
@@ -323,25 +516,22 @@ func (m *Model) handleMouse(msg tea.MouseMsg) (tea.Model, tea.Cmd) {
 				}
 			}
 			if info := zone.Get(card.closeZoneID); info != nil && info.InBounds(msg) {
-				m.hidden[card.sessionID] = struct{}{}
-				if m.focusedSession == card.sessionID {
-					m.focusedSession = ""
-				}
-				if m.cursorSession == card.sessionID {
-					m.cursorSession = ""
-				}
-				if m.hoveredSession == card.sessionID {
-					m.hoveredSession = ""
-				}
+				m.hideSession(card.sessionID)
 				m.hoveredControl = ""
-				delete(m.previews, card.sessionID)
 				m.resetCtrlC()
 				m.updatePreviewDimensions(m.filteredSessionCount())
 				return m, showStatusMessage(fmt.Sprintf("Closed session %s", sessionLabel(card.sessionID)))
 			}
+			if mouse.Mod.Contains(tea.ModShift) {
+				m.toggleSelected(card.sessionID)
+				m.cursorSession = card.sessionID
+				return m, nil
+			}
 			m.focusedSession = card.sessionID
+			m.markRead(card.sessionID)
 			m.cursorSession = card.sessionID
 			m.hoveredSession = card.sessionID
+			m.dragSession = card.sessionID
 			m.resetCtrlC()
 			if preview != nil {
 				preview.viewport.GotoBottom()
@@ -386,6 +576,20 @@ func (m *Model) handleTabMouse(msg tea.MouseMsg) (bool, tea.Cmd) {
 	return false, nil
 }
 
+// handleGroupMouse collapses or expands a group when its header is clicked.
+func (m *Model) handleGroupMouse(msg tea.MouseMsg) bool {
+	if _, click := msg.(tea.MouseClickMsg); !click || msg.Mouse().Button != tea.MouseLeft {
+		return false
+	}
+	for _, group := range m.groupLayout {
+		if info := zone.Get(group.zoneID); info != nil && info.InBounds(msg) {
+			m.toggleGroup(group.key)
+			return true
+		}
+	}
+	return false
+}
+
 // tabIndexFromZoneIDs inspects zone identifiers and extracts the tab index
 // encoded by BubbleApp's `tabtitles` component.
 func tabIndexFromZoneIDs(ids []string) (int, bool) {
@@ -411,7 +615,7 @@ func tabIndexFromZoneIDs(ids []string) (int, bool) {
 }
 
 func controlUnderPointer(card cardBounds, msg tea.MouseMsg) string {
-	for _, id := range []string{card.maximizeZoneID, card.collapseZoneID, card.closeZoneID} {
+	for _, id := range []string{card.jumpZoneID, card.maximizeZoneID, card.collapseZoneID, card.closeZoneID} {
 		if info := zone.Get(id); info != nil && info.InBounds(msg) {
 			return id
 		}
@@ -445,10 +649,61 @@ func (m *Model) handleSearchKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	return m, cmd
 }
 
-// isHidden reports whether the given session ID is hidden from the grid.
+// isHidden reports whether the given session ID is hidden from the grid,
+// either explicitly or because a hide rule matches it.
 func (m *Model) isHidden(id string) bool {
-	_, ok := m.hidden[id]
-	return ok
+	if _, ok := m.hidden[id]; ok {
+		return true
+	}
+	return m.hiddenByPattern(id)
+}
+
+// hiddenByPattern reports whether a hide rule matches the session and the
+// user has not revealed it.
+func (m *Model) hiddenByPattern(id string) bool {
+	if len(m.hideRules) == 0 {
+		return false
+	}
+	if _, ok := m.revealed[id]; ok {
+		return false
+	}
+	session, ok := m.sessionByID(id)
+	if !ok {
+		return false
+	}
+	_, matched := m.matchingHideRule(session)
+	return matched
+}
+
+// hiddenCount returns how many sessions in the snapshot are hidden.
+func (m *Model) hiddenCount() int {
+	count := 0
+	for _, session := range m.sessions {
+		if m.isHidden(session.ID) {
+			count++
+		}
+	}
+	return count
+}
+
+// showHidden reveals manually hidden sessions and sessions hidden by pattern.
+func (m *Model) showHidden() bool {
+	changed := len(m.hidden) > 0
+	m.hidden = make(map[string]struct{})
+	if m.revealed == nil {
+		m.revealed = make(map[string]struct{})
+	}
+	for _, session := range m.sessions {
+		if m.hiddenByPattern(session.ID) {
+			m.revealed[session.ID] = struct{}{}
+			changed = true
+		}
+	}
+	if changed {
+		m.logEvent(eventAction, "", "showed hidden sessions")
+		m.updatePreviewDimensions(m.filteredSessionCount())
+	}
+	return changed
 }
 
 // resetCtrlC clears the timing cache used to detect the quit chord.
@@ -12,45 +12,6 @@ import (
 	"github.com/steipete/tmuxwatch/internal/tmux"
 )
 
-// TestTmuxKeysFrom ensures Bubble Tea key messages map to tmux key strings.
-func TestTmuxKeysFrom(t *testing.T) {
-	t.Parallel()
-
-	tests := []struct {
-		name   string
-		msg    tea.KeyMsg
-		want   []string
-		expect bool
-	}{
-		{name: "enter", msg: tea.KeyPressMsg{Code: tea.KeyEnter}, want: []string{"Enter"}, expect: true},
-		{name: "space", msg: tea.KeyPressMsg{Code: tea.KeySpace}, want: []string{" "}, expect: true},
-		{name: "alt runes rejected", msg: tea.KeyPressMsg{Text: "a", Code: 'a', Mod: tea.ModAlt}, expect: false},
-		{name: "runes ok", msg: tea.KeyPressMsg{Text: "a", Code: 'a'}, want: []string{"a"}, expect: true},
-	}
-
-	for _, tt := range tests {
-		tt := tt
-		t.Run(tt.name, func(t *testing.T) {
-			t.Parallel()
-			got, ok := tmuxKeysFrom(tt.msg)
-			if ok != tt.expect {
-				t.Fatalf("tmuxKeysFrom ok = %v, want %v", ok, tt.expect)
-			}
-			if !tt.expect {
-				return
-			}
-			if len(got) != len(tt.want) {
-				t.Fatalf("tmuxKeysFrom len = %d, want %d", len(got), len(tt.want))
-			}
-			for i := range got {
-				if got[i] != tt.want[i] {
-					t.Fatalf("tmuxKeysFrom got[%d] = %q, want %q", i, got[i], tt.want[i])
-				}
-			}
-		})
-	}
-}
-
 // TestTabIndexFromZoneIDs checks zone identifiers convert to tab indexes.
 func TestTabIndexFromZoneIDs(t *testing.T) {
 	t.Parallel()
@@ -174,4 +135,35 @@ func TestHandleMouseHoverSetsState(t *testing.T) {
 	}
 }
 
+// TestHidePatternsAndShowHidden hides matching sessions until revealed.
+func TestHidePatternsAndShowHidden(t *testing.T) {
+	t.Parallel()
+
+	m := &Model{
+		sessions: []tmux.Session{
+			{ID: "$1", Name: "scratch-1"},
+			{ID: "$2", Name: "api"},
+		},
+		hidden:    map[string]struct{}{"$2": {}},
+		hideRules: []hideRule{{session: "scratch-*"}},
+	}
+	if !m.isHidden("$1") || !m.isHidden("$2") {
+		t.Fatal("expected pattern and manual hides to apply")
+	}
+	if got := m.hiddenCount(); got != 2 {
+		t.Fatalf("hiddenCount = %d, want 2", got)
+	}
+	if !m.showHidden() {
+		t.Fatal("showHidden should report a change")
+	}
+	if m.isHidden("$1") || m.isHidden("$2") {
+		t.Fatal("expected all sessions visible after showHidden")
+	}
+
+	m.sessions = append(m.sessions, tmux.Session{ID: "$3", Name: "scratch-2"})
+	if !m.isHidden("$3") {
+		t.Fatal("new sessions matching a pattern should stay hidden")
+	}
+}
+
 // TestControlUnderPointer identifies active control zones for hover styling.
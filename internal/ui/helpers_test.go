@@ -0,0 +1,27 @@
+// File helpers_test.go holds model and session builders shared by the ui
+// tests.
+package ui
+
+import "github.com/steipete/tmuxwatch/internal/tmux"
+
+// lifecycleSession returns a session with one active window holding panes.
+func lifecycleSession(id, name string, panes ...tmux.Pane) tmux.Session {
+	return tmux.Session{ID: id, Name: name, Windows: []tmux.Window{{Name: "main", Active: true, Panes: panes}}}
+}
+
+// testModel returns an overview of sessions with the cursor on the first
+// card and the bookkeeping maps Update expects. Tests set whatever else they
+// need on the result.
+func testModel(sessions ...tmux.Session) *Model {
+	m := &Model{
+		sessions:  sessions,
+		hidden:    make(map[string]struct{}),
+		stale:     make(map[string]struct{}),
+		collapsed: make(map[string]struct{}),
+		previews:  make(map[string]*sessionPreview),
+	}
+	if len(sessions) > 0 {
+		m.cursorSession = sessions[0].ID
+	}
+	return m
+}
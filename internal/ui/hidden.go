@@ -0,0 +1,255 @@
+// File hidden.go implements hide rules and the hidden-session manager, which
+// lists hidden sessions with the reason each is hidden and restores them one
+// at a time.
+package ui
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+
+	"github.com/steipete/tmuxwatch/internal/config"
+	"github.com/steipete/tmuxwatch/internal/tmux"
+)
+
+// hiddenRows is how many hidden sessions the manager lists at once.
+const hiddenRows = 12
+
+// hideRule hides sessions whose name matches session and that run a command
+// matching command; an empty glob matches anything.
+type hideRule struct {
+	session string
+	command string
+}
+
+// newHideRules merges the `hidden` name patterns and the `hide_rules`
+// config into one rule list.
+func newHideRules(patterns []string, rules []config.HideRule) []hideRule {
+	out := make([]hideRule, 0, len(patterns)+len(rules))
+	for _, pattern := range patterns {
+		out = append(out, hideRule{session: pattern})
+	}
+	for _, rule := range rules {
+		out = append(out, hideRule{session: rule.Session, command: rule.Command})
+	}
+	return out
+}
+
+// matches reports whether the rule hides session.
+func (r hideRule) matches(session tmux.Session) bool {
+	if r.session != "" {
+		if ok, _ := path.Match(r.session, session.Name); !ok {
+			return false
+		}
+	}
+	if r.command == "" {
+		return true
+	}
+	for _, window := range session.Windows {
+		for _, pane := range window.Panes {
+			if ok, _ := path.Match(r.command, pane.CurrentCmd); ok {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// String describes the rule for the manager and title bar.
+func (r hideRule) String() string {
+	switch {
+	case r.command == "":
+		return "name " + r.session
+	case r.session == "":
+		return "runs " + r.command
+	default:
+		return fmt.Sprintf("name %s running %s", r.session, r.command)
+	}
+}
+
+// matchingHideRule returns the first rule that hides session.
+func (m *Model) matchingHideRule(session tmux.Session) (hideRule, bool) {
+	for _, rule := range m.hideRules {
+		if rule.matches(session) {
+			return rule, true
+		}
+	}
+	return hideRule{}, false
+}
+
+// hideReason explains why a session is hidden, or returns "" when it is
+// visible.
+func (m *Model) hideReason(id string) string {
+	if _, ok := m.hidden[id]; ok {
+		return "hidden with [x]"
+	}
+	if !m.hiddenByPattern(id) {
+		return ""
+	}
+	session, _ := m.sessionByID(id)
+	rule, _ := m.matchingHideRule(session)
+	return "rule: " + rule.String()
+}
+
+// hiddenSummary counts hidden sessions by cause for the title bar, e.g.
+// "3 hidden (1 manual, 2 by rule)".
+func (m *Model) hiddenSummary() string {
+	manual, byRule := 0, 0
+	for _, session := range m.sessions {
+		if _, ok := m.hidden[session.ID]; ok {
+			manual++
+		} else if m.hiddenByPattern(session.ID) {
+			byRule++
+		}
+	}
+	switch {
+	case manual+byRule == 0:
+		return ""
+	case byRule == 0:
+		return fmt.Sprintf("%d hidden (manual)", manual)
+	case manual == 0:
+		return fmt.Sprintf("%d hidden (by rule)", byRule)
+	default:
+		return fmt.Sprintf("%d hidden (%d manual, %d by rule)", manual+byRule, manual, byRule)
+	}
+}
+
+// hiddenSessions lists the hidden sessions in snapshot order.
+func (m *Model) hiddenSessions() []tmux.Session {
+	var out []tmux.Session
+	for _, session := range m.sessions {
+		if m.isHidden(session.ID) {
+			out = append(out, session)
+		}
+	}
+	return out
+}
+
+// revealSession makes a hidden session visible, overriding any hide rule
+// until the session closes.
+func (m *Model) revealSession(id string) {
+	delete(m.hidden, id)
+	if m.hiddenByPattern(id) {
+		if m.revealed == nil {
+			m.revealed = make(map[string]struct{})
+		}
+		m.revealed[id] = struct{}{}
+	}
+}
+
+// hideSession hides a card with [x] and drops any focus, cursor, hover, or
+// selection on it.
+func (m *Model) hideSession(id string) {
+	if m.hidden == nil {
+		m.hidden = make(map[string]struct{})
+	}
+	m.hidden[id] = struct{}{}
+	m.logEvent(eventAction, id, "hid session")
+	if m.focusedSession == id {
+		m.focusedSession = ""
+	}
+	if m.cursorSession == id {
+		m.cursorSession = ""
+	}
+	if m.hoveredSession == id {
+		m.hoveredSession = ""
+	}
+	delete(m.selected, id)
+	delete(m.previews, id)
+}
+
+// unhideSession restores one session to the grid.
+func (m *Model) unhideSession(id string) {
+	if !m.isHidden(id) {
+		return
+	}
+	m.revealSession(id)
+	m.logEvent(eventAction, id, "unhid session")
+	m.updatePreviewDimensions(m.filteredSessionCount())
+}
+
+// openHiddenManager shows the hidden-session manager.
+func (m *Model) openHiddenManager() {
+	m.closePalette()
+	m.closeTimeline()
+	m.hiddenOpen = true
+	m.hiddenIndex = 0
+}
+
+// closeHiddenManager hides the manager.
+func (m *Model) closeHiddenManager() {
+	m.hiddenOpen = false
+}
+
+// handleHiddenKey restores the selected session with enter, space, or u,
+// every session with a, and closes with esc or the show-hidden key.
+func (m *Model) handleHiddenKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if _, ok := msg.(tea.KeyPressMsg); !ok {
+		return m, nil
+	}
+	key := msg.String()
+	if m.keymap().lookup(scopeGlobal, key) == actionShowHidden {
+		m.closeHiddenManager()
+		return m, nil
+	}
+	sessions := m.hiddenSessions()
+	switch key {
+	case "esc", "q":
+		m.closeHiddenManager()
+	case "up", "k":
+		m.hiddenIndex = max(m.hiddenIndex-1, 0)
+	case "down", "j":
+		m.hiddenIndex = min(m.hiddenIndex+1, max(len(sessions)-1, 0))
+	case "enter", "space", "u":
+		if m.hiddenIndex < len(sessions) {
+			m.unhideSession(sessions[m.hiddenIndex].ID)
+			m.hiddenIndex = min(m.hiddenIndex, max(len(sessions)-2, 0))
+		}
+	case "a":
+		m.showHidden()
+		m.hiddenIndex = 0
+	}
+	return m, nil
+}
+
+// renderHiddenManager draws the hidden-session list and the active rules.
+func (m *Model) renderHiddenManager(width int) string {
+	th := m.colors()
+	inner := max(width-6, 20)
+	text := lipgloss.NewStyle().Foreground(lipgloss.Color(th.overlayText))
+	muted := lipgloss.NewStyle().Foreground(lipgloss.Color(th.overlayMuted))
+
+	header := text.Bold(true).Render("hidden sessions") +
+		muted.Render("  enter restores · a restores all · esc closes")
+	sessions := m.hiddenSessions()
+	var lines []string
+	if len(sessions) == 0 {
+		lines = append(lines, muted.Render("nothing hidden"))
+	}
+	start := 0
+	if m.hiddenIndex >= hiddenRows {
+		start = m.hiddenIndex - hiddenRows + 1
+	}
+	for i := start; i < len(sessions) && i < start+hiddenRows; i++ {
+		marker, style := "  ", text
+		if i == m.hiddenIndex {
+			marker, style = "▸ ", text.Bold(true)
+		}
+		line := fmt.Sprintf("%-20s  %s", truncate(sessions[i].Name, 20), m.hideReason(sessions[i].ID))
+		lines = append(lines, marker+style.Render(truncate(line, inner-2)))
+	}
+	if len(m.hideRules) > 0 {
+		rules := make([]string, 0, len(m.hideRules))
+		for _, rule := range m.hideRules {
+			rules = append(rules, rule.String())
+		}
+		lines = append(lines, "", muted.Render(truncate("rules: "+strings.Join(rules, " · "), inner)))
+	}
+	return paletteStyle(th).
+		MarginTop(0).
+		Width(min(width, 90)).
+		Render(lipgloss.JoinVertical(lipgloss.Left, header, strings.Join(lines, "\n")))
+}
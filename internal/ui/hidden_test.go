@@ -0,0 +1,81 @@
+// File hidden_test.go covers hide rules and the hidden-session manager.
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+
+	"github.com/steipete/tmuxwatch/internal/config"
+	"github.com/steipete/tmuxwatch/internal/tmux"
+)
+
+// TestHideReasons explains each hidden session and counts them by cause.
+func TestHideReasons(t *testing.T) {
+	t.Parallel()
+
+	m := testModel(
+		tmux.Session{ID: "$1", Name: "scratch-1"},
+		tmux.Session{ID: "$2", Name: "api"},
+		lifecycleSession("$3", "monitor", tmux.Pane{ID: "%3", CurrentCmd: "htop"}),
+		lifecycleSession("$4", "web", tmux.Pane{ID: "%4", CurrentCmd: "node"}),
+	)
+	m.hidden["$2"] = struct{}{}
+	m.hideRules = newHideRules([]string{"scratch-*"}, []config.HideRule{{Command: "htop"}, {Session: "web", Command: "vim"}})
+	tests := []struct {
+		id   string
+		want string
+	}{
+		{id: "$1", want: "rule: name scratch-*"},
+		{id: "$2", want: "hidden with [x]"},
+		{id: "$3", want: "rule: runs htop"},
+		{id: "$4", want: ""},
+	}
+	for _, tc := range tests {
+		if got := m.hideReason(tc.id); got != tc.want {
+			t.Fatalf("hideReason(%s) = %q, want %q", tc.id, got, tc.want)
+		}
+	}
+	if got := m.hiddenSummary(); got != "3 hidden (1 manual, 2 by rule)" {
+		t.Fatalf("hiddenSummary = %q", got)
+	}
+}
+
+// TestHiddenManagerRestoresOne restores only the selected session, leaves
+// the rule in force for new sessions, and restores the rest with a.
+func TestHiddenManagerRestoresOne(t *testing.T) {
+	t.Parallel()
+
+	m := testModel(
+		tmux.Session{ID: "$1", Name: "scratch-1"},
+		tmux.Session{ID: "$2", Name: "api"},
+		lifecycleSession("$3", "monitor", tmux.Pane{ID: "%3", CurrentCmd: "htop"}),
+		lifecycleSession("$4", "web", tmux.Pane{ID: "%4", CurrentCmd: "node"}),
+	)
+	m.hidden["$2"] = struct{}{}
+	m.hideRules = newHideRules([]string{"scratch-*"}, []config.HideRule{{Command: "htop"}, {Session: "web", Command: "vim"}})
+	m.openHiddenManager()
+	if view := m.renderHiddenManager(100); !strings.Contains(view, "rules: name scratch-* · runs htop · name web running vim") {
+		t.Fatalf("manager should list the rules:\n%s", view)
+	}
+	m.handleHiddenKey(tea.KeyPressMsg{Code: tea.KeyDown})
+	m.handleHiddenKey(tea.KeyPressMsg{Code: tea.KeyEnter})
+	if m.isHidden("$2") || !m.isHidden("$1") || !m.isHidden("$3") {
+		t.Fatal("enter should restore only the selected session")
+	}
+
+	m.sessions = append(m.sessions, lifecycleSession("$5", "top", tmux.Pane{ID: "%5", CurrentCmd: "htop"}))
+	if !m.isHidden("$5") {
+		t.Fatal("a new session matching a rule should be hidden")
+	}
+
+	m.handleHiddenKey(tea.KeyPressMsg{Code: 'a', Text: "a"})
+	if m.hiddenCount() != 0 {
+		t.Fatalf("hiddenCount = %d after restoring all", m.hiddenCount())
+	}
+	m.handleHiddenKey(tea.KeyPressMsg{Code: tea.KeyEscape})
+	if m.hiddenOpen {
+		t.Fatal("esc should close the manager")
+	}
+}
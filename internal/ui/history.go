@@ -0,0 +1,92 @@
+// File history.go feeds the persistent activity history: a sample per live
+// pane every minute plus a record for each pane start and exit.
+package ui
+
+import (
+	"time"
+
+	"github.com/steipete/tmuxwatch/internal/history"
+	"github.com/steipete/tmuxwatch/internal/notify"
+)
+
+// historyInterval is how often output samples are written per pane.
+const historyInterval = time.Minute
+
+// countHistoryLines adds output lines to the pane's pending sample.
+func (m *Model) countHistoryLines(paneID string, lines int) {
+	if m.history == nil || lines <= 0 {
+		return
+	}
+	if m.historyLines == nil {
+		m.historyLines = make(map[string]int)
+	}
+	m.historyLines[paneID] += lines
+}
+
+// recordHistory queues exits and new panes from this snapshot, and a sample
+// for every running pane once historyInterval has passed, for the background
+// writer.
+func (m *Model) recordHistory(now time.Time, exits []paneExit) {
+	if m.history == nil {
+		return
+	}
+	var records []history.Record
+	for _, exit := range exits {
+		ev := exit.event
+		kind := history.KindExit
+		if ev.Kind == notify.KindClosed {
+			kind = history.KindClosed
+		}
+		records = append(records, history.Record{
+			Time:     ev.EndedAt,
+			Kind:     kind,
+			Session:  ev.Session,
+			Window:   ev.Window,
+			Pane:     ev.Pane,
+			Command:  ev.Command,
+			Seconds:  ev.Seconds,
+			ExitCode: ev.ExitCode,
+		})
+	}
+
+	flush := !m.historyStart.IsZero() && now.Sub(m.historyStart) >= historyInterval
+	elapsed := now.Sub(m.historyStart).Seconds()
+	first := m.historyPanes == nil
+	seen := make(map[string]struct{})
+	for _, session := range m.sessions {
+		for _, window := range session.Windows {
+			for _, pane := range window.Panes {
+				seen[pane.ID] = struct{}{}
+				base := history.Record{
+					Time:    now,
+					Session: session.Name,
+					Window:  window.Name,
+					Pane:    pane.ID,
+					Command: pane.CurrentCmd,
+				}
+				if _, ok := m.historyPanes[pane.ID]; !ok && !first && !pane.Dead {
+					start := base
+					start.Kind = history.KindStart
+					records = append(records, start)
+				}
+				if flush && !pane.Dead {
+					sample := base
+					sample.Kind = history.KindSample
+					sample.Seconds = elapsed
+					sample.Lines = m.historyLines[pane.ID]
+					records = append(records, sample)
+				}
+			}
+		}
+	}
+	m.historyPanes = seen
+	if flush || m.historyStart.IsZero() {
+		m.historyStart = now
+		clear(m.historyLines)
+	}
+	if len(records) == 0 {
+		return
+	}
+	store := m.history
+	m.writeInBackground(sinkHistory, func() error { return store.Append(records...) })
+}
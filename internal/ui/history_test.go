@@ -0,0 +1,70 @@
+// File history_test.go covers feeding snapshots into the activity history.
+package ui
+
+import (
+	"testing"
+	"time"
+
+	"github.com/steipete/tmuxwatch/internal/history"
+	"github.com/steipete/tmuxwatch/internal/notify"
+	"github.com/steipete/tmuxwatch/internal/tmux"
+)
+
+// TestRecordHistorySamplesAndExits writes a start for panes that appear
+// after the first snapshot, a sample per running pane each interval, and
+// exits with their codes.
+func TestRecordHistorySamplesAndExits(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	st, err := history.Open(dir)
+	if err != nil {
+		t.Fatalf("Open returned error: %v", err)
+	}
+	start := time.Date(2026, 10, 18, 9, 0, 0, 0, time.Local)
+	m := &Model{history: st, sessions: []tmux.Session{
+		lifecycleSession("$1", "api", tmux.Pane{ID: "%1", CurrentCmd: "node"}),
+	}}
+	m.recordHistory(start, nil)
+
+	m.sessions = []tmux.Session{
+		lifecycleSession("$1", "api", tmux.Pane{ID: "%1", CurrentCmd: "node"}),
+		lifecycleSession("$2", "ci", tmux.Pane{ID: "%2", CurrentCmd: "go"}),
+	}
+	m.countHistoryLines("%1", 7)
+	m.recordHistory(start.Add(30*time.Second), nil)
+	m.countHistoryLines("%1", 3)
+	exit := paneExit{sessionID: "$2", event: notify.Event{
+		Kind: notify.KindExit, Session: "ci", Pane: "%2", Command: "go", ExitCode: 1, EndedAt: start.Add(time.Minute), Seconds: 30,
+	}}
+	m.sessions = m.sessions[:1]
+	m.recordHistory(start.Add(time.Minute), []paneExit{exit})
+	m.Flush()
+	if err := st.Close(); err != nil {
+		t.Fatalf("Close returned error: %v", err)
+	}
+
+	records, err := history.Load(dir, start)
+	if err != nil {
+		t.Fatalf("Load returned error: %v", err)
+	}
+	var kinds []string
+	for _, r := range records {
+		kinds = append(kinds, r.Kind)
+	}
+	want := []string{history.KindStart, history.KindExit, history.KindSample}
+	if len(kinds) != len(want) {
+		t.Fatalf("kinds = %v, want %v", kinds, want)
+	}
+	for i := range want {
+		if kinds[i] != want[i] {
+			t.Fatalf("kinds = %v, want %v", kinds, want)
+		}
+	}
+	if r := records[1]; r.ExitCode != 1 || r.Command != "go" {
+		t.Fatalf("exit = %+v", r)
+	}
+	if r := records[2]; r.Pane != "%1" || r.Lines != 10 || r.Seconds != 60 {
+		t.Fatalf("sample = %+v, want %%1 with 10 lines over 60s", r)
+	}
+}
@@ -0,0 +1,66 @@
+// File jump.go moves from a card to the session in tmux itself: inside tmux
+// the calling client switches to it, outside tmux tmuxwatch exits so the
+// caller can attach.
+package ui
+
+import (
+	tea "charm.land/bubbletea/v2"
+)
+
+// jumpSession returns the session a jump targets: the focused session, or
+// the overview cursor.
+func (m *Model) jumpSession() string {
+	if m.focusedSession != "" {
+		return m.focusedSession
+	}
+	return m.cursorSession
+}
+
+// jumpToTmux makes the session's active window and pane current in tmux and
+// brings a client to it. Inside tmux the client running tmuxwatch switches
+// there; outside tmux tmuxwatch quits and records the session to attach to.
+func (m *Model) jumpToTmux(sessionID string) tea.Cmd {
+	session, ok := m.sessionByID(sessionID)
+	if !ok {
+		return nil
+	}
+	var windowID, paneID string
+	if window, ok := activeWindow(session); ok {
+		windowID = window.ID
+		if pane, ok := activePane(window); ok {
+			paneID = pane.ID
+		}
+	}
+	m.logEvent(eventAction, sessionID, "jumped to session in tmux")
+	if m.insideTmux {
+		return tea.Batch(
+			switchClientCmd(m.client, m.tmuxPane, session.ID, windowID, paneID),
+			showStatusMessage("Switched to "+session.Name),
+		)
+	}
+	m.jumpTarget = session.Name
+	return tea.Sequence(selectPaneCmd(m.client, windowID, paneID), tea.Quit)
+}
+
+// AttachTarget names the session to attach to after tmuxwatch exits, set
+// when the user jumped to a session from outside tmux.
+func (m *Model) AttachTarget() string {
+	return m.jumpTarget
+}
+
+// jumpPaletteCommands offers a jump to the focused or cursor session.
+func (m *Model) jumpPaletteCommands() []commandItem {
+	target := m.jumpSession()
+	session, ok := m.sessionByID(target)
+	label := "Jump to session in tmux"
+	if ok {
+		label = "Jump to " + session.Name + " in tmux"
+	}
+	return []commandItem{{
+		label:   label,
+		enabled: ok,
+		run: func(m *Model) tea.Cmd {
+			return m.jumpToTmux(target)
+		},
+	}}
+}
@@ -0,0 +1,51 @@
+// File jump_test.go covers jumping from a card into tmux.
+package ui
+
+import (
+	"testing"
+
+	"github.com/steipete/tmuxwatch/internal/tmux"
+)
+
+// TestJumpToTmux switches the client inside tmux and, outside tmux, quits
+// with the session recorded for attaching.
+func TestJumpToTmux(t *testing.T) {
+	t.Parallel()
+
+	sessions := []tmux.Session{lifecycleSession("$1", "build", tmux.Pane{ID: "%1", Active: true})}
+	tests := []struct {
+		name       string
+		insideTmux bool
+		wantTarget string
+	}{
+		{name: "inside tmux", insideTmux: true},
+		{name: "outside tmux", wantTarget: "build"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			m := &Model{sessions: sessions, insideTmux: tc.insideTmux, cursorSession: "$1"}
+			if cmd := m.jumpToTmux(m.jumpSession()); cmd == nil {
+				t.Fatal("expected a command")
+			}
+			if got := m.AttachTarget(); got != tc.wantTarget {
+				t.Fatalf("AttachTarget = %q, want %q", got, tc.wantTarget)
+			}
+		})
+	}
+}
+
+// TestJumpPaletteCommand names the target session and is disabled without
+// one.
+func TestJumpPaletteCommand(t *testing.T) {
+	t.Parallel()
+
+	m := &Model{sessions: []tmux.Session{{ID: "$1", Name: "build"}}}
+	if item := m.jumpPaletteCommands()[0]; item.enabled {
+		t.Fatal("jump should be disabled without a focused or cursor session")
+	}
+	m.focusedSession = "$1"
+	if item := m.jumpPaletteCommands()[0]; !item.enabled || item.label != "Jump to build in tmux" {
+		t.Fatalf("item = %q enabled=%v", item.label, item.enabled)
+	}
+}
@@ -0,0 +1,285 @@
+// File keymap.go maps key strings to named tmuxwatch actions and validates
+// user overrides loaded from the config file.
+package ui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/steipete/tmuxwatch/internal/config"
+)
+
+// action names a tmuxwatch command that can be bound to one or more keys.
+type action string
+
+const (
+	actionNone           action = ""
+	actionPrevTab        action = "prev-tab"
+	actionNextTab        action = "next-tab"
+	actionCursorLeft     action = "cursor-left"
+	actionCursorRight    action = "cursor-right"
+	actionCursorUp       action = "cursor-up"
+	actionCursorDown     action = "cursor-down"
+	actionFocus          action = "focus"
+	actionSearch         action = "search"
+	actionBack           action = "back"
+	actionPalette        action = "palette"
+	actionShowHidden     action = "show-hidden"
+	actionKillAllStale   action = "kill-all-stale"
+	actionKillStale      action = "kill-stale"
+	actionQuit           action = "quit"
+	actionScrollUp       action = "scroll-up"
+	actionScrollDown     action = "scroll-down"
+	actionPageUp         action = "page-up"
+	actionPageDown       action = "page-down"
+	actionHalfPageUp     action = "half-page-up"
+	actionHalfPageDown   action = "half-page-down"
+	actionScrollTop      action = "scroll-top"
+	actionScrollBottom   action = "scroll-bottom"
+	actionToggleDetail   action = "toggle-detail"
+	actionCollapse       action = "collapse"
+	actionExpandAll      action = "expand-all"
+	actionCycleSort      action = "cycle-sort"
+	actionCycleGroup     action = "cycle-group"
+	actionToggleGroup    action = "toggle-group"
+	actionTogglePin      action = "toggle-pin"
+	actionToggleWatch    action = "toggle-watch"
+	actionMonSilence     action = "toggle-silence-monitor"
+	actionMonActivity    action = "toggle-activity-monitor"
+	actionMoveLeft       action = "move-card-left"
+	actionMoveRight      action = "move-card-right"
+	actionMoveUp         action = "move-card-up"
+	actionMoveDown       action = "move-card-down"
+	actionAckAlerts      action = "ack-alerts"
+	actionTimeline       action = "toggle-timeline"
+	actionArchive        action = "browse-archive"
+	actionJump           action = "jump-tmux"
+	actionInsert         action = "insert-mode"
+	actionPrevPane       action = "prev-pane"
+	actionNextPane       action = "next-pane"
+	actionSelect         action = "toggle-select"
+	actionSelectAll      action = "select-matching"
+	actionBroadcast      action = "broadcast"
+	actionCompose        action = "compose"
+	actionRecord         action = "toggle-record"
+	actionReplay         action = "replay"
+	actionCopyScreen     action = "copy-screen"
+	actionCopyScrollback action = "copy-scrollback"
+	actionCardMenu       action = "card-menu"
+	actionCardLeft       action = "card-left"
+	actionCardRight      action = "card-right"
+	actionCardUp         action = "card-up"
+	actionCardDown       action = "card-down"
+)
+
+// keyScope describes when a binding is consulted. Global bindings apply
+// everywhere outside insert mode, overview bindings only while no pane is
+// focused, and pane bindings only while a pane is focused in navigation mode.
+type keyScope int
+
+const (
+	scopeGlobal keyScope = iota
+	scopeOverview
+	scopePane
+)
+
+func (s keyScope) String() string {
+	switch s {
+	case scopeOverview:
+		return "overview"
+	case scopePane:
+		return "pane"
+	default:
+		return "global"
+	}
+}
+
+// actionSpec declares an action, its scope, and its default keys.
+type actionSpec struct {
+	name     action
+	scope    keyScope
+	defaults []string
+}
+
+// actionSpecs lists every bindable action in display order.
+var actionSpecs = []actionSpec{
+	{actionPrevTab, scopeGlobal, []string{"shift+left", "["}},
+	{actionNextTab, scopeGlobal, []string{"shift+right", "]"}},
+	{actionFocus, scopeGlobal, []string{"enter"}},
+	{actionSearch, scopeGlobal, []string{"/", "ctrl+f"}},
+	{actionBack, scopeGlobal, []string{"esc"}},
+	{actionPalette, scopeGlobal, []string{"ctrl+p"}},
+	{actionShowHidden, scopeGlobal, []string{"H"}},
+	{actionKillAllStale, scopeGlobal, []string{"ctrl+x"}},
+	{actionKillStale, scopeGlobal, []string{"X"}},
+	{actionAckAlerts, scopeGlobal, []string{"a"}},
+	{actionTimeline, scopeGlobal, []string{"T"}},
+	{actionArchive, scopeGlobal, []string{"A"}},
+	{actionJump, scopeGlobal, []string{"J"}},
+	{actionBroadcast, scopeGlobal, []string{"B"}},
+	{actionCompose, scopeGlobal, []string{":"}},
+	{actionRecord, scopeGlobal, []string{"R"}},
+	{actionReplay, scopeGlobal, []string{"P"}},
+	{actionCopyScreen, scopeGlobal, []string{"y"}},
+	{actionCopyScrollback, scopeGlobal, []string{"Y"}},
+	{actionCardMenu, scopeGlobal, []string{"e"}},
+	{actionQuit, scopeGlobal, []string{"q"}},
+	{actionCursorLeft, scopeOverview, []string{"left", "h"}},
+	{actionCursorRight, scopeOverview, []string{"right", "l"}},
+	{actionCursorUp, scopeOverview, []string{"up", "k"}},
+	{actionCursorDown, scopeOverview, []string{"down", "j"}},
+	{actionCycleSort, scopeOverview, []string{"s"}},
+	{actionCycleGroup, scopeOverview, []string{"S"}},
+	{actionToggleGroup, scopeOverview, []string{"c"}},
+	{actionTogglePin, scopeOverview, []string{"p"}},
+	{actionToggleWatch, scopeOverview, []string{"w"}},
+	{actionMonSilence, scopeOverview, []string{"m"}},
+	{actionMonActivity, scopeOverview, []string{"M"}},
+	{actionMoveLeft, scopeOverview, []string{"alt+left"}},
+	{actionMoveRight, scopeOverview, []string{"alt+right"}},
+	{actionMoveUp, scopeOverview, []string{"alt+up"}},
+	{actionMoveDown, scopeOverview, []string{"alt+down"}},
+	{actionSelect, scopeOverview, []string{"space"}},
+	{actionSelectAll, scopeOverview, []string{"ctrl+a"}},
+	{actionScrollUp, scopePane, []string{"up", "k"}},
+	{actionScrollDown, scopePane, []string{"down", "j"}},
+	{actionPageUp, scopePane, []string{"pgup"}},
+	{actionPageDown, scopePane, []string{"pgdown"}},
+	{actionHalfPageUp, scopePane, []string{"ctrl+u"}},
+	{actionHalfPageDown, scopePane, []string{"ctrl+d"}},
+	{actionScrollTop, scopePane, []string{"g"}},
+	{actionScrollBottom, scopePane, []string{"G"}},
+	{actionToggleDetail, scopePane, []string{"f"}},
+	{actionCollapse, scopePane, []string{"z"}},
+	{actionExpandAll, scopePane, []string{"Z"}},
+	{actionInsert, scopePane, []string{"i"}},
+	{actionPrevPane, scopePane, []string{"h"}},
+	{actionNextPane, scopePane, []string{"l"}},
+	{actionCardLeft, scopePane, []string{"alt+h"}},
+	{actionCardRight, scopePane, []string{"alt+l"}},
+	{actionCardUp, scopePane, []string{"alt+k"}},
+	{actionCardDown, scopePane, []string{"alt+j"}},
+}
+
+// keymap resolves key strings to actions for each scope.
+type keymap struct {
+	leader   string
+	bindings map[keyScope]map[string]action
+}
+
+// defaultKeymap returns the built-in bindings without a leader key.
+func defaultKeymap() *keymap {
+	km, err := newKeymap(config.Keymap{})
+	if err != nil {
+		panic(fmt.Sprintf("default keymap invalid: %v", err))
+	}
+	return km
+}
+
+// newKeymap applies user overrides on top of the defaults and rejects unknown
+// actions, blank keys, and keys that would shadow each other.
+func newKeymap(cfg config.Keymap) (*keymap, error) {
+	specs := make(map[action]actionSpec, len(actionSpecs))
+	for _, spec := range actionSpecs {
+		specs[spec.name] = spec
+	}
+	keys := make(map[action][]string, len(actionSpecs))
+	for _, spec := range actionSpecs {
+		keys[spec.name] = append([]string(nil), spec.defaults...)
+	}
+
+	names := make([]string, 0, len(cfg.Bindings))
+	for name := range cfg.Bindings {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		act := action(name)
+		if _, ok := specs[act]; !ok {
+			return nil, &config.FieldError{Field: bindingField(name), Msg: fmt.Sprintf("unknown action %q", name)}
+		}
+		override := make([]string, 0, len(cfg.Bindings[name]))
+		for _, key := range cfg.Bindings[name] {
+			key = strings.TrimSpace(key)
+			if key == "" {
+				return nil, &config.FieldError{Field: bindingField(name), Msg: fmt.Sprintf("action %q has an empty key", name)}
+			}
+			override = append(override, key)
+		}
+		keys[act] = override
+	}
+
+	leader := strings.TrimSpace(cfg.Leader)
+	km := &keymap{
+		leader: leader,
+		bindings: map[keyScope]map[string]action{
+			scopeGlobal:   {},
+			scopeOverview: {},
+			scopePane:     {},
+		},
+	}
+	for _, spec := range actionSpecs {
+		for _, key := range keys[spec.name] {
+			if key == leader && leader != "" {
+				return nil, &config.FieldError{Field: "keymap.leader", Msg: fmt.Sprintf("%q is the leader key and cannot be bound to %s", key, spec.name)}
+			}
+			if other, scope, ok := km.conflict(spec.scope, key); ok && other != spec.name {
+				field := "keymap"
+				if _, ok := cfg.Bindings[string(spec.name)]; ok {
+					field = bindingField(string(spec.name))
+				} else if _, ok := cfg.Bindings[string(other)]; ok {
+					field = bindingField(string(other))
+				}
+				return nil, &config.FieldError{Field: field, Msg: fmt.Sprintf("%q is bound to both %s (%s) and %s (%s)", key, other, scope, spec.name, spec.scope)}
+			}
+			km.bindings[spec.scope][key] = spec.name
+		}
+	}
+	return km, nil
+}
+
+// bindingField is the config key holding the bindings of action name.
+func bindingField(name string) string {
+	return "keymap.bindings." + name
+}
+
+// ValidateKeymap checks keymap overrides without building a Model. It is a
+// config.Check, so errors surface with the line of the offending key before
+// tmux is contacted.
+func ValidateKeymap(cfg config.Config) error {
+	_, err := newKeymap(cfg.Keymap)
+	return err
+}
+
+// conflict reports an existing binding that would clash with binding key in
+// scope. Overview and pane bindings never overlap at runtime, so they may
+// share keys; global bindings shadow both.
+func (k *keymap) conflict(scope keyScope, key string) (action, keyScope, bool) {
+	check := []keyScope{scope}
+	switch scope {
+	case scopeGlobal:
+		check = []keyScope{scopeGlobal, scopeOverview, scopePane}
+	case scopeOverview, scopePane:
+		check = append(check, scopeGlobal)
+	}
+	for _, s := range check {
+		if other, ok := k.bindings[s][key]; ok {
+			return other, s, true
+		}
+	}
+	return actionNone, scopeGlobal, false
+}
+
+// lookup resolves key within scope.
+func (k *keymap) lookup(scope keyScope, key string) action {
+	return k.bindings[scope][key]
+}
+
+// keymap returns the model's keymap, falling back to defaults.
+func (m *Model) keymap() *keymap {
+	if m.keys == nil {
+		m.keys = defaultKeymap()
+	}
+	return m.keys
+}
@@ -0,0 +1,158 @@
+// File keymap_test.go validates keymap overrides, conflicts, and the leader.
+package ui
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+
+	"github.com/steipete/tmuxwatch/internal/config"
+	"github.com/steipete/tmuxwatch/internal/tmux"
+)
+
+// TestDefaultKeymapBindings resolves the built-in keys per scope.
+func TestDefaultKeymapBindings(t *testing.T) {
+	t.Parallel()
+
+	km := defaultKeymap()
+	tests := []struct {
+		scope keyScope
+		key   string
+		want  action
+	}{
+		{scopeGlobal, "q", actionQuit},
+		{scopeGlobal, "ctrl+f", actionSearch},
+		{scopeOverview, "up", actionCursorUp},
+		{scopePane, "up", actionScrollUp},
+		{scopePane, "z", actionCollapse},
+		{scopePane, "f", actionToggleDetail},
+		{scopeGlobal, "enter", actionFocus},
+		{scopeGlobal, "z", actionNone},
+	}
+	for _, tt := range tests {
+		if got := km.lookup(tt.scope, tt.key); got != tt.want {
+			t.Fatalf("lookup(%s, %q) = %q, want %q", tt.scope, tt.key, got, tt.want)
+		}
+	}
+}
+
+// TestNewKeymapOverrides replaces and unbinds default keys.
+func TestNewKeymapOverrides(t *testing.T) {
+	t.Parallel()
+
+	km, err := newKeymap(config.Keymap{Bindings: map[string][]string{
+		"quit":     {"Q"},
+		"collapse": {},
+	}})
+	if err != nil {
+		t.Fatalf("newKeymap returned error: %v", err)
+	}
+	if got := km.lookup(scopeGlobal, "Q"); got != actionQuit {
+		t.Fatalf("Q = %q, want quit", got)
+	}
+	if got := km.lookup(scopeGlobal, "q"); got != actionNone {
+		t.Fatalf("q should be unbound, got %q", got)
+	}
+	if got := km.lookup(scopePane, "z"); got != actionNone {
+		t.Fatalf("z should be unbound, got %q", got)
+	}
+}
+
+// TestNewKeymapErrors rejects unknown actions, blank keys, and conflicts.
+func TestNewKeymapErrors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		cfg  config.Keymap
+		want string
+	}{
+		{name: "unknown action", cfg: config.Keymap{Bindings: map[string][]string{"fly": {"f"}}}, want: "unknown action"},
+		{name: "blank key", cfg: config.Keymap{Bindings: map[string][]string{"quit": {" "}}}, want: "empty key"},
+		{name: "global conflict", cfg: config.Keymap{Bindings: map[string][]string{"quit": {"H"}}}, want: "bound to both"},
+		{name: "global shadows pane", cfg: config.Keymap{Bindings: map[string][]string{"palette": {"g"}}}, want: "bound to both"},
+		{name: "leader bound", cfg: config.Keymap{Leader: "q"}, want: "leader key"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			_, err := newKeymap(tt.cfg)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Fatalf("newKeymap error = %v, want %q", err, tt.want)
+			}
+		})
+	}
+}
+
+// TestValidateKeymapLocatesKey reports keymap errors at the offending line
+// when run as a config check.
+func TestValidateKeymapLocatesKey(t *testing.T) {
+	t.Parallel()
+
+	doc := "{\n  \"keymap\": {\n    \"bindings\": {\n      \"fly\": [\"f\"]\n    }\n  }\n}\n"
+	_, err := config.Parse([]byte(doc), ValidateKeymap)
+	var pe *config.ParseError
+	if !errors.As(err, &pe) {
+		t.Fatalf("Parse error = %v, want *config.ParseError", err)
+	}
+	if pe.Line != 4 || !strings.Contains(pe.Msg, `unknown action "fly"`) {
+		t.Fatalf("error = %v, want line 4 unknown action", pe)
+	}
+}
+
+// TestLeaderKeyRoutesCommands forwards plain keys in insert mode and runs
+// leader chords without leaving it.
+func TestLeaderKeyRoutesCommands(t *testing.T) {
+	t.Parallel()
+
+	km, err := newKeymap(config.Keymap{Leader: "ctrl+b"})
+	if err != nil {
+		t.Fatalf("newKeymap returned error: %v", err)
+	}
+	vp := viewportFor(innerDimension{width: 40, height: 6})
+	m := &Model{
+		keys:           km,
+		focusedSession: "$1",
+		insertSession:  "$1",
+		previews:       map[string]*sessionPreview{"$1": {viewport: &vp, paneID: "%1"}},
+		sessions: []tmux.Session{{ID: "$1", Windows: []tmux.Window{{
+			Active: true,
+			Panes:  []tmux.Pane{{ID: "%1", Active: true}},
+		}}}},
+		collapsed: make(map[string]struct{}),
+	}
+
+	if handled, _ := m.handleLeaderKey(tea.KeyPressMsg{Code: 'z', Text: "z"}); handled {
+		t.Fatal("plain keys should fall through to insert mode")
+	}
+	handled, cmd := m.handleInsertKey(tea.KeyPressMsg{Code: 'z', Text: "z"})
+	if !handled || cmd == nil {
+		t.Fatal("expected plain key to be forwarded to the pane")
+	}
+	if m.isCollapsed("$1") {
+		t.Fatal("plain z must not collapse while a leader is configured")
+	}
+
+	handled, _ = m.handleLeaderKey(tea.KeyPressMsg{Code: 'b', Mod: tea.ModCtrl})
+	if !handled || !m.leaderArmed {
+		t.Fatal("expected leader to arm")
+	}
+	handled, _ = m.handleLeaderKey(tea.KeyPressMsg{Code: 'z', Text: "z"})
+	if !handled || m.leaderArmed {
+		t.Fatal("expected leader chord to be consumed")
+	}
+	if !m.isCollapsed("$1") || m.inputMode() != modeInsert {
+		t.Fatal("leader z should collapse the focused card and stay in insert mode")
+	}
+
+	m.leaderArmed = true
+	_, cmd = m.handleLeaderKey(tea.KeyPressMsg{Code: 'q', Text: "q"})
+	if cmd == nil {
+		t.Fatal("expected leader q to quit")
+	}
+	if _, ok := cmd().(tea.QuitMsg); !ok {
+		t.Fatal("leader q should return tea.Quit")
+	}
+}
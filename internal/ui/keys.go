@@ -0,0 +1,124 @@
+// File keys.go translates Bubble Tea key presses into tmux send-keys input so
+// a focused pane receives arrows, function keys, and ctrl and alt chords as
+// if typed into tmux directly.
+package ui
+
+import (
+	"fmt"
+	"unicode"
+
+	tea "charm.land/bubbletea/v2"
+)
+
+// tmuxKey is what one key press sends to a pane: a tmux key name such as
+// "C-a" or "M-Up", or text typed verbatim with send-keys -l.
+type tmuxKey struct {
+	name    string
+	literal bool
+}
+
+// tmuxKeyNames maps Bubble Tea special keys to tmux key names.
+var tmuxKeyNames = map[rune]string{
+	tea.KeyEnter:      "Enter",
+	tea.KeyTab:        "Tab",
+	tea.KeySpace:      "Space",
+	tea.KeyBackspace:  "BSpace",
+	tea.KeyEscape:     "Escape",
+	tea.KeyUp:         "Up",
+	tea.KeyDown:       "Down",
+	tea.KeyLeft:       "Left",
+	tea.KeyRight:      "Right",
+	tea.KeyHome:       "Home",
+	tea.KeyEnd:        "End",
+	tea.KeyPgUp:       "PPage",
+	tea.KeyPgDown:     "NPage",
+	tea.KeyInsert:     "IC",
+	tea.KeyDelete:     "DC",
+	tea.KeyKpEnter:    "KPEnter",
+	tea.KeyKpEqual:    "KP=",
+	tea.KeyKpMultiply: "KP*",
+	tea.KeyKpPlus:     "KP+",
+	tea.KeyKpMinus:    "KP-",
+	tea.KeyKpDecimal:  "KP.",
+	tea.KeyKpDivide:   "KP/",
+	tea.KeyKp0:        "KP0",
+	tea.KeyKp1:        "KP1",
+	tea.KeyKp2:        "KP2",
+	tea.KeyKp3:        "KP3",
+	tea.KeyKp4:        "KP4",
+	tea.KeyKp5:        "KP5",
+	tea.KeyKp6:        "KP6",
+	tea.KeyKp7:        "KP7",
+	tea.KeyKp8:        "KP8",
+	tea.KeyKp9:        "KP9",
+	tea.KeyKpUp:       "Up",
+	tea.KeyKpDown:     "Down",
+	tea.KeyKpLeft:     "Left",
+	tea.KeyKpRight:    "Right",
+	tea.KeyKpHome:     "Home",
+	tea.KeyKpEnd:      "End",
+	tea.KeyKpPgUp:     "PPage",
+	tea.KeyKpPgDown:   "NPage",
+	tea.KeyKpInsert:   "IC",
+	tea.KeyKpDelete:   "DC",
+}
+
+// tmuxKeyName returns the tmux name of a special key, including F1–F20.
+func tmuxKeyName(code rune) (string, bool) {
+	if name, ok := tmuxKeyNames[code]; ok {
+		return name, true
+	}
+	if code >= tea.KeyF1 && code <= tea.KeyF20 {
+		return fmt.Sprintf("F%d", code-tea.KeyF1+1), true
+	}
+	return "", false
+}
+
+// tmuxKeyFrom converts a key press into pane input. Plain text is sent
+// literally; special keys and chords use tmux key names with C-, M-, and S-
+// modifier prefixes.
+func tmuxKeyFrom(msg tea.KeyMsg) (tmuxKey, bool) {
+	press, ok := msg.(tea.KeyPressMsg)
+	if !ok {
+		return tmuxKey{}, false
+	}
+	key := press.Key()
+	ctrl := key.Mod&tea.ModCtrl != 0
+	alt := key.Mod&(tea.ModAlt|tea.ModMeta) != 0
+	shift := key.Mod&tea.ModShift != 0
+
+	name, special := tmuxKeyName(key.Code)
+	switch {
+	case special && name == "Tab" && shift && !ctrl && !alt:
+		return tmuxKey{name: "BTab"}, true
+	case special:
+		if name == "Space" && !ctrl && !alt && !shift {
+			return tmuxKey{name: " ", literal: true}, true
+		}
+		if shift {
+			name = "S-" + name
+		}
+	case ctrl || alt:
+		base := key.Code
+		if shift && key.ShiftedCode != 0 {
+			base = key.ShiftedCode
+		}
+		if !unicode.IsPrint(base) {
+			return tmuxKey{}, false
+		}
+		name = string(base)
+	case key.Text != "":
+		return tmuxKey{name: key.Text, literal: true}, true
+	case unicode.IsPrint(key.Code):
+		return tmuxKey{name: string(key.Code), literal: true}, true
+	default:
+		return tmuxKey{}, false
+	}
+	if alt {
+		name = "M-" + name
+	}
+	if ctrl {
+		name = "C-" + name
+	}
+	return tmuxKey{name: name}, true
+}
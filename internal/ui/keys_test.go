@@ -0,0 +1,101 @@
+// File keys_test.go covers translating key presses and pastes for panes.
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"charm.land/bubbles/v2/textinput"
+	tea "charm.land/bubbletea/v2"
+
+	"github.com/steipete/tmuxwatch/internal/tmux"
+)
+
+// TestTmuxKeyFrom maps Bubble Tea key presses to tmux key names and
+// literal text.
+func TestTmuxKeyFrom(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		msg  tea.KeyMsg
+		want tmuxKey
+		ok   bool
+	}{
+		{name: "enter", msg: tea.KeyPressMsg{Code: tea.KeyEnter}, want: tmuxKey{name: "Enter"}, ok: true},
+		{name: "space", msg: tea.KeyPressMsg{Code: tea.KeySpace, Text: " "}, want: tmuxKey{name: " ", literal: true}, ok: true},
+		{name: "ctrl space", msg: tea.KeyPressMsg{Code: tea.KeySpace, Mod: tea.ModCtrl}, want: tmuxKey{name: "C-Space"}, ok: true},
+		{name: "text", msg: tea.KeyPressMsg{Code: 'a', Text: "a"}, want: tmuxKey{name: "a", literal: true}, ok: true},
+		{name: "shifted text", msg: tea.KeyPressMsg{Code: 'a', ShiftedCode: 'A', Text: "A", Mod: tea.ModShift}, want: tmuxKey{name: "A", literal: true}, ok: true},
+		{name: "unicode", msg: tea.KeyPressMsg{Code: 'é', Text: "é"}, want: tmuxKey{name: "é", literal: true}, ok: true},
+		{name: "key name as text", msg: tea.KeyPressMsg{Code: ';', Text: ";"}, want: tmuxKey{name: ";", literal: true}, ok: true},
+		{name: "ctrl letter", msg: tea.KeyPressMsg{Code: 'r', Mod: tea.ModCtrl}, want: tmuxKey{name: "C-r"}, ok: true},
+		{name: "alt letter", msg: tea.KeyPressMsg{Code: 'b', Mod: tea.ModAlt}, want: tmuxKey{name: "M-b"}, ok: true},
+		{name: "alt shifted letter", msg: tea.KeyPressMsg{Code: 'b', ShiftedCode: 'B', Mod: tea.ModAlt | tea.ModShift}, want: tmuxKey{name: "M-B"}, ok: true},
+		{name: "ctrl alt letter", msg: tea.KeyPressMsg{Code: 'x', Mod: tea.ModCtrl | tea.ModAlt}, want: tmuxKey{name: "C-M-x"}, ok: true},
+		{name: "arrow", msg: tea.KeyPressMsg{Code: tea.KeyUp}, want: tmuxKey{name: "Up"}, ok: true},
+		{name: "ctrl arrow", msg: tea.KeyPressMsg{Code: tea.KeyLeft, Mod: tea.ModCtrl}, want: tmuxKey{name: "C-Left"}, ok: true},
+		{name: "shift alt arrow", msg: tea.KeyPressMsg{Code: tea.KeyRight, Mod: tea.ModShift | tea.ModAlt}, want: tmuxKey{name: "M-S-Right"}, ok: true},
+		{name: "home", msg: tea.KeyPressMsg{Code: tea.KeyHome}, want: tmuxKey{name: "Home"}, ok: true},
+		{name: "end", msg: tea.KeyPressMsg{Code: tea.KeyEnd}, want: tmuxKey{name: "End"}, ok: true},
+		{name: "page up", msg: tea.KeyPressMsg{Code: tea.KeyPgUp}, want: tmuxKey{name: "PPage"}, ok: true},
+		{name: "page down", msg: tea.KeyPressMsg{Code: tea.KeyPgDown}, want: tmuxKey{name: "NPage"}, ok: true},
+		{name: "insert", msg: tea.KeyPressMsg{Code: tea.KeyInsert}, want: tmuxKey{name: "IC"}, ok: true},
+		{name: "delete", msg: tea.KeyPressMsg{Code: tea.KeyDelete}, want: tmuxKey{name: "DC"}, ok: true},
+		{name: "backspace", msg: tea.KeyPressMsg{Code: tea.KeyBackspace}, want: tmuxKey{name: "BSpace"}, ok: true},
+		{name: "alt backspace", msg: tea.KeyPressMsg{Code: tea.KeyBackspace, Mod: tea.ModAlt}, want: tmuxKey{name: "M-BSpace"}, ok: true},
+		{name: "escape", msg: tea.KeyPressMsg{Code: tea.KeyEscape}, want: tmuxKey{name: "Escape"}, ok: true},
+		{name: "shift tab", msg: tea.KeyPressMsg{Code: tea.KeyTab, Mod: tea.ModShift}, want: tmuxKey{name: "BTab"}, ok: true},
+		{name: "f1", msg: tea.KeyPressMsg{Code: tea.KeyF1}, want: tmuxKey{name: "F1"}, ok: true},
+		{name: "shift f12", msg: tea.KeyPressMsg{Code: tea.KeyF12, Mod: tea.ModShift}, want: tmuxKey{name: "S-F12"}, ok: true},
+		{name: "keypad enter", msg: tea.KeyPressMsg{Code: tea.KeyKpEnter}, want: tmuxKey{name: "KPEnter"}, ok: true},
+		{name: "release ignored", msg: tea.KeyReleaseMsg{Code: 'a', Text: "a"}},
+		{name: "unknown key", msg: tea.KeyPressMsg{Code: tea.KeyCapsLock}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, ok := tmuxKeyFrom(tt.msg)
+			if ok != tt.ok || got != tt.want {
+				t.Fatalf("tmuxKeyFrom = %+v, %v; want %+v, %v", got, ok, tt.want, tt.ok)
+			}
+		})
+	}
+}
+
+// TestHandlePaste pastes into the focused pane, types into an open search,
+// and ignores pastes while an overlay is open.
+func TestHandlePaste(t *testing.T) {
+	t.Parallel()
+
+	newModel := func() *Model {
+		return &Model{
+			sessions:       []tmux.Session{{ID: "$1", Name: "dev"}},
+			previews:       map[string]*sessionPreview{"$1": {paneID: "%1"}},
+			focusedSession: "$1",
+		}
+	}
+
+	m := newModel()
+	if _, cmd := m.handlePaste(tea.PasteMsg{Content: "make test\n"}); cmd == nil {
+		t.Fatal("paste should reach the focused pane")
+	}
+	if last := m.timeline[len(m.timeline)-1]; last.text != "pasted 10 bytes" {
+		t.Fatalf("timeline = %q", last.text)
+	}
+
+	m = newModel()
+	m.timelineOpen = true
+	if _, cmd := m.handlePaste(tea.PasteMsg{Content: "x"}); cmd != nil {
+		t.Fatal("overlays should swallow pastes")
+	}
+
+	m = newModel()
+	m.searching = true
+	m.searchInput = textinput.New()
+	m.searchInput.Focus()
+	m.handlePaste(tea.PasteMsg{Content: " api "})
+	if !strings.Contains(m.searchInput.Value(), "api") || m.searchQuery != "api" {
+		t.Fatalf("search = %q / %q", m.searchInput.Value(), m.searchQuery)
+	}
+}
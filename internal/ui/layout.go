@@ -3,6 +3,8 @@
 package ui
 
 import (
+	"slices"
+
 	"charm.land/bubbles/v2/viewport"
 	tea "charm.land/bubbletea/v2"
 	zone "github.com/steipete/tmuxwatch/internal/zone"
@@ -36,7 +38,7 @@ func viewportFor(dim innerDimension) viewport.Model {
 // updatePreviewDimensions recalculates viewport sizes based on terminal
 // geometry and how many sessions are visible.
 func (m *Model) updatePreviewDimensions(count int) {
-	if count <= 0 || m.width <= 0 || m.height <= 0 {
+	if (count <= 0 && !m.replayVisible()) || m.width <= 0 || m.height <= 0 {
 		return
 	}
 	offset := m.previewOffset
@@ -63,6 +65,7 @@ func (m *Model) updatePreviewDimensions(count int) {
 	selectedCols := 1
 	selectedHeight := 0
 	selectedWidth := max(1, m.width-columnOverhead)
+	groupSizes := m.groupSizes()
 
 	for cols := maxCols; cols >= 1; cols-- {
 		columnWidth := m.width / cols
@@ -73,11 +76,15 @@ func (m *Model) updatePreviewDimensions(count int) {
 		if innerWidth < 1 {
 			innerWidth = 1
 		}
-		rows := (count + cols - 1) / cols
+		rows, headerLines := gridRows(count, cols, groupSizes)
+		if m.replayVisible() {
+			// The replay card takes a row of its own above the live cards.
+			rows++
+		}
 		if rows < 1 {
 			rows = 1
 		}
-		bodyBudget := availableHeight - rows*frameHeight
+		bodyBudget := availableHeight - rows*frameHeight - headerLines
 		if bodyBudget < 0 {
 			bodyBudget = 0
 		}
@@ -95,7 +102,7 @@ func (m *Model) updatePreviewDimensions(count int) {
 			candidateHeight = 0
 		}
 
-		predicted := rows * (frameHeight + candidateHeight)
+		predicted := rows*(frameHeight+candidateHeight) + headerLines
 		if cols == maxCols {
 			selectedCols = cols
 			selectedWidth = innerWidth
@@ -135,6 +142,19 @@ func (m *Model) updatePreviewDimensions(count int) {
 	}
 }
 
+// gridRows counts card rows and group header lines for a column count. With
+// no groups the cards fill rows in one block.
+func gridRows(count, cols int, groupSizes []int) (int, int) {
+	if groupSizes == nil {
+		return (count + cols - 1) / cols, 0
+	}
+	rows := 0
+	for _, size := range groupSizes {
+		rows += (size + cols - 1) / cols
+	}
+	return rows, len(groupSizes)
+}
+
 // cardAt resolves the card located at the given mouse coordinates.
 func (m *Model) cardAt(msg tea.MouseMsg) (cardBounds, bool) {
 	for _, card := range m.cardLayout {
@@ -186,6 +206,9 @@ func (m *Model) moveCursorDown() bool {
 }
 
 // moveCursorByDelta advances the cursor by the provided delta if permitted.
+// Horizontal moves stay within the current row; vertical moves step whole
+// rows of the sorted, grouped grid and clamp to the last card of shorter
+// rows.
 func (m *Model) moveCursorByDelta(delta int, enforceRow bool) bool {
 	sessions := m.filteredSessions()
 	if len(sessions) == 0 {
@@ -193,28 +216,42 @@ func (m *Model) moveCursorByDelta(delta int, enforceRow bool) bool {
 		return false
 	}
 	m.ensureCursor(sessions)
-	cols := max(1, m.cardCols)
-	currentIndex := -1
-	for idx, session := range sessions {
-		if session.ID == m.cursorSession {
-			currentIndex = idx
-			break
-		}
+	if !enforceRow {
+		delta /= max(1, m.cardCols)
 	}
-	if currentIndex == -1 {
+	next, ok := m.cardNeighbor(m.cursorSession, delta, enforceRow)
+	if !ok {
 		return false
 	}
-	nextIndex := currentIndex + delta
-	if nextIndex < 0 || nextIndex >= len(sessions) {
-		return false
+	m.cursorSession = next
+	return true
+}
+
+// cardNeighbor finds the card delta columns (horizontal) or delta rows away
+// from sessionID in the rendered grid. Vertical moves clamp to the last card
+// of shorter rows.
+func (m *Model) cardNeighbor(sessionID string, delta int, horizontal bool) (string, bool) {
+	rows := m.cardRows()
+	row, col := -1, -1
+	for r, ids := range rows {
+		if c := slices.Index(ids, sessionID); c >= 0 {
+			row, col = r, c
+			break
+		}
+	}
+	if row == -1 || delta == 0 {
+		return "", false
 	}
-	if enforceRow {
-		currentRow := currentIndex / cols
-		nextRow := nextIndex / cols
-		if currentRow != nextRow {
-			return false
+	if horizontal {
+		next := col + delta
+		if next < 0 || next >= len(rows[row]) {
+			return "", false
 		}
+		return rows[row][next], true
 	}
-	m.cursorSession = sessions[nextIndex].ID
-	return true
+	nextRow := row + delta
+	if nextRow < 0 || nextRow >= len(rows) {
+		return "", false
+	}
+	return rows[nextRow][min(col, len(rows[nextRow])-1)], true
 }
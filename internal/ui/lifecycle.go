@@ -0,0 +1,280 @@
+// File lifecycle.go tracks pane running→dead transitions across snapshots and
+// turns them into notifications.
+package ui
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+
+	"github.com/steipete/tmuxwatch/internal/config"
+	"github.com/steipete/tmuxwatch/internal/notify"
+	"github.com/steipete/tmuxwatch/internal/tmux"
+)
+
+// notifyCommandTimeout bounds how long a user notification command may run.
+const notifyCommandTimeout = 10 * time.Second
+
+// exitAlertRule names the alerts recorded for pane exits.
+const exitAlertRule = "exit"
+
+// paneLife remembers what the previous snapshot said about a pane.
+type paneLife struct {
+	sessionID string
+	session   string
+	window    string
+	title     string
+	command   string
+	dead      bool
+	started   time.Time
+}
+
+// paneExit is a pane that died since the previous snapshot.
+type paneExit struct {
+	sessionID string
+	event     notify.Event
+}
+
+// trackLifecycle compares the current snapshot with the previous one and
+// returns the panes that stopped running: panes tmux kept as dead
+// (remain-on-exit) and running panes that disappeared. Panes seen for the
+// first time never count as exits, so start-up does not replay old deaths.
+func (m *Model) trackLifecycle(now time.Time) []paneExit {
+	next := make(map[string]paneLife)
+	var exits []paneExit
+	for _, session := range m.sessions {
+		for _, window := range session.Windows {
+			for _, pane := range window.Panes {
+				prev, seen := m.paneLife[pane.ID]
+				life := paneLife{
+					sessionID: session.ID,
+					session:   session.Name,
+					window:    window.Name,
+					title:     pane.Title,
+					command:   pane.CurrentCmd,
+					dead:      pane.Dead,
+					started:   prev.started,
+				}
+				switch {
+				case !seen:
+					life.started = paneStart(pane, now)
+				case prev.dead && !pane.Dead:
+					life.started = now
+				case !prev.dead && pane.Dead:
+					exits = append(exits, paneExit{
+						sessionID: session.ID,
+						event:     exitEvent(session, window, pane, prev.started, now),
+					})
+				}
+				next[pane.ID] = life
+			}
+		}
+	}
+	for id, prev := range m.paneLife {
+		if _, ok := next[id]; ok || prev.dead {
+			continue
+		}
+		exits = append(exits, paneExit{sessionID: prev.sessionID, event: notify.Event{
+			Kind:      notify.KindClosed,
+			Session:   prev.session,
+			Window:    prev.window,
+			Pane:      id,
+			Title:     prev.title,
+			Command:   prev.command,
+			StartedAt: prev.started,
+			EndedAt:   now,
+			Seconds:   now.Sub(prev.started).Seconds(),
+		}})
+	}
+	slices.SortFunc(exits, func(a, b paneExit) int { return strings.Compare(a.event.Pane, b.event.Pane) })
+	m.paneLife = next
+	return exits
+}
+
+// paneStart prefers tmux's creation time and falls back to now.
+func paneStart(pane tmux.Pane, now time.Time) time.Time {
+	if pane.CreatedAt.Unix() > 0 && !pane.CreatedAt.After(now) {
+		return pane.CreatedAt
+	}
+	return now
+}
+
+// exitEvent describes a pane that just died.
+func exitEvent(session tmux.Session, window tmux.Window, pane tmux.Pane, started, ended time.Time) notify.Event {
+	ev := notify.Event{
+		Kind:     notify.KindExit,
+		Session:  session.Name,
+		Window:   window.Name,
+		Pane:     pane.ID,
+		Title:    pane.Title,
+		Command:  pane.CurrentCmd,
+		ExitCode: pane.DeadStatus,
+		EndedAt:  ended,
+	}
+	if !started.IsZero() {
+		ev.StartedAt = started
+		ev.Seconds = ended.Sub(started).Seconds()
+	}
+	return ev
+}
+
+// notifyMode returns the configured exit notification mode.
+func (m *Model) notifyMode() string {
+	if m.notifyOn == "" {
+		return config.DefaultNotifyOn
+	}
+	return m.notifyOn
+}
+
+// shouldNotify applies the notification mode to an exit.
+func (m *Model) shouldNotify(exit paneExit) bool {
+	switch m.notifyMode() {
+	case "all":
+		return true
+	case "failures":
+		return exit.event.Failed() || m.isWatched(exit.sessionID)
+	case "watched":
+		return m.isWatched(exit.sessionID)
+	default:
+		return false
+	}
+}
+
+// handleExits notifies about the exits that match the notification mode.
+// A watched session is notified once and then stops being watched. It must
+// run before the snapshot prunes state for sessions that disappeared.
+func (m *Model) handleExits(exits []paneExit) tea.Cmd {
+	m.logExits(exits)
+	var cmds []tea.Cmd
+	for _, exit := range exits {
+		if !m.shouldNotify(exit) {
+			continue
+		}
+		delete(m.watched, exit.sessionID)
+		m.recordAlert(exitAlertRule, exit.sessionID, exit.event.Pane, exit.event.Summary(), exit.event.EndedAt)
+		if m.highlighted == nil {
+			m.highlighted = make(map[string]struct{})
+		}
+		m.highlighted[exit.sessionID] = struct{}{}
+		cmds = append(cmds, m.deliverNotification(exit.event))
+	}
+	return tea.Batch(cmds...)
+}
+
+// deliverNotification sends ev through every configured method.
+func (m *Model) deliverNotification(ev notify.Event) tea.Cmd {
+	methods := m.notifyMethods
+	if methods == nil {
+		methods = config.DefaultNotifyMethods
+	}
+	summary := ev.Summary()
+	var cmds []tea.Cmd
+	for _, method := range methods {
+		switch method {
+		case "toast":
+			m.showToast(summary)
+		case "bell":
+			cmds = append(cmds, tea.Raw("\a"))
+		case "osc9":
+			cmds = append(cmds, tea.Raw(m.terminalEscape(notify.OSC9("tmuxwatch: "+summary))))
+		case "osc777":
+			cmds = append(cmds, tea.Raw(m.terminalEscape(notify.OSC777("tmuxwatch", summary))))
+		case "command":
+			if m.notifyCommand != "" {
+				cmds = append(cmds, runNotifyCommandCmd(m.notifyCommand, ev))
+			}
+		}
+	}
+	return tea.Batch(cmds...)
+}
+
+// terminalEscape wraps seq for tmux passthrough when running inside tmux.
+func (m *Model) terminalEscape(seq string) string {
+	if m.insideTmux {
+		return notify.Passthrough(seq)
+	}
+	return seq
+}
+
+// runNotifyCommandCmd runs the user's notification command in the background.
+func runNotifyCommandCmd(command string, ev notify.Event) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), notifyCommandTimeout)
+		defer cancel()
+		if err := notify.Run(ctx, command, ev); err != nil {
+			return statusMsg(err.Error())
+		}
+		return nil
+	}
+}
+
+// isWatched reports whether the user asked to be told when the session's
+// pane finishes.
+func (m *Model) isWatched(sessionID string) bool {
+	_, ok := m.watched[sessionID]
+	return ok
+}
+
+// toggleWatch flips "notify when finished" for a session.
+func (m *Model) toggleWatch(sessionID string) tea.Cmd {
+	session, ok := m.sessionByID(sessionID)
+	if !ok {
+		return nil
+	}
+	if m.isWatched(sessionID) {
+		delete(m.watched, sessionID)
+		return showStatusMessage("Stopped watching " + session.Name)
+	}
+	if m.watched == nil {
+		m.watched = make(map[string]struct{})
+	}
+	m.watched[sessionID] = struct{}{}
+	return showStatusMessage(fmt.Sprintf("Will notify when %s finishes", session.Name))
+}
+
+// forgetPanes drops lifecycle state for a session tmuxwatch closed itself so
+// the kill is not reported as an exit.
+func (m *Model) forgetPanes(sessionID string) {
+	for id, life := range m.paneLife {
+		if life.sessionID == sessionID {
+			delete(m.paneLife, id)
+		}
+	}
+}
+
+// pruneWatched forgets watches on sessions that no longer exist.
+func (m *Model) pruneWatched() {
+	for id := range m.watched {
+		if !m.sessionExists(id) {
+			delete(m.watched, id)
+		}
+	}
+}
+
+// watchPaletteCommands offers toggling the notification for the cursor or
+// focused session.
+func (m *Model) watchPaletteCommands() []commandItem {
+	target := m.focusedSession
+	if target == "" {
+		target = m.cursorSession
+	}
+	session, ok := m.sessionByID(target)
+	if !ok {
+		return nil
+	}
+	label := "Notify when " + session.Name + " finishes"
+	if m.isWatched(target) {
+		label = "Stop watching " + session.Name
+	}
+	return []commandItem{{
+		label:   label,
+		enabled: true,
+		run: func(m *Model) tea.Cmd {
+			return m.toggleWatch(target)
+		},
+	}}
+}
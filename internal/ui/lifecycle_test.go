@@ -0,0 +1,99 @@
+// File lifecycle_test.go covers pane exit tracking and notifications.
+package ui
+
+import (
+	"testing"
+	"time"
+
+	"github.com/steipete/tmuxwatch/internal/notify"
+	"github.com/steipete/tmuxwatch/internal/tmux"
+)
+
+// TestTrackLifecycleReportsTransitions reports running→dead and vanished
+// panes with their run time, ignoring panes that were already dead.
+func TestTrackLifecycleReportsTransitions(t *testing.T) {
+	t.Parallel()
+
+	start := time.Unix(1_000, 0)
+	m := &Model{sessions: []tmux.Session{
+		lifecycleSession("$1", "ci", tmux.Pane{ID: "%1", Title: "make", CreatedAt: start}),
+		lifecycleSession("$2", "old", tmux.Pane{ID: "%2", Dead: true, DeadStatus: 1}),
+		lifecycleSession("$3", "shell", tmux.Pane{ID: "%3", CurrentCmd: "zsh"}),
+	}}
+	if exits := m.trackLifecycle(start.Add(time.Minute)); len(exits) != 0 {
+		t.Fatalf("first snapshot reported %d exits", len(exits))
+	}
+
+	m.sessions = []tmux.Session{
+		lifecycleSession("$1", "ci", tmux.Pane{ID: "%1", Title: "make", Dead: true, DeadStatus: 2, CreatedAt: start}),
+		lifecycleSession("$2", "old", tmux.Pane{ID: "%2", Dead: true, DeadStatus: 1}),
+	}
+	exits := m.trackLifecycle(start.Add(10 * time.Minute))
+	if len(exits) != 2 {
+		t.Fatalf("exits = %+v, want 2", exits)
+	}
+	if got, want := exits[0].event.Summary(), "make in ci: exited 2 after 10m0s"; got != want {
+		t.Fatalf("exit summary = %q, want %q", got, want)
+	}
+	if exits[1].event.Kind != notify.KindClosed || exits[1].sessionID != "$3" {
+		t.Fatalf("second exit = %+v, want closed %%3", exits[1])
+	}
+}
+
+// TestHandleExitsFollowsMode notifies according to the configured mode and
+// clears one-shot watches.
+func TestHandleExitsFollowsMode(t *testing.T) {
+	t.Parallel()
+
+	failed := paneExit{sessionID: "$1", event: notify.Event{Kind: notify.KindExit, Session: "ci", Pane: "%1", ExitCode: 1}}
+	passed := paneExit{sessionID: "$2", event: notify.Event{Kind: notify.KindExit, Session: "docs", Pane: "%2"}}
+	tests := []struct {
+		name    string
+		mode    string
+		watched string
+		want    int
+	}{
+		{name: "watched only", mode: "watched", watched: "$2", want: 1},
+		{name: "nothing watched", mode: "watched", want: 0},
+		{name: "failures", mode: "failures", want: 1},
+		{name: "failures plus watched", mode: "failures", watched: "$2", want: 2},
+		{name: "all", mode: "all", want: 2},
+		{name: "off", mode: "off", watched: "$2", want: 0},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			m := &Model{
+				sessions: []tmux.Session{
+					lifecycleSession("$1", "ci"),
+					lifecycleSession("$2", "docs"),
+				},
+				notifyOn:      tc.mode,
+				notifyMethods: []string{"toast"},
+			}
+			if tc.watched != "" {
+				m.toggleWatch(tc.watched)
+			}
+			m.handleExits([]paneExit{failed, passed})
+			if len(m.alerts) != tc.want {
+				t.Fatalf("alerts = %+v, want %d", m.alerts, tc.want)
+			}
+			if tc.want > 0 && tc.mode != "off" && m.isWatched(tc.watched) {
+				t.Fatal("watch should be cleared after notifying")
+			}
+		})
+	}
+}
+
+// TestTerminalEscapeInsideTmux wraps desktop notifications for passthrough.
+func TestTerminalEscapeInsideTmux(t *testing.T) {
+	t.Parallel()
+
+	seq := notify.OSC9("done")
+	if got := (&Model{}).terminalEscape(seq); got != seq {
+		t.Fatalf("outside tmux = %q, want unchanged", got)
+	}
+	if got := (&Model{insideTmux: true}).terminalEscape(seq); got != notify.Passthrough(seq) {
+		t.Fatalf("inside tmux = %q, want passthrough", got)
+	}
+}
@@ -12,41 +12,38 @@ import (
 	tea "charm.land/bubbletea/v2"
 	zone "github.com/steipete/tmuxwatch/internal/zone"
 
+	"github.com/steipete/tmuxwatch/internal/archive"
+	"github.com/steipete/tmuxwatch/internal/cast"
+	"github.com/steipete/tmuxwatch/internal/config"
+	"github.com/steipete/tmuxwatch/internal/eventlog"
+	"github.com/steipete/tmuxwatch/internal/history"
+	"github.com/steipete/tmuxwatch/internal/store"
 	"github.com/steipete/tmuxwatch/internal/tmux"
 )
 
 const (
-	defaultPollInterval = time.Second
+	defaultPollInterval = config.DefaultPollInterval
 	minPreviewHeight    = 6
-	maxCapturesPerTick  = 6
+	maxCapturesPerTick  = config.DefaultMaxPerTick
 	cardPadding         = 1
 	closeLabel          = "[x]"
+	jumpLabel           = "[>]"
 	maximizeLabel       = "[^]"
 	restoreLabel        = "[v]"
 	collapseLabel       = "[-]"
 	expandLabel         = "[+]"
+	pinMarker           = "⚑"
+	unreadMarker        = "●"
+	watchMarker         = "◷"
+	selectedMarker      = "✓"
+	recordMarker        = "REC"
 	scrollStep          = 3
 	pulseDuration       = 1500 * time.Millisecond
 	quitChordWindow     = 600 * time.Millisecond
-	staleThreshold      = time.Hour
-	minCaptureLines     = 80
-	maxCaptureLines     = 600
+	staleThreshold      = config.DefaultStaleThreshold
+	minCaptureLines     = config.DefaultMinCaptureLines
+	maxCaptureLines     = config.DefaultMaxCaptureLines
 	captureSlackLines   = 40
-	borderColorBase     = "62"
-	borderColorFocus    = "212"
-	borderColorPulse    = "213"
-	borderColorCursor   = "111"
-	borderColorHover    = "143"
-	borderColorExitFail = "203"
-	borderColorExitOK   = "36"
-	borderColorStale    = "95"
-	headerColorBase     = "249"
-	headerColorFocus    = "212"
-	headerColorPulse    = "219"
-	headerColorCursor   = "111"
-	headerColorExitFail = "203"
-	headerColorExitOK   = "37"
-	headerColorStale    = "103"
 )
 
 type viewMode int
@@ -71,10 +68,42 @@ type (
 		vars      map[string]string
 		err       error
 	}
-	killSessionsMsg struct{ ids []string }
-	errMsg          struct{ err error }
-	tickMsg         struct{}
-	searchBlurMsg   struct{}
+	cpuMsg struct {
+		usage map[string]float64
+		err   error
+	}
+	repoRootsMsg struct {
+		// roots maps pane directories to their git work tree, "" outside one.
+		roots map[string]string
+	}
+	killSessionsMsg struct {
+		ids     []string
+		results []killResult
+	}
+	// replayTickMsg advances a playing replay; gen drops ticks from an
+	// earlier playback loop.
+	replayTickMsg struct {
+		gen int
+		at  time.Time
+	}
+	// exportMsg reports a finished export; text is set for clipboard
+	// exports and path for saved files.
+	exportMsg struct {
+		sessionID string
+		scope     exportScope
+		clipboard bool
+		text      string
+		path      string
+		lines     int
+		err       error
+	}
+	broadcastMsg struct {
+		// results maps each target pane to its delivery error, nil on success.
+		results map[string]error
+	}
+	errMsg        struct{ err error }
+	tickMsg       struct{}
+	searchBlurMsg struct{}
 )
 
 type sessionPreview struct {
@@ -91,6 +120,7 @@ type cardBounds struct {
 	closeZoneID    string
 	maximizeZoneID string
 	collapseZoneID string
+	jumpZoneID     string
 }
 
 type commandItem struct {
@@ -116,6 +146,15 @@ type Model struct {
 	hidden    map[string]struct{}
 	stale     map[string]struct{}
 	collapsed map[string]struct{}
+	// selected holds the cards chosen for bulk actions.
+	selected map[string]struct{}
+
+	dialog   *dialog
+	prompt   *prompt
+	composer *composer
+	// commandHistory holds composed commands, oldest first.
+	commandHistory []string
+	snippets       []config.Snippet
 
 	paletteOpen     bool
 	paletteIndex    int
@@ -154,6 +193,123 @@ type Model struct {
 	cachedStatus string
 	lastCtrlC    time.Time
 	lastEsc      time.Time
+
+	keys        *keymap
+	leaderArmed bool
+	// insertSession is the focused card in insert mode, if any.
+	insertSession string
+	// broadcasting sends every key to the broadcast targets.
+	broadcasting  bool
+	broadcastErrs map[string]error
+	// broadcastQueue holds input typed while a delivery is in flight.
+	broadcastQueue []broadcastJob
+	broadcastBusy  bool
+
+	captureMin     int
+	captureMax     int
+	capturePerTick int
+	staleAfter     time.Duration
+	staleRules     []staleRule
+	staleExempt    []string
+	staleProtect   []string
+	staleSignals   []string
+	staleCPU       float64
+	staleWhy       map[string]string
+	hideRules      []hideRule
+	revealed       map[string]struct{}
+
+	theme     theme
+	themeAuto bool
+
+	sortMode        sortMode
+	groupMode       groupMode
+	collapsedGroups map[string]struct{}
+	groupLayout     []groupBounds
+	repoRoots       map[string]string
+	cpuUsage        map[string]float64
+
+	statePath   string
+	pins        map[string]struct{}
+	manualOrder []string
+	pendingView *store.View
+	dragSession string
+
+	alertRules  []alertRule
+	alerts      []alert
+	alertFired  map[string]time.Time
+	highlighted map[string]struct{}
+	unread      map[string]struct{}
+
+	notifyOn      string
+	notifyMethods []string
+	notifyCommand string
+	insideTmux    bool
+	tmuxPane      string
+	jumpTarget    string
+	paneLife      map[string]paneLife
+	watched       map[string]struct{}
+
+	silenceAfter   time.Duration
+	resumeAfter    time.Duration
+	tmuxFlagAlerts bool
+	monitors       map[string]*sessionMonitor
+	windowFlags    map[string]windowFlags
+
+	activity map[string]*activitySeries
+
+	timeline        []timelineEvent
+	eventLog        *eventlog.Writer
+	keysPending     bool
+	timelineOpen    bool
+	timelineIndex   int
+	timelineKindIdx int
+	timelineFilter  string
+
+	history *history.Store
+	// writer appends to the event log and history off the update loop.
+	writer       *diskWriter
+	historyStart time.Time
+	historyLines map[string]int
+	historyPanes map[string]struct{}
+
+	archiveDir     string
+	archiveOpen    bool
+	archiveEntries []archive.Entry
+	archiveIndex   int
+	archiveReader  *archiveReader
+
+	recordDir  string
+	recordings map[string]*cast.Writer
+
+	replay        *replay
+	replayFocused bool
+
+	exportDir  string
+	exportANSI bool
+	cardMenu   *cardMenu
+
+	hiddenOpen  bool
+	hiddenIndex int
+}
+
+// Options carries start-up settings for NewModel. State is the previously
+// saved UI state; StatePath, when set, is where changes are written back.
+// EventLog, when set, receives a copy of every timeline event, and History
+// receives activity samples, pane starts, and exits. ArchiveDir, when set,
+// is where sessions' scrollback is saved before they are killed, and
+// RecordDir is where asciicast recordings are written, and ExportDir is
+// where exported pane text is saved.
+type Options struct {
+	Config     config.Config
+	State      store.State
+	StatePath  string
+	EventLog   *eventlog.Writer
+	History    *history.Store
+	ArchiveDir string
+	RecordDir  string
+	ExportDir  string
+	DebugMsgs  []tea.Msg
+	TraceMouse bool
 }
 
 // sessionLabel strips leading sigils from tmux session identifiers for
@@ -165,17 +321,29 @@ func sessionLabel(id string) string {
 	return id
 }
 
-// NewModel builds a Model with defaults and the provided tmux client.
-func NewModel(client *tmux.Client, poll time.Duration, debugMsgs []tea.Msg, traceMouse bool) *Model {
+// NewModel builds a Model with defaults and the provided tmux client. It
+// fails when the configured keymap or an alert rule is invalid.
+func NewModel(client *tmux.Client, opts Options) (*Model, error) {
+	cfg := opts.Config
+	poll := time.Duration(cfg.PollInterval)
 	if poll <= 0 {
 		poll = defaultPollInterval
 	}
+	keys, err := newKeymap(cfg.Keymap)
+	if err != nil {
+		return nil, err
+	}
+	rules, err := compileAlertRules(cfg.Alerts)
+	if err != nil {
+		return nil, err
+	}
 	ti := textinput.New()
 	ti.Placeholder = "filter sessions, windows, panes"
 	ti.CharLimit = 256
 	ti.Prompt = "/ "
-	return &Model{
+	m := &Model{
 		client:          client,
+		keys:            keys,
 		pollInterval:    poll,
 		zonePrefix:      zone.NewPrefix(),
 		previews:        make(map[string]*sessionPreview),
@@ -189,15 +357,53 @@ func NewModel(client *tmux.Client, poll time.Duration, debugMsgs []tea.Msg, trac
 		cardInnerHeight: minPreviewHeight,
 		inflight:        true,
 		previewOffset:   topPaddingLines,
-		debugMsgs:       append([]tea.Msg(nil), debugMsgs...),
-		traceMouse:      traceMouse,
+		debugMsgs:       append([]tea.Msg(nil), opts.DebugMsgs...),
+		traceMouse:      opts.TraceMouse,
 		toast:           &toastState{},
 		viewMode:        viewModeOverview,
 		tabSessionIDs:   make([]string, 0),
 		footer:          footerViewport(),
 		footerHeight:    3,
 		hostname:        lookupHostname(),
+		captureMin:      cfg.Capture.MinLines,
+		captureMax:      cfg.Capture.MaxLines,
+		capturePerTick:  cfg.Capture.MaxPerTick,
+		staleAfter:      time.Duration(cfg.StaleThreshold),
+		staleRules:      newStaleRules(cfg.Stale.Rules),
+		staleExempt:     append([]string(nil), cfg.Stale.Exempt...),
+		staleProtect:    append([]string(nil), cfg.Stale.Protect...),
+		staleSignals:    append([]string(nil), cfg.Stale.Signals...),
+		staleCPU:        cfg.Stale.CPU,
+		hideRules:       newHideRules(cfg.Hidden, cfg.HideRules),
+		snippets:        cfg.Snippets,
+		revealed:        make(map[string]struct{}),
+		collapsedGroups: make(map[string]struct{}),
+		statePath:       opts.StatePath,
+		alertRules:      rules,
+		notifyOn:        cfg.Notify.On,
+		notifyMethods:   append([]string(nil), cfg.Notify.Methods...),
+		notifyCommand:   cfg.Notify.Command,
+		insideTmux:      os.Getenv("TMUX") != "",
+		tmuxPane:        os.Getenv("TMUX_PANE"),
+		silenceAfter:    time.Duration(cfg.Monitors.Silence),
+		resumeAfter:     time.Duration(cfg.Monitors.ResumeAfter),
+		tmuxFlagAlerts:  cfg.Monitors.TmuxFlags,
+		eventLog:        opts.EventLog,
+		history:         opts.History,
+		archiveDir:      opts.ArchiveDir,
+		recordDir:       opts.RecordDir,
+		exportDir:       opts.ExportDir,
+		exportANSI:      cfg.Export.ANSI,
 	}
+	m.setSortMode(sortMode(cfg.Sort))
+	m.setGroupMode(groupMode(cfg.Group))
+	// Saved state comes last so a restored sort (e.g. a manual order) wins
+	// over the configured default.
+	m.restoreState(opts.State)
+	if cfg.Theme == "" || !m.setTheme(cfg.Theme) {
+		m.setTheme(config.DefaultTheme)
+	}
+	return m, nil
 }
 
 func lookupHostname() string {
@@ -221,6 +427,9 @@ func (m *Model) Init() tea.Cmd {
 		fetchSnapshotCmd(m.client),
 		scheduleTick(m.pollInterval),
 	}
+	if m.themeAuto {
+		cmds = append(cmds, tea.RequestBackgroundColor)
+	}
 	for _, msg := range m.debugMsgs {
 		cmds = append(cmds, emitMsg(msg))
 	}
@@ -0,0 +1,151 @@
+// File modes.go implements the navigation and insert modes of a focused card.
+// Navigation mode runs tmuxwatch commands and moves between cards, tabs, and
+// panes; insert mode sends every key to the pane until the escape chord.
+package ui
+
+import (
+	"fmt"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+)
+
+// inputMode says where key presses go.
+type inputMode int
+
+const (
+	modeNavigate inputMode = iota
+	modeInsert
+	modeBroadcast
+	modeReplay
+)
+
+// String returns the badge shown in the status line.
+func (i inputMode) String() string {
+	switch i {
+	case modeInsert:
+		return "INSERT"
+	case modeBroadcast:
+		return "BROADCAST"
+	case modeReplay:
+		return "REPLAY"
+	default:
+		return "NAV"
+	}
+}
+
+// inputMode reports the current mode. Insert mode belongs to the card it was
+// entered on, so focusing another card or leaving focus returns to
+// navigation. Broadcast mode applies whatever is focused. Replay mode is
+// active while the replay card has focus.
+func (m *Model) inputMode() inputMode {
+	if m.broadcasting {
+		return modeBroadcast
+	}
+	if m.focusedSession != "" && m.insertSession == m.focusedSession {
+		return modeInsert
+	}
+	if m.replayActive() {
+		return modeReplay
+	}
+	return modeNavigate
+}
+
+// enterInsertMode starts sending keys to the focused pane.
+func (m *Model) enterInsertMode() {
+	if m.focusedSession == "" {
+		return
+	}
+	m.insertSession = m.focusedSession
+	m.lastEsc = time.Time{}
+	m.resetCtrlC()
+}
+
+// leaveInsertMode returns the focused card to navigation mode.
+func (m *Model) leaveInsertMode() {
+	m.insertSession = ""
+	m.lastEsc = time.Time{}
+}
+
+// handleInsertKey forwards a key to the focused pane. Pressing the back key
+// twice in quick succession returns to navigation mode; the first press still
+// reaches the pane so editors and shells see a single escape.
+func (m *Model) handleInsertKey(msg tea.KeyMsg) (bool, tea.Cmd) {
+	if m.keymap().lookup(scopeGlobal, msg.String()) != actionBack {
+		m.lastEsc = time.Time{}
+		return m.forwardKey(msg)
+	}
+	now := time.Now()
+	if !m.lastEsc.IsZero() && now.Sub(m.lastEsc) < quitChordWindow {
+		m.leaveInsertMode()
+		return true, nil
+	}
+	m.lastEsc = now
+	return m.forwardKey(msg)
+}
+
+// cyclePane selects the previous or next pane of the focused session in
+// tmux, crossing window boundaries, and refreshes so the card follows it.
+func (m *Model) cyclePane(delta int) tea.Cmd {
+	session, ok := m.sessionByID(m.focusedSession)
+	if !ok {
+		return nil
+	}
+	type target struct{ window, pane string }
+	var targets []target
+	current := 0
+	for _, window := range session.Windows {
+		for _, pane := range window.Panes {
+			if preview, ok := m.previews[session.ID]; ok && preview.paneID == pane.ID {
+				current = len(targets)
+			}
+			targets = append(targets, target{window.ID, pane.ID})
+		}
+	}
+	if len(targets) < 2 {
+		return nil
+	}
+	next := targets[(current+delta+len(targets))%len(targets)]
+	m.resetCtrlC()
+	return tea.Sequence(selectPaneCmd(m.client, next.window, next.pane), fetchSnapshotCmd(m.client))
+}
+
+// modeHint lists the keys that matter in the current mode.
+func (m *Model) modeHint() string {
+	switch {
+	case m.inputMode() == modeBroadcast:
+		targets, _ := m.broadcastTargets()
+		return fmt.Sprintf("keys go to %d panes · esc esc stops", len(targets))
+	case m.inputMode() == modeInsert && m.keymap().leader != "":
+		return "keys go to the pane · " + m.keymap().leader + " then a key runs a command · esc esc navigates"
+	case m.inputMode() == modeInsert:
+		return "keys go to the pane · esc esc navigates"
+	case m.inputMode() == modeReplay:
+		return "space play/pause · ←/→ seek · +/- speed · n/N matches · x close · esc leaves"
+	case m.focusedSession != "":
+		return "j/k scroll · h/l panes · alt+hjkl cards · [ ] tabs · i insert · esc esc unfocus"
+	case m.selectionSummary() != "":
+		return m.selectionSummary() + " · ctrl+p bulk actions · esc clears"
+	default:
+		return "hjkl move · [ ] tabs · enter focus · space select"
+	}
+}
+
+// renderModeBadge draws the mode indicator that starts the status footer.
+func (m *Model) renderModeBadge() string {
+	th := m.colors()
+	badge := lipgloss.NewStyle().
+		Bold(true).
+		Padding(0, 1).
+		Foreground(lipgloss.Color(th.accentText)).
+		Background(lipgloss.Color(th.accent))
+	switch m.inputMode() {
+	case modeInsert:
+		badge = badge.Background(lipgloss.Color(th.borderAlert))
+	case modeBroadcast:
+		badge = badge.Background(lipgloss.Color(th.borderExitFail))
+	}
+	hint := lipgloss.NewStyle().Foreground(lipgloss.Color(th.helpText))
+	return badge.Render(m.inputMode().String()) + " " + hint.Render(m.modeHint())
+}
@@ -0,0 +1,155 @@
+// File modes_test.go covers navigation and insert modes.
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+
+	"github.com/steipete/tmuxwatch/internal/tmux"
+)
+
+// sentKeys counts the keys forwarded to panes so far.
+func sentKeys(m *Model) int {
+	total := 0
+	for _, event := range m.timeline {
+		total += event.keys
+	}
+	return total
+}
+
+// TestModesRouteKeys keeps plain keys out of the pane in navigation mode,
+// forwards everything in insert mode, and leaves insert mode on esc esc.
+func TestModesRouteKeys(t *testing.T) {
+	t.Parallel()
+
+	m := testModel(tmux.Session{ID: "$1", Name: "dev", Windows: []tmux.Window{
+		{ID: "@1", Active: true, Panes: []tmux.Pane{{ID: "%1", Active: true}}},
+		{ID: "@2", Panes: []tmux.Pane{{ID: "%2", Active: true}}},
+	}})
+	vp := viewportFor(innerDimension{width: 40, height: 6})
+	m.previews["$1"] = &sessionPreview{viewport: &vp, paneID: "%1"}
+	m.focusedSession = "$1"
+	press := func(key tea.KeyPressMsg) tea.Cmd {
+		_, cmd := m.Update(key)
+		return cmd
+	}
+	if m.inputMode() != modeNavigate || !strings.Contains(m.renderModeBadge(), "NAV") {
+		t.Fatal("a focused card should start in navigation mode")
+	}
+	press(tea.KeyPressMsg{Code: 'x', Text: "x"})
+	press(tea.KeyPressMsg{Code: 'z', Text: "z"})
+	if sentKeys(m) != 0 || !m.isCollapsed("$1") {
+		t.Fatal("navigation mode should run commands instead of typing")
+	}
+
+	press(tea.KeyPressMsg{Code: 'i', Text: "i"})
+	if m.inputMode() != modeInsert || !strings.Contains(m.renderModeBadge(), "INSERT") {
+		t.Fatal("i should enter insert mode")
+	}
+	for _, key := range []tea.KeyPressMsg{{Code: 'q', Text: "q"}, {Code: 'z', Text: "z"}, {Code: tea.KeyEscape}} {
+		if press(key) == nil {
+			t.Fatalf("%s should be forwarded in insert mode", key)
+		}
+	}
+	if sentKeys(m) != 3 || m.inputMode() != modeInsert || !m.isCollapsed("$1") {
+		t.Fatalf("sent %d keys, mode %s", sentKeys(m), m.inputMode())
+	}
+	press(tea.KeyPressMsg{Code: tea.KeyEscape})
+	if m.inputMode() != modeNavigate || sentKeys(m) != 3 || m.focusedSession != "$1" {
+		t.Fatal("esc esc should return to navigation mode without forwarding the second escape")
+	}
+
+	press(tea.KeyPressMsg{Code: tea.KeyEnter})
+	if m.inputMode() != modeInsert {
+		t.Fatal("enter on the focused card should enter insert mode")
+	}
+	m.focusedSession = ""
+	if m.inputMode() != modeNavigate {
+		t.Fatal("leaving focus should end insert mode")
+	}
+}
+
+// TestVimNavigationBindings binds hjkl to cards and panes and [ ] to tabs.
+func TestVimNavigationBindings(t *testing.T) {
+	t.Parallel()
+
+	km := defaultKeymap()
+	tests := []struct {
+		scope keyScope
+		key   string
+		want  action
+	}{
+		{scopeOverview, "h", actionCursorLeft},
+		{scopeOverview, "j", actionCursorDown},
+		{scopeOverview, "k", actionCursorUp},
+		{scopeOverview, "l", actionCursorRight},
+		{scopePane, "j", actionScrollDown},
+		{scopePane, "k", actionScrollUp},
+		{scopePane, "h", actionPrevPane},
+		{scopePane, "l", actionNextPane},
+		{scopePane, "i", actionInsert},
+		{scopePane, "alt+h", actionCardLeft},
+		{scopePane, "alt+j", actionCardDown},
+		{scopePane, "alt+k", actionCardUp},
+		{scopePane, "alt+l", actionCardRight},
+		{scopeGlobal, "[", actionPrevTab},
+		{scopeGlobal, "]", actionNextTab},
+	}
+	for _, tt := range tests {
+		if got := km.lookup(tt.scope, tt.key); got != tt.want {
+			t.Fatalf("lookup(%s, %q) = %q, want %q", tt.scope, tt.key, got, tt.want)
+		}
+	}
+
+	m := testModel(tmux.Session{ID: "$1", Name: "dev", Windows: []tmux.Window{
+		{ID: "@1", Active: true, Panes: []tmux.Pane{{ID: "%1", Active: true}}},
+		{ID: "@2", Panes: []tmux.Pane{{ID: "%2", Active: true}}},
+	}})
+	vp := viewportFor(innerDimension{width: 40, height: 6})
+	m.previews["$1"] = &sessionPreview{viewport: &vp, paneID: "%1"}
+	m.focusedSession = "$1"
+	if m.cyclePane(1) == nil {
+		t.Fatal("l should select the pane in the next window")
+	}
+	m.sessions[0].Windows = m.sessions[0].Windows[:1]
+	if m.cyclePane(1) != nil {
+		t.Fatal("a single pane has nowhere to move")
+	}
+}
+
+// TestFocusNeighborWalksCards moves focus and the cursor across the grid
+// from a focused card and stays put at the edges.
+func TestFocusNeighborWalksCards(t *testing.T) {
+	t.Parallel()
+
+	m := testModel(
+		tmux.Session{ID: "$1", Name: "a"},
+		tmux.Session{ID: "$2", Name: "b"},
+		tmux.Session{ID: "$3", Name: "c"},
+	)
+	m.cardCols = 2
+	m.focusedSession = "$1"
+	for _, id := range []string{"$1", "$2", "$3"} {
+		vp := viewportFor(innerDimension{width: 40, height: 6})
+		m.previews[id] = &sessionPreview{viewport: &vp}
+	}
+
+	press := func(key tea.KeyPressMsg) {
+		t.Helper()
+		m.runPaneAction(m.keymap().lookup(scopePane, key.String()))
+	}
+	press(tea.KeyPressMsg{Code: 'l', Mod: tea.ModAlt})
+	if m.focusedSession != "$2" || m.cursorSession != "$2" {
+		t.Fatalf("alt+l focused %q (cursor %q), want $2", m.focusedSession, m.cursorSession)
+	}
+	press(tea.KeyPressMsg{Code: 'j', Mod: tea.ModAlt})
+	if m.focusedSession != "$3" {
+		t.Fatalf("alt+j focused %q, want $3", m.focusedSession)
+	}
+	press(tea.KeyPressMsg{Code: 'h', Mod: tea.ModAlt})
+	if m.focusedSession != "$3" {
+		t.Fatalf("alt+h at the left edge focused %q, want $3", m.focusedSession)
+	}
+}
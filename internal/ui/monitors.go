@@ -0,0 +1,302 @@
+// File monitors.go implements per-card silence and activity monitors and turns
+// tmux's window bell, activity, and silence flags into alerts.
+package ui
+
+import (
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+
+	"github.com/steipete/tmuxwatch/internal/config"
+	"github.com/steipete/tmuxwatch/internal/notify"
+	"github.com/steipete/tmuxwatch/internal/tmux"
+)
+
+// sessionMonitor holds the monitors enabled on one card.
+type sessionMonitor struct {
+	silence  bool
+	activity bool
+	armedAt  time.Time
+	// lastOutput is the newest output seen at the previous check.
+	lastOutput time.Time
+	// silenceFired stops a silence monitor repeating until output resumes.
+	silenceFired bool
+}
+
+// windowFlags is the last seen state of tmux's window alert flags.
+type windowFlags struct {
+	bell     bool
+	activity bool
+	silence  bool
+}
+
+// monitorEvent is a monitor or tmux flag that fired for a session.
+type monitorEvent struct {
+	sessionID string
+	event     notify.Event
+}
+
+// silenceThreshold returns how long a pane must be quiet to trip a silence
+// monitor.
+func (m *Model) silenceThreshold() time.Duration {
+	if m.silenceAfter <= 0 {
+		return config.DefaultSilence
+	}
+	return m.silenceAfter
+}
+
+// resumeThreshold returns how long a pane must be quiet before new output
+// trips an activity monitor.
+func (m *Model) resumeThreshold() time.Duration {
+	if m.resumeAfter <= 0 {
+		return config.DefaultResumeAfter
+	}
+	return m.resumeAfter
+}
+
+// lastOutput returns the newest output time known for a session, combining
+// tmux activity timestamps with the last capture that changed.
+func (m *Model) lastOutput(session tmux.Session) time.Time {
+	last := session.LastActivity
+	for _, window := range session.Windows {
+		for _, pane := range window.Panes {
+			if pane.LastActivity.After(last) {
+				last = pane.LastActivity
+			}
+		}
+	}
+	if preview, ok := m.previews[session.ID]; ok && preview.lastChanged.After(last) {
+		last = preview.lastChanged
+	}
+	return last
+}
+
+// monitor returns the session's monitor, creating it armed from now with the
+// session's newest known output as the baseline.
+func (m *Model) monitor(sessionID string) *sessionMonitor {
+	if mon, ok := m.monitors[sessionID]; ok {
+		return mon
+	}
+	if m.monitors == nil {
+		m.monitors = make(map[string]*sessionMonitor)
+	}
+	now := time.Now()
+	mon := &sessionMonitor{armedAt: now, lastOutput: now}
+	if session, ok := m.sessionByID(sessionID); ok {
+		if last := m.lastOutput(session); !last.IsZero() {
+			mon.lastOutput = last
+		}
+	}
+	m.monitors[sessionID] = mon
+	return mon
+}
+
+// toggleSilenceMonitor flips the silence monitor on a session.
+func (m *Model) toggleSilenceMonitor(sessionID string) tea.Cmd {
+	session, ok := m.sessionByID(sessionID)
+	if !ok {
+		return nil
+	}
+	mon := m.monitor(sessionID)
+	mon.silence = !mon.silence
+	mon.silenceFired = false
+	m.dropIdleMonitor(sessionID)
+	if mon.silence {
+		return showStatusMessage("Alert when " + session.Name + " is silent for " + durationLabel(m.silenceThreshold()))
+	}
+	return showStatusMessage("Stopped silence monitor on " + session.Name)
+}
+
+// toggleActivityMonitor flips the activity monitor on a session.
+func (m *Model) toggleActivityMonitor(sessionID string) tea.Cmd {
+	session, ok := m.sessionByID(sessionID)
+	if !ok {
+		return nil
+	}
+	mon := m.monitor(sessionID)
+	mon.activity = !mon.activity
+	m.dropIdleMonitor(sessionID)
+	if mon.activity {
+		return showStatusMessage("Alert when " + session.Name + " prints again")
+	}
+	return showStatusMessage("Stopped activity monitor on " + session.Name)
+}
+
+// dropIdleMonitor forgets a monitor once nothing is enabled on it.
+func (m *Model) dropIdleMonitor(sessionID string) {
+	if mon, ok := m.monitors[sessionID]; ok && !mon.silence && !mon.activity {
+		delete(m.monitors, sessionID)
+	}
+}
+
+// monitorLabels describes the monitors enabled on a card for its header.
+func (m *Model) monitorLabels(sessionID string) []string {
+	mon, ok := m.monitors[sessionID]
+	if !ok {
+		return nil
+	}
+	var labels []string
+	if mon.silence {
+		labels = append(labels, "watch silence "+durationLabel(m.silenceThreshold()))
+	}
+	if mon.activity {
+		labels = append(labels, "watch output")
+	}
+	return labels
+}
+
+// checkMonitors evaluates every session's monitors against the latest
+// output times. Monitors of vanished sessions are dropped.
+func (m *Model) checkMonitors(now time.Time) []monitorEvent {
+	var events []monitorEvent
+	for _, session := range m.sessions {
+		mon, ok := m.monitors[session.ID]
+		if !ok {
+			continue
+		}
+		if last := m.lastOutput(session); last.After(mon.lastOutput) {
+			if mon.activity && last.Sub(mon.lastOutput) >= m.resumeThreshold() {
+				events = append(events, monitorEvent{session.ID, m.monitorEvent(session, notify.KindActivity, mon.lastOutput, last)})
+			}
+			mon.lastOutput = last
+			mon.silenceFired = false
+		}
+		// Silence counts from arming so enabling the monitor on a pane that
+		// is already quiet does not fire straight away.
+		quietSince := mon.lastOutput
+		if quietSince.Before(mon.armedAt) {
+			quietSince = mon.armedAt
+		}
+		if mon.silence && !mon.silenceFired && now.Sub(quietSince) >= m.silenceThreshold() {
+			mon.silenceFired = true
+			events = append(events, monitorEvent{session.ID, m.monitorEvent(session, notify.KindSilence, mon.lastOutput, now)})
+		}
+	}
+	for id := range m.monitors {
+		if !m.sessionExists(id) {
+			delete(m.monitors, id)
+		}
+	}
+	return events
+}
+
+// monitorEvent builds an event for the session's active pane covering the
+// quiet span from start to end.
+func (m *Model) monitorEvent(session tmux.Session, kind string, start, end time.Time) notify.Event {
+	ev := notify.Event{
+		Kind:      kind,
+		Session:   session.Name,
+		StartedAt: start,
+		EndedAt:   end,
+		Seconds:   end.Sub(start).Seconds(),
+	}
+	if window, ok := activeWindow(session); ok {
+		ev.Window = window.Name
+		if pane, ok := activePane(window); ok {
+			ev.Pane = pane.ID
+			ev.Title = pane.Title
+			ev.Command = pane.CurrentCmd
+		}
+	}
+	return ev
+}
+
+// trackWindowFlags records tmux's window alert flags and reports flags that
+// were raised since the previous snapshot. Windows seen for the first time
+// never report, so start-up does not replay old flags.
+func (m *Model) trackWindowFlags(now time.Time) []monitorEvent {
+	next := make(map[string]windowFlags)
+	var events []monitorEvent
+	for _, session := range m.sessions {
+		for _, window := range session.Windows {
+			flags := windowFlags{bell: window.BellFlag, activity: window.ActivityFlag, silence: window.SilenceFlag}
+			next[window.ID] = flags
+			prev, seen := m.windowFlags[window.ID]
+			if !seen || !m.tmuxFlagAlerts {
+				continue
+			}
+			raised := []struct {
+				kind     string
+				was, now bool
+			}{
+				{notify.KindBell, prev.bell, flags.bell},
+				{notify.KindActivity, prev.activity, flags.activity},
+				{notify.KindSilence, prev.silence, flags.silence},
+			}
+			for _, r := range raised {
+				if r.was || !r.now {
+					continue
+				}
+				ev := notify.Event{Kind: r.kind, Session: session.Name, Window: window.Name, Pane: window.ID, Title: window.Name, EndedAt: now}
+				if pane, ok := activePane(window); ok {
+					ev.Pane = pane.ID
+					ev.Command = pane.CurrentCmd
+				}
+				events = append(events, monitorEvent{session.ID, ev})
+			}
+		}
+	}
+	m.windowFlags = next
+	return events
+}
+
+// raiseMonitorEvents records each event as an alert on its card, marks the
+// card unread, and delivers it through the notification methods.
+func (m *Model) raiseMonitorEvents(events []monitorEvent) tea.Cmd {
+	var cmds []tea.Cmd
+	for _, e := range events {
+		m.recordAlert(e.event.Kind, e.sessionID, e.event.Pane, e.event.Summary(), e.event.EndedAt)
+		m.appendEvent(timelineEvent{at: e.event.EndedAt, kind: eventAlert, sessionID: e.sessionID, session: e.event.Session, text: e.event.Summary()})
+		if m.highlighted == nil {
+			m.highlighted = make(map[string]struct{})
+		}
+		m.highlighted[e.sessionID] = struct{}{}
+		if e.sessionID != m.focusedSession {
+			if m.unread == nil {
+				m.unread = make(map[string]struct{})
+			}
+			m.unread[e.sessionID] = struct{}{}
+		}
+		cmds = append(cmds, m.deliverNotification(e.event))
+	}
+	return tea.Batch(cmds...)
+}
+
+// monitorPaletteCommands offers toggling both monitors on the cursor or
+// focused session.
+func (m *Model) monitorPaletteCommands() []commandItem {
+	target := m.focusedSession
+	if target == "" {
+		target = m.cursorSession
+	}
+	session, ok := m.sessionByID(target)
+	if !ok {
+		return nil
+	}
+	silenceLabel := "Alert when " + session.Name + " is silent for " + durationLabel(m.silenceThreshold())
+	activityLabel := "Alert when " + session.Name + " prints again"
+	if mon, ok := m.monitors[target]; ok {
+		if mon.silence {
+			silenceLabel = "Stop silence monitor on " + session.Name
+		}
+		if mon.activity {
+			activityLabel = "Stop activity monitor on " + session.Name
+		}
+	}
+	return []commandItem{
+		{
+			label:   silenceLabel,
+			enabled: true,
+			run: func(m *Model) tea.Cmd {
+				return m.toggleSilenceMonitor(target)
+			},
+		},
+		{
+			label:   activityLabel,
+			enabled: true,
+			run: func(m *Model) tea.Cmd {
+				return m.toggleActivityMonitor(target)
+			},
+		},
+	}
+}
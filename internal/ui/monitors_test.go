@@ -0,0 +1,111 @@
+// File monitors_test.go covers silence and activity monitors and tmux window
+// flag alerts.
+package ui
+
+import (
+	"testing"
+	"time"
+
+	"github.com/steipete/tmuxwatch/internal/notify"
+	"github.com/steipete/tmuxwatch/internal/tmux"
+)
+
+// TestSilenceMonitorFiresOncePerQuietStretch waits for the threshold after
+// arming and re-arms when output resumes.
+func TestSilenceMonitorFiresOncePerQuietStretch(t *testing.T) {
+	t.Parallel()
+
+	m := testModel(tmux.Session{
+		ID:           "$1",
+		Name:         "tail",
+		LastActivity: time.Now().Add(-time.Hour),
+		Windows:      []tmux.Window{{ID: "@1", Name: "logs", Active: true, Panes: []tmux.Pane{{ID: "%1", Active: true}}}},
+	})
+	m.silenceAfter = 10 * time.Minute
+	m.toggleSilenceMonitor("$1")
+	armed := m.monitors["$1"].armedAt
+
+	if events := m.checkMonitors(armed.Add(5 * time.Minute)); len(events) != 0 {
+		t.Fatalf("fired before threshold: %+v", events)
+	}
+	events := m.checkMonitors(armed.Add(11 * time.Minute))
+	if len(events) != 1 || events[0].event.Kind != notify.KindSilence {
+		t.Fatalf("events = %+v, want one silence event", events)
+	}
+	if events := m.checkMonitors(armed.Add(30 * time.Minute)); len(events) != 0 {
+		t.Fatalf("silence repeated without new output: %+v", events)
+	}
+
+	m.sessions[0].LastActivity = armed.Add(31 * time.Minute)
+	m.checkMonitors(armed.Add(31 * time.Minute))
+	if events := m.checkMonitors(armed.Add(42 * time.Minute)); len(events) != 1 {
+		t.Fatalf("events = %+v, want silence to re-arm after output", events)
+	}
+}
+
+// TestActivityMonitorNeedsQuietGap fires only for output that follows a
+// quiet period of at least resume_after.
+func TestActivityMonitorNeedsQuietGap(t *testing.T) {
+	t.Parallel()
+
+	base := time.Now().Add(-time.Hour)
+	m := testModel(tmux.Session{
+		ID:           "$1",
+		Name:         "tail",
+		LastActivity: base,
+		Windows:      []tmux.Window{{ID: "@1", Name: "logs", Active: true, Panes: []tmux.Pane{{ID: "%1", Active: true}}}},
+	})
+	m.resumeAfter = time.Minute
+	m.notifyMethods = []string{"toast"}
+	m.toggleActivityMonitor("$1")
+
+	m.sessions[0].LastActivity = base.Add(30 * time.Second)
+	if events := m.checkMonitors(base.Add(30 * time.Second)); len(events) != 0 {
+		t.Fatalf("fired on a short gap: %+v", events)
+	}
+	m.sessions[0].LastActivity = base.Add(5 * time.Minute)
+	events := m.checkMonitors(base.Add(5 * time.Minute))
+	if len(events) != 1 || events[0].event.Kind != notify.KindActivity {
+		t.Fatalf("events = %+v, want one activity event", events)
+	}
+	if got := events[0].event.Duration(); got != 4*time.Minute+30*time.Second {
+		t.Fatalf("quiet span = %s, want 4m30s", got)
+	}
+
+	m.raiseMonitorEvents(events)
+	if !m.isHighlighted("$1") || !m.isUnread("$1") || len(m.alerts) != 1 {
+		t.Fatal("monitor events should highlight, mark unread, and record an alert")
+	}
+
+	m.toggleActivityMonitor("$1")
+	if _, ok := m.monitors["$1"]; ok {
+		t.Fatal("disabling the last monitor should drop it")
+	}
+}
+
+// TestTrackWindowFlagsReportsRaisedFlags alerts on flags that turn on after
+// the first snapshot.
+func TestTrackWindowFlagsReportsRaisedFlags(t *testing.T) {
+	t.Parallel()
+
+	m := testModel(tmux.Session{
+		ID:           "$1",
+		Name:         "tail",
+		LastActivity: time.Time{},
+		Windows:      []tmux.Window{{ID: "@1", Name: "logs", Active: true, Panes: []tmux.Pane{{ID: "%1", Active: true}}}},
+	})
+	m.tmuxFlagAlerts = true
+	m.sessions[0].Windows[0].ActivityFlag = true
+	if events := m.trackWindowFlags(time.Now()); len(events) != 0 {
+		t.Fatalf("first snapshot reported %+v", events)
+	}
+
+	m.sessions[0].Windows[0].BellFlag = true
+	events := m.trackWindowFlags(time.Now())
+	if len(events) != 1 || events[0].event.Kind != notify.KindBell || events[0].event.Pane != "%1" {
+		t.Fatalf("events = %+v, want one bell on %%1", events)
+	}
+	if got := windowFlagLabels(m.sessions[0]); len(got) != 2 || got[0] != "bell" || got[1] != "activity" {
+		t.Fatalf("labels = %v, want [bell activity]", got)
+	}
+}
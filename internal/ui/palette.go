@@ -66,6 +66,9 @@ func (m *Model) handlePaletteKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 func (m *Model) buildCommandItems() []commandItem {
 	var items []commandItem
 
+	items = append(items, m.selectionPaletteCommands()...)
+	items = append(items, m.broadcastPaletteCommands()...)
+	items = append(items, m.composerPaletteCommands()...)
 	items = append(items, m.tabPaletteCommands()...)
 
 	focusedStale := m.isStale(m.focusedSession)
@@ -78,7 +81,7 @@ func (m *Model) buildCommandItems() []commandItem {
 				if !m.isStale(sessionID) {
 					return nil
 				}
-				return killSessionsCmd(m.client, []string{sessionID})
+				return m.confirmKillSessions([]string{sessionID})
 			},
 		})
 	}
@@ -92,23 +95,41 @@ func (m *Model) buildCommandItems() []commandItem {
 			if len(ids) == 0 {
 				return nil
 			}
-			return killSessionsCmd(m.client, ids)
+			return m.confirmKillSessions(ids)
 		},
 	})
 
 	items = append(items, commandItem{
-		label:   "Show hidden sessions",
-		enabled: len(m.hidden) > 0,
+		label:   fmt.Sprintf("Manage hidden sessions (%d)", m.hiddenCount()),
+		enabled: true,
+		run: func(m *Model) tea.Cmd {
+			m.openHiddenManager()
+			return nil
+		},
+	})
+
+	items = append(items, commandItem{
+		label:   "Show all hidden sessions",
+		enabled: m.hiddenCount() > 0,
 		run: func(*Model) tea.Cmd {
-			if len(m.hidden) == 0 {
-				return nil
-			}
-			m.hidden = make(map[string]struct{})
-			m.updatePreviewDimensions(m.filteredSessionCount())
+			m.showHidden()
 			return nil
 		},
 	})
 
+	items = append(items, m.alertPaletteCommands()...)
+	items = append(items, m.timelinePaletteCommands()...)
+	items = append(items, m.archivePaletteCommands()...)
+	items = append(items, m.jumpPaletteCommands()...)
+	items = append(items, m.recordPaletteCommands()...)
+	items = append(items, m.exportPaletteCommands()...)
+	items = append(items, m.replayPaletteCommands()...)
+	items = append(items, m.pinPaletteCommands()...)
+	items = append(items, m.watchPaletteCommands()...)
+	items = append(items, m.monitorPaletteCommands()...)
+	items = append(items, m.sortPaletteCommands()...)
+	items = append(items, m.themePaletteCommands()...)
+
 	items = append(items, commandItem{
 		label:   "Force refresh from tmux",
 		enabled: true,
@@ -146,45 +167,46 @@ func (m *Model) buildCommandItems() []commandItem {
 
 // renderCommandPalette draws the palette overlay content with selection state.
 func (m *Model) renderCommandPalette() string {
+	th := m.colors()
 	title := lipgloss.NewStyle().
 		Bold(true).
-		Foreground(lipgloss.Color("231")).
+		Foreground(lipgloss.Color(th.overlayText)).
 		Render("command palette")
 
 	if len(m.paletteCommands) == 0 {
 		body := lipgloss.NewStyle().
-			Foreground(lipgloss.Color("244")).
+			Foreground(lipgloss.Color(th.overlayMuted)).
 			Render("no actions available")
-		return paletteStyle().Render(lipgloss.JoinVertical(lipgloss.Left, title, body))
+		return paletteStyle(th).Render(lipgloss.JoinVertical(lipgloss.Left, title, body))
 	}
 
 	var lines []string
 	for i, item := range m.paletteCommands {
 		marker := "  "
-		labelStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("250"))
+		labelStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(th.overlayText))
 		if i == m.paletteIndex {
 			marker = "▸ "
 			labelStyle = labelStyle.Bold(true)
 		}
 		if !item.enabled {
-			labelStyle = labelStyle.Foreground(lipgloss.Color("240"))
+			labelStyle = labelStyle.Foreground(lipgloss.Color(th.overlayDisabled))
 		}
 		lines = append(lines, marker+labelStyle.Render(item.label))
 	}
 
 	body := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("244")).
+		Foreground(lipgloss.Color(th.overlayMuted)).
 		Render(strings.Join(lines, "\n"))
 
-	return paletteStyle().Render(lipgloss.JoinVertical(lipgloss.Left, title, body))
+	return paletteStyle(th).Render(lipgloss.JoinVertical(lipgloss.Left, title, body))
 }
 
-func paletteStyle() lipgloss.Style {
+func paletteStyle(th theme) lipgloss.Style {
 	return lipgloss.NewStyle().
 		MarginTop(1).
 		Border(lipgloss.RoundedBorder()).
-		BorderForeground(lipgloss.Color("62")).
-		Background(lipgloss.Color("235")).
+		BorderForeground(lipgloss.Color(th.accent)).
+		Background(lipgloss.Color(th.overlayBg)).
 		Padding(1, 2)
 }
 
@@ -0,0 +1,214 @@
+// File pins.go implements pinned cards and manual card ordering, both keyed
+// by session name and persisted through the state store.
+package ui
+
+import (
+	"slices"
+	"sort"
+
+	tea "charm.land/bubbletea/v2"
+
+	"github.com/steipete/tmuxwatch/internal/store"
+	"github.com/steipete/tmuxwatch/internal/tmux"
+)
+
+// maxOrderEntries caps the remembered manual order so names of long-gone
+// sessions do not accumulate forever.
+const maxOrderEntries = 256
+
+// isPinned reports whether the session with the given ID is pinned.
+func (m *Model) isPinned(sessionID string) bool {
+	session, ok := m.sessionByID(sessionID)
+	if !ok {
+		return false
+	}
+	_, pinned := m.pins[session.Name]
+	return pinned
+}
+
+// togglePin pins or unpins a session and reports the new state.
+func (m *Model) togglePin(sessionID string) (bool, bool) {
+	session, ok := m.sessionByID(sessionID)
+	if !ok {
+		return false, false
+	}
+	if m.pins == nil {
+		m.pins = make(map[string]struct{})
+	}
+	if _, pinned := m.pins[session.Name]; pinned {
+		delete(m.pins, session.Name)
+		return false, true
+	}
+	m.pins[session.Name] = struct{}{}
+	return true, true
+}
+
+// pinCmd toggles the pin on sessionID, announces it, and saves state.
+func (m *Model) pinCmd(sessionID string) tea.Cmd {
+	session, ok := m.sessionByID(sessionID)
+	if !ok {
+		return nil
+	}
+	pinned, ok := m.togglePin(sessionID)
+	if !ok {
+		return nil
+	}
+	m.updatePreviewDimensions(m.filteredSessionCount())
+	verb := "Unpinned "
+	if pinned {
+		verb = "Pinned "
+	}
+	return tea.Batch(showStatusMessage(verb+session.Name), m.saveStateCmd())
+}
+
+// applyPins moves pinned sessions ahead of the rest, keeping relative order.
+func (m *Model) applyPins(sessions []tmux.Session) {
+	if len(m.pins) == 0 {
+		return
+	}
+	slices.SortStableFunc(sessions, func(a, b tmux.Session) int {
+		_, pa := m.pins[a.Name]
+		_, pb := m.pins[b.Name]
+		return boolRank(!pa) - boolRank(!pb)
+	})
+}
+
+// manualRank orders a session by its position in the saved manual order.
+// Unknown sessions follow every ordered one.
+func (m *Model) manualRank(name string) int {
+	if idx := slices.Index(m.manualOrder, name); idx >= 0 {
+		return idx
+	}
+	return len(m.manualOrder)
+}
+
+// moveCursorCard moves the card under the cursor one step in the grid.
+func (m *Model) moveCursorCard(delta int, horizontal bool) tea.Cmd {
+	target, ok := m.cardNeighbor(m.cursorSession, delta, horizontal)
+	if !ok || !m.moveCardTo(m.cursorSession, target) {
+		return nil
+	}
+	return m.saveStateCmd()
+}
+
+// moveCardTo moves src to the position of dst and switches to manual order.
+// Cards cannot cross the pinned boundary or a group header.
+func (m *Model) moveCardTo(src, dst string) bool {
+	if src == "" || dst == "" || src == dst {
+		return false
+	}
+	srcSession, ok := m.sessionByID(src)
+	if !ok {
+		return false
+	}
+	dstSession, ok := m.sessionByID(dst)
+	if !ok {
+		return false
+	}
+	if m.isPinned(src) != m.isPinned(dst) {
+		return false
+	}
+	if m.grouped() {
+		srcGroup, _ := m.groupOfSession(src)
+		dstGroup, _ := m.groupOfSession(dst)
+		if srcGroup != dstGroup {
+			return false
+		}
+	}
+
+	order := make([]string, 0, len(m.sessions)+len(m.manualOrder))
+	for _, session := range m.orderedSessions() {
+		order = append(order, session.Name)
+	}
+	for _, name := range m.manualOrder {
+		if !slices.Contains(order, name) {
+			order = append(order, name)
+		}
+	}
+	si := slices.Index(order, srcSession.Name)
+	di := slices.Index(order, dstSession.Name)
+	if si < 0 || di < 0 {
+		return false
+	}
+	order = slices.Delete(order, si, si+1)
+	order = slices.Insert(order, di, srcSession.Name)
+	if len(order) > maxOrderEntries {
+		order = order[:maxOrderEntries]
+	}
+	m.manualOrder = order
+	m.sortMode = sortManual
+	return true
+}
+
+// pinPaletteCommands offers pinning the cursor or focused session.
+func (m *Model) pinPaletteCommands() []commandItem {
+	target := m.focusedSession
+	if target == "" {
+		target = m.cursorSession
+	}
+	session, ok := m.sessionByID(target)
+	if !ok {
+		return nil
+	}
+	label := "Pin " + session.Name + " to the top"
+	if m.isPinned(target) {
+		label = "Unpin " + session.Name
+	}
+	return []commandItem{{
+		label:   label,
+		enabled: true,
+		run: func(m *Model) tea.Cmd {
+			return m.pinCmd(target)
+		},
+	}}
+}
+
+// persistedState captures the UI state written to the state file.
+func (m *Model) persistedState() store.State {
+	pins := make([]string, 0, len(m.pins))
+	for name := range m.pins {
+		pins = append(pins, name)
+	}
+	sort.Strings(pins)
+	return store.State{
+		Version:  store.CurrentVersion,
+		Pins:     pins,
+		Order:    slices.Clone(m.manualOrder),
+		Sort:     string(m.currentSort()),
+		View:     m.currentView(),
+		Commands: slices.Clone(m.commandHistory),
+	}
+}
+
+// restoreState applies a previously saved state.
+func (m *Model) restoreState(st store.State) {
+	m.pins = make(map[string]struct{}, len(st.Pins))
+	for _, name := range st.Pins {
+		m.pins[name] = struct{}{}
+	}
+	m.manualOrder = slices.Clone(st.Order)
+	if st.Sort != "" {
+		m.setSortMode(sortMode(st.Sort))
+	}
+	m.commandHistory = slices.Clone(st.Commands)
+	if !st.View.IsZero() {
+		view := st.View
+		m.pendingView = &view
+	}
+}
+
+// saveStateCmd writes the current UI state in the background. It is a no-op
+// when no state path is configured.
+func (m *Model) saveStateCmd() tea.Cmd {
+	if m.statePath == "" {
+		return nil
+	}
+	path := m.statePath
+	st := m.persistedState()
+	return func() tea.Msg {
+		if err := store.Save(path, st); err != nil {
+			return statusMsg("Could not save state: " + err.Error())
+		}
+		return nil
+	}
+}
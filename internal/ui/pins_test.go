@@ -0,0 +1,114 @@
+// File pins_test.go covers pinned cards, manual ordering, and state saving.
+package ui
+
+import (
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/steipete/tmuxwatch/internal/config"
+	"github.com/steipete/tmuxwatch/internal/store"
+	"github.com/steipete/tmuxwatch/internal/tmux"
+	zone "github.com/steipete/tmuxwatch/internal/zone"
+)
+
+func pinTestSessions() []tmux.Session {
+	return []tmux.Session{
+		{ID: "$1", Name: "alpha"},
+		{ID: "$2", Name: "beta"},
+		{ID: "$3", Name: "ci"},
+	}
+}
+
+// TestPinnedSessionsSortFirst keeps pinned cards ahead of every sort mode.
+func TestPinnedSessionsSortFirst(t *testing.T) {
+	t.Parallel()
+
+	m := &Model{sessions: pinTestSessions(), sortMode: sortName}
+	if pinned, ok := m.togglePin("$3"); !ok || !pinned {
+		t.Fatalf("togglePin = %v/%v, want pinned", pinned, ok)
+	}
+	if got := sessionIDs(m.filteredSessionsFull()); !slices.Equal(got, []string{"$3", "$1", "$2"}) {
+		t.Fatalf("order = %v, want pinned $3 first", got)
+	}
+	if m.moveCardTo("$1", "$3") {
+		t.Fatal("unpinned card must not move across pinned cards")
+	}
+	if pinned, _ := m.togglePin("$3"); pinned {
+		t.Fatal("second toggle should unpin")
+	}
+}
+
+// TestMoveCursorCardSavesManualOrder reorders by name, switches to manual
+// sort, and writes the result to the state file.
+func TestMoveCursorCardSavesManualOrder(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "state.json")
+	m := &Model{sessions: pinTestSessions(), cardCols: 3, cursorSession: "$1", statePath: path}
+	cmd := m.moveCursorCard(1, true)
+	if cmd == nil {
+		t.Fatal("expected a save command")
+	}
+	if msg := cmd(); msg != nil {
+		t.Fatalf("save returned %v", msg)
+	}
+	if m.currentSort() != sortManual {
+		t.Fatalf("sort = %q, want manual", m.currentSort())
+	}
+	if got := sessionIDs(m.filteredSessionsFull()); !slices.Equal(got, []string{"$2", "$1", "$3"}) {
+		t.Fatalf("order = %v, want [$2 $1 $3]", got)
+	}
+	st, err := store.Load(path)
+	if err != nil {
+		t.Fatalf("Load returned error: %v", err)
+	}
+	if !slices.Equal(st.Order, []string{"beta", "alpha", "ci"}) || st.Sort != "manual" {
+		t.Fatalf("saved state = %+v", st)
+	}
+}
+
+// TestRestoreStateMatchesByName applies saved pins and order to sessions
+// whose tmux IDs changed since the state was written.
+func TestRestoreStateMatchesByName(t *testing.T) {
+	t.Parallel()
+
+	m := &Model{sessions: []tmux.Session{
+		{ID: "$7", Name: "alpha"},
+		{ID: "$8", Name: "new"},
+		{ID: "$9", Name: "ci"},
+	}}
+	m.restoreState(store.State{Pins: []string{"alpha"}, Order: []string{"ci", "gone", "alpha"}, Sort: "manual"})
+	if got := sessionIDs(m.filteredSessionsFull()); !slices.Equal(got, []string{"$7", "$9", "$8"}) {
+		t.Fatalf("order = %v, want pinned alpha, then ci, then unknown", got)
+	}
+	if !m.isPinned("$7") {
+		t.Fatal("alpha should be pinned")
+	}
+}
+
+// TestNewModelKeepsSavedSort restores the saved sort over the configured
+// default and falls back to the config when nothing was saved.
+func TestNewModelKeepsSavedSort(t *testing.T) {
+	zone.NewGlobal()
+
+	tests := []struct {
+		name  string
+		state store.State
+		want  sortMode
+	}{
+		{name: "saved manual order", state: store.State{Sort: "manual", Order: []string{"beta", "alpha"}}, want: sortManual},
+		{name: "nothing saved", want: sortMode(config.DefaultSort)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, err := NewModel(nil, Options{Config: config.Default(), State: tt.state})
+			if err != nil {
+				t.Fatalf("NewModel returned error: %v", err)
+			}
+			if got := m.currentSort(); got != tt.want {
+				t.Fatalf("sort = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,88 @@
+// File prompt.go implements the one-line text prompt used by actions that
+// need input, such as sending a command to the selected sessions.
+package ui
+
+import (
+	"strings"
+
+	"charm.land/bubbles/v2/textinput"
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+)
+
+// promptMaxWidth bounds the prompt box's text width.
+const promptMaxWidth = 72
+
+// prompt asks for one line of text and runs onSubmit with it.
+type prompt struct {
+	title    string
+	hint     string
+	input    textinput.Model
+	onSubmit func(m *Model, value string) tea.Cmd
+}
+
+// openPrompt shows a prompt above everything but the dialog.
+func (m *Model) openPrompt(title, hint, placeholder string, onSubmit func(m *Model, value string) tea.Cmd) tea.Cmd {
+	m.closePalette()
+	m.closeTimeline()
+	m.closeArchive()
+	m.closeHiddenManager()
+	ti := textinput.New()
+	ti.Placeholder = placeholder
+	ti.CharLimit = 1024
+	ti.Prompt = "> "
+	m.prompt = &prompt{title: title, hint: hint, input: ti, onSubmit: onSubmit}
+	return m.prompt.input.Focus()
+}
+
+// closePrompt dismisses the prompt without running it.
+func (m *Model) closePrompt() {
+	m.prompt = nil
+}
+
+// handlePromptKey submits with enter, cancels with esc, and edits the text
+// otherwise. Blank input cancels.
+func (m *Model) handlePromptKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	p := m.prompt
+	switch msg.String() {
+	case "esc":
+		m.closePrompt()
+		return m, nil
+	case "enter":
+		m.closePrompt()
+		value := p.input.Value()
+		if strings.TrimSpace(value) == "" || p.onSubmit == nil {
+			return m, nil
+		}
+		return m, p.onSubmit(m, value)
+	}
+	var cmd tea.Cmd
+	p.input, cmd = p.input.Update(msg)
+	return m, cmd
+}
+
+// handlePromptPaste types pasted text into the prompt.
+func (m *Model) handlePromptPaste(msg tea.PasteMsg) (tea.Model, tea.Cmd) {
+	var cmd tea.Cmd
+	m.prompt.input, cmd = m.prompt.input.Update(msg)
+	return m, cmd
+}
+
+// renderPrompt draws the prompt box.
+func (m *Model) renderPrompt(width int) string {
+	p := m.prompt
+	th := m.colors()
+	textWidth := min(max(width-8, 20), promptMaxWidth)
+	p.input.SetWidth(textWidth - lipgloss.Width(p.input.Prompt))
+	title := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color(th.overlayText))
+	muted := lipgloss.NewStyle().Foreground(lipgloss.Color(th.overlayMuted))
+
+	rows := []string{title.Render(truncate(p.title, textWidth))}
+	if p.hint != "" {
+		rows = append(rows, muted.Render(truncate(p.hint, textWidth)))
+	}
+	rows = append(rows, "", p.input.View(), "", muted.Render("enter runs · esc cancels"))
+	return paletteStyle(th).
+		MarginTop(0).
+		Render(strings.Join(rows, "\n"))
+}
@@ -0,0 +1,39 @@
+// File prompt_test.go covers the text prompt overlay.
+package ui
+
+import (
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+)
+
+// TestPromptSubmitsAndCancels runs the action with the typed text on enter
+// and drops it on esc or blank input.
+func TestPromptSubmitsAndCancels(t *testing.T) {
+	t.Parallel()
+
+	var got []string
+	submit := func(_ *Model, value string) tea.Cmd {
+		got = append(got, value)
+		return nil
+	}
+	m := &Model{}
+	m.openPrompt("Group", "", "name", submit)
+	for _, r := range "ops" {
+		m.Update(tea.KeyPressMsg{Code: r, Text: string(r)})
+	}
+	m.Update(tea.PasteMsg{Content: "-1"})
+	m.Update(tea.KeyPressMsg{Code: tea.KeyEnter})
+	if m.prompt != nil || len(got) != 1 || got[0] != "ops-1" {
+		t.Fatalf("prompt = %v, submitted %q", m.prompt, got)
+	}
+
+	m.openPrompt("Group", "", "name", submit)
+	m.Update(tea.KeyPressMsg{Code: 'x', Text: "x"})
+	m.Update(tea.KeyPressMsg{Code: tea.KeyEscape})
+	m.openPrompt("Group", "", "name", submit)
+	m.Update(tea.KeyPressMsg{Code: tea.KeyEnter})
+	if m.prompt != nil || len(got) != 1 {
+		t.Fatalf("esc and blank input should not submit, got %q", got)
+	}
+}
@@ -0,0 +1,122 @@
+// File recording.go records cards as asciicast files. Each capture of a
+// recorded card's pane becomes a frame timed when the capture arrived, and
+// recorded cards are captured on every tick so the timing stays close to
+// the pane's own.
+package ui
+
+import (
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+
+	"github.com/steipete/tmuxwatch/internal/cast"
+)
+
+// isRecording reports whether the card is being recorded.
+func (m *Model) isRecording(id string) bool {
+	_, ok := m.recordings[id]
+	return ok
+}
+
+// toggleRecording starts recording the card or stops and saves the
+// recording.
+func (m *Model) toggleRecording(sessionID string) tea.Cmd {
+	if m.isRecording(sessionID) {
+		return m.stopRecording(sessionID)
+	}
+	return m.startRecording(sessionID)
+}
+
+// startRecording opens a recording of the card's active pane, starting with
+// the preview already on screen.
+func (m *Model) startRecording(sessionID string) tea.Cmd {
+	session, ok := m.sessionByID(sessionID)
+	if !ok {
+		return nil
+	}
+	if m.recordDir == "" {
+		return showStatusMessage("Recording is unavailable")
+	}
+	pane, ok := m.paneFor(sessionID)
+	if !ok {
+		return nil
+	}
+	now := time.Now()
+	w, err := cast.Create(m.recordDir, session.Name, pane.Width, pane.Height, now)
+	if err != nil {
+		return showStatusMessage(err.Error())
+	}
+	if m.recordings == nil {
+		m.recordings = make(map[string]*cast.Writer)
+	}
+	m.recordings[sessionID] = w
+	if preview, ok := m.previews[sessionID]; ok && preview.lastContent != "" {
+		m.recordFrame(sessionID, now, preview.lastContent)
+	}
+	m.logEvent(eventAction, sessionID, "started recording")
+	return showStatusMessage("Recording " + session.Name)
+}
+
+// stopRecording closes the card's recording.
+func (m *Model) stopRecording(sessionID string) tea.Cmd {
+	w, ok := m.recordings[sessionID]
+	if !ok {
+		return nil
+	}
+	delete(m.recordings, sessionID)
+	if err := w.Close(); err != nil {
+		return showStatusMessage(err.Error())
+	}
+	m.logEvent(eventAction, sessionID, "saved recording "+w.Path())
+	return showStatusMessage("Saved " + w.Path())
+}
+
+// recordFrame appends a capture to the card's recording. A recording that
+// cannot be written is stopped.
+func (m *Model) recordFrame(sessionID string, at time.Time, content string) {
+	w, ok := m.recordings[sessionID]
+	if !ok {
+		return
+	}
+	if err := w.Screen(at, content); err != nil {
+		delete(m.recordings, sessionID)
+		_ = w.Close()
+		m.showToast(err.Error())
+	}
+}
+
+// pruneRecordings saves the recordings of sessions that closed.
+func (m *Model) pruneRecordings() {
+	for id := range m.recordings {
+		if !m.sessionExists(id) {
+			m.stopRecording(id)
+		}
+	}
+}
+
+// StopRecordings saves every open recording. It is called once tmuxwatch
+// exits.
+func (m *Model) StopRecordings() {
+	for id := range m.recordings {
+		m.stopRecording(id)
+	}
+}
+
+// recordPaletteCommands offers starting or stopping a recording of the
+// focused or cursor card.
+func (m *Model) recordPaletteCommands() []commandItem {
+	target := m.jumpSession()
+	session, ok := m.sessionByID(target)
+	if !ok {
+		return nil
+	}
+	label := "Record " + session.Name + " as asciicast"
+	if m.isRecording(target) {
+		label = "Stop recording " + session.Name
+	}
+	return []commandItem{{
+		label:   label,
+		enabled: m.recordDir != "",
+		run:     func(m *Model) tea.Cmd { return m.toggleRecording(target) },
+	}}
+}
@@ -0,0 +1,80 @@
+// File recording_test.go covers recording cards as asciicast files.
+package ui
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+
+	"github.com/steipete/tmuxwatch/internal/tmux"
+)
+
+// TestRecordingToggle starts a recording with R, writes a frame per changed
+// capture, and saves it when R is pressed again.
+func TestRecordingToggle(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	m := testModel(lifecycleSession("$1", "ci", tmux.Pane{ID: "%1", Width: 80, Height: 24}))
+	vp := viewportFor(innerDimension{width: 80, height: 6})
+	m.previews["$1"] = &sessionPreview{viewport: &vp, paneID: "%1", lastContent: "$ make"}
+	m.recordDir = dir
+	m.Update(tea.KeyPressMsg{Code: 'R', Text: "R"})
+	if !m.isRecording("$1") {
+		t.Fatal("R should start recording the cursor card")
+	}
+	if items := m.recordPaletteCommands(); len(items) != 1 || items[0].label != "Stop recording ci" {
+		t.Fatalf("palette = %+v", items)
+	}
+	m.Update(paneContentMsg{sessionID: "$1", paneID: "%1", text: "$ make\nok\n"})
+	m.Update(paneContentMsg{sessionID: "$1", paneID: "%1", err: os.ErrDeadlineExceeded})
+	m.Update(tea.KeyPressMsg{Code: 'R', Text: "R"})
+	if m.isRecording("$1") {
+		t.Fatal("R should stop the recording")
+	}
+
+	files, err := filepath.Glob(filepath.Join(dir, "*-ci.cast"))
+	if err != nil || len(files) != 1 {
+		t.Fatalf("recordings = %v (%v)", files, err)
+	}
+	data, err := os.ReadFile(files[0])
+	if err != nil {
+		t.Fatalf("read: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("recording = %q, want a header and two frames", lines)
+	}
+	if !strings.Contains(lines[0], `"width":80,"height":24`) || !strings.Contains(lines[2], `$ make\r\nok`) {
+		t.Fatalf("recording = %q", lines)
+	}
+	if got := m.timeline[len(m.timeline)-1].text; got != "saved recording "+files[0] {
+		t.Fatalf("timeline = %q", got)
+	}
+}
+
+// TestRecordingStopsWhenSessionCloses saves the recording of a session that
+// left the snapshot, and refuses to record without a recording directory.
+func TestRecordingStopsWhenSessionCloses(t *testing.T) {
+	t.Parallel()
+
+	ci := lifecycleSession("$1", "ci", tmux.Pane{ID: "%1"})
+	m := testModel(ci)
+	m.recordDir = t.TempDir()
+	if m.toggleRecording("$1"); !m.isRecording("$1") {
+		t.Fatal("expected a recording")
+	}
+	m.sessions = nil
+	m.pruneRecordings()
+	if m.isRecording("$1") {
+		t.Fatal("closed sessions should stop recording")
+	}
+
+	m = testModel(ci)
+	if cmd := m.toggleRecording("$1"); cmd == nil || m.isRecording("$1") {
+		t.Fatal("recording without a directory should only report it")
+	}
+}
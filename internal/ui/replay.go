@@ -0,0 +1,435 @@
+// File replay.go implements the replay card, which plays a recorded .cast
+// file or shows a plain log above the live cards. Playback can be paused,
+// sped up, and seeked; search-filter and alert-rule matches are highlighted
+// in the card's viewport.
+package ui
+
+import (
+	"cmp"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"charm.land/bubbles/v2/viewport"
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+	zone "github.com/steipete/tmuxwatch/internal/zone"
+
+	"github.com/steipete/tmuxwatch/internal/cast"
+)
+
+const (
+	// replayFrameInterval is how often a playing replay advances.
+	replayFrameInterval = 50 * time.Millisecond
+	// replaySeekStep is how far left and right seek.
+	replaySeekStep = 5 * time.Second
+	// replayRecent caps the recordings offered in the command palette.
+	replayRecent = 5
+)
+
+// replaySpeeds are the playback speeds + and - step through.
+var replaySpeeds = []float64{0.25, 0.5, 1, 2, 4, 8}
+
+// replay is an open recording or log. Events up to pos have been written to
+// term, whose text the viewport shows.
+type replay struct {
+	name     string
+	log      bool
+	events   []cast.Event
+	duration time.Duration
+	term     *cast.Terminal
+	next     int
+	pos      time.Duration
+	playing  bool
+	speed    int
+	// gen identifies the running playback loop so stale ticks are dropped.
+	gen      int
+	last     time.Time
+	viewport *viewport.Model
+	// query is the search filter the highlights were computed for.
+	query string
+}
+
+// openReplay loads a .cast recording, or any other file as a plain log, into
+// the replay card and starts playing it.
+func (m *Model) openReplay(path string) tea.Cmd {
+	path = expandHome(strings.TrimSpace(path))
+	r := &replay{name: filepath.Base(path), speed: slices.Index(replaySpeeds, 1)}
+	if filepath.Ext(path) == ".cast" {
+		rec, err := cast.Read(path)
+		if err != nil {
+			return showStatusMessage(err.Error())
+		}
+		r.events = rec.Events
+		r.duration = rec.Duration()
+		r.term = cast.NewTerminal(rec.Header.Height)
+	} else {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return showStatusMessage(fmt.Sprintf("open log: %v", err))
+		}
+		r.log = true
+		r.events = []cast.Event{{Data: string(data)}}
+		r.term = cast.NewTerminal(0)
+	}
+	vp := viewportFor(innerDimension{width: m.cardInnerWidth, height: m.cardInnerHeight})
+	r.viewport = &vp
+	if m.replay != nil {
+		m.replay.gen++
+	}
+	m.replay = r
+	m.focusReplay()
+	m.seekReplay(0)
+	m.updatePreviewDimensions(m.filteredSessionCount())
+	if r.log {
+		r.viewport.GotoBottom()
+		return showStatusMessage("Opened " + r.name)
+	}
+	return tea.Batch(m.playReplay(), showStatusMessage("Replaying "+r.name))
+}
+
+// closeReplay removes the replay card.
+func (m *Model) closeReplay() {
+	m.replay = nil
+	m.replayFocused = false
+	m.updatePreviewDimensions(m.filteredSessionCount())
+}
+
+// focusReplay sends keys to the replay card instead of the live cards.
+func (m *Model) focusReplay() {
+	if m.replay == nil {
+		return
+	}
+	m.replayFocused = true
+	m.focusedSession = ""
+	m.leaveInsertMode()
+}
+
+// replayActive reports whether keys go to the replay card. Focusing a live
+// card takes over from it.
+func (m *Model) replayActive() bool {
+	return m.replay != nil && m.replayFocused && m.focusedSession == ""
+}
+
+// replayVisible reports whether the replay card is drawn; the detail view
+// shows only its session.
+func (m *Model) replayVisible() bool {
+	return m.replay != nil && m.viewMode != viewModeDetail
+}
+
+// playReplay starts or resumes playback, restarting a finished replay.
+func (m *Model) playReplay() tea.Cmd {
+	r := m.replay
+	if r == nil || r.duration == 0 {
+		return nil
+	}
+	if r.pos >= r.duration {
+		m.seekReplay(0)
+	}
+	r.playing = true
+	r.gen++
+	r.last = time.Now()
+	return replayTickCmd(r.gen)
+}
+
+// replayTickCmd schedules the next playback step.
+func replayTickCmd(gen int) tea.Cmd {
+	return tea.Tick(replayFrameInterval, func(at time.Time) tea.Msg {
+		return replayTickMsg{gen: gen, at: at}
+	})
+}
+
+// handleReplayTick advances playback by the time since the last step scaled
+// by the speed, and stops at the end.
+func (m *Model) handleReplayTick(msg replayTickMsg) tea.Cmd {
+	r := m.replay
+	if r == nil || !r.playing || msg.gen != r.gen {
+		return nil
+	}
+	elapsed := time.Duration(float64(msg.at.Sub(r.last)) * replaySpeeds[r.speed])
+	r.last = msg.at
+	if elapsed > 0 {
+		m.seekReplay(r.pos + elapsed)
+	}
+	if r.pos >= r.duration {
+		r.playing = false
+		return nil
+	}
+	return replayTickCmd(r.gen)
+}
+
+// seekReplay moves playback to pos. Seeking backwards replays the output
+// from the start.
+func (m *Model) seekReplay(pos time.Duration) {
+	r := m.replay
+	switch {
+	case pos < 0:
+		pos = 0
+	case pos > r.duration:
+		pos = r.duration
+	}
+	if pos < r.pos || r.next == 0 {
+		r.term.Reset()
+		r.next = 0
+	}
+	r.pos = pos
+	for r.next < len(r.events) && r.events[r.next].Time <= pos.Seconds() {
+		r.term.Write(r.events[r.next].Data)
+		r.next++
+	}
+	wasAtBottom := r.viewport.AtBottom()
+	r.viewport.SetContent(r.term.String())
+	if wasAtBottom {
+		r.viewport.GotoBottom()
+	}
+	m.highlightReplay()
+}
+
+// highlightReplay marks search-filter and alert-rule matches in the replay.
+func (m *Model) highlightReplay() {
+	r := m.replay
+	r.query = m.searchQuery
+	r.viewport.ClearHighlights()
+	content := r.viewport.GetContent()
+	var matches [][]int
+	if r.query != "" {
+		lower := strings.ToLower(content)
+		needle := strings.ToLower(r.query)
+		for at := 0; ; {
+			i := strings.Index(lower[at:], needle)
+			if i < 0 {
+				break
+			}
+			matches = append(matches, []int{at + i, at + i + len(needle)})
+			at += i + len(needle)
+		}
+	}
+	for _, rule := range m.alertRules {
+		matches = append(matches, rule.re.FindAllStringIndex(content, -1)...)
+	}
+	slices.SortFunc(matches, func(a, b []int) int { return cmp.Compare(a[0], b[0]) })
+	// The viewport wants ordered ranges that do not overlap.
+	merged := matches[:0]
+	for _, match := range matches {
+		if match[1] <= match[0] {
+			continue
+		}
+		if n := len(merged); n > 0 && match[0] < merged[n-1][1] {
+			merged[n-1][1] = max(merged[n-1][1], match[1])
+			continue
+		}
+		merged = append(merged, match)
+	}
+	if len(merged) > 0 {
+		offset := r.viewport.YOffset()
+		r.viewport.SetHighlights(merged)
+		r.viewport.SetYOffset(offset)
+	}
+}
+
+// handleReplayKey runs playback keys on the focused replay card. Other keys
+// fall through to the global bindings.
+func (m *Model) handleReplayKey(msg tea.KeyMsg) (bool, tea.Cmd) {
+	r := m.replay
+	switch msg.String() {
+	case "space":
+		if r.playing {
+			r.playing = false
+			return true, nil
+		}
+		return true, m.playReplay()
+	case "left", "h":
+		m.seekReplay(r.pos - replaySeekStep)
+	case "right", "l":
+		m.seekReplay(r.pos + replaySeekStep)
+	case "home", "0":
+		m.seekReplay(0)
+	case "end", "$":
+		m.seekReplay(r.duration)
+	case "+", "=":
+		r.speed = min(r.speed+1, len(replaySpeeds)-1)
+	case "-":
+		r.speed = max(r.speed-1, 0)
+	case "up", "k":
+		r.viewport.ScrollUp(1)
+	case "down", "j":
+		r.viewport.ScrollDown(1)
+	case "pgup":
+		r.viewport.PageUp()
+	case "pgdown":
+		r.viewport.PageDown()
+	case "n":
+		r.viewport.HighlightNext()
+	case "N":
+		r.viewport.HighlightPrevious()
+	case "x":
+		m.closeReplay()
+	case "esc":
+		m.replayFocused = false
+	default:
+		return false, nil
+	}
+	return true, nil
+}
+
+// handleReplayMouse focuses the replay card on click, scrolls it with the
+// wheel, and closes it with its close control. Clicks elsewhere unfocus it.
+func (m *Model) handleReplayMouse(msg tea.MouseMsg) bool {
+	if !m.replayVisible() {
+		return false
+	}
+	info := zone.Get(m.zonePrefix + "replay")
+	if info == nil || !info.InBounds(msg) {
+		if _, click := msg.(tea.MouseClickMsg); click {
+			m.replayFocused = false
+		}
+		return false
+	}
+	switch msg.Mouse().Button {
+	case tea.MouseWheelDown:
+		if _, wheel := msg.(tea.MouseWheelMsg); wheel {
+			m.replay.viewport.ScrollDown(scrollStep)
+		}
+	case tea.MouseWheelUp:
+		if _, wheel := msg.(tea.MouseWheelMsg); wheel {
+			m.replay.viewport.ScrollUp(scrollStep)
+		}
+	case tea.MouseLeft:
+		if _, click := msg.(tea.MouseClickMsg); click {
+			if info := zone.Get(m.zonePrefix + "replay-close"); info != nil && info.InBounds(msg) {
+				m.closeReplay()
+				return true
+			}
+			m.focusReplay()
+		}
+	}
+	return true
+}
+
+// renderReplayCard draws the replay card: a header with the playback state,
+// the output, and a progress bar.
+func (m *Model) renderReplayCard(th theme, baseStyle lipgloss.Style, innerWidth, innerHeight int) string {
+	r := m.replay
+	if r.query != m.searchQuery {
+		m.highlightReplay()
+	}
+	r.viewport.HighlightStyle = lipgloss.NewStyle().
+		Foreground(lipgloss.Color(th.accentText)).
+		Background(lipgloss.Color(th.borderAlert))
+	r.viewport.SelectedHighlightStyle = r.viewport.HighlightStyle.
+		Background(lipgloss.Color(th.accent))
+
+	bodyHeight := innerHeight
+	var progress string
+	if !r.log && innerHeight > 1 {
+		bodyHeight--
+		progress = m.renderReplayProgress(th, innerWidth)
+	}
+	if r.viewport.Width() != innerWidth {
+		r.viewport.SetWidth(innerWidth)
+	}
+	if r.viewport.Height() != bodyHeight {
+		r.viewport.SetHeight(bodyHeight)
+	}
+
+	state := "⏸"
+	if r.playing {
+		state = "▶"
+	}
+	label := fmt.Sprintf("%s replay · %s · %gx · %s / %s", state, r.name, replaySpeeds[r.speed], clockDuration(r.pos), clockDuration(r.duration))
+	if r.log {
+		label = "log · " + r.name
+	}
+	closeID := m.zonePrefix + "replay-close"
+	controls := zone.Mark(closeID, closeLabel)
+	space := max(innerWidth-lipgloss.Width(controls), 1)
+	label = truncate(label, space)
+	headerStyle := lipgloss.NewStyle()
+	if m.replayActive() {
+		headerStyle = headerStyle.Foreground(lipgloss.Color(th.headerFocus))
+	}
+	header := headerStyle.Render(label + strings.Repeat(" ", max(space-lipgloss.Width(label), 0)) + controls)
+
+	parts := []string{header, r.viewport.View()}
+	if progress != "" {
+		parts = append(parts, progress)
+	}
+	border := baseStyle.BorderForeground(lipgloss.Color(th.borderHover))
+	if m.replayActive() {
+		border = border.BorderForeground(lipgloss.Color(th.borderFocus))
+	}
+	return zone.Mark(m.zonePrefix+"replay", border.Render(lipgloss.JoinVertical(lipgloss.Left, parts...)))
+}
+
+// renderReplayProgress draws a bar showing how far playback has got.
+func (m *Model) renderReplayProgress(th theme, width int) string {
+	r := m.replay
+	filled := width
+	if r.duration > 0 {
+		filled = int(float64(width) * float64(r.pos) / float64(r.duration))
+	}
+	filled = min(max(filled, 0), width)
+	return lipgloss.NewStyle().Foreground(lipgloss.Color(th.accent)).Render(strings.Repeat("━", filled)) +
+		lipgloss.NewStyle().Foreground(lipgloss.Color(th.helpText)).Render(strings.Repeat("─", width-filled))
+}
+
+// clockDuration formats d as m:ss.
+func clockDuration(d time.Duration) string {
+	s := int(d.Round(time.Second) / time.Second)
+	return fmt.Sprintf("%d:%02d", s/60, s%60)
+}
+
+// expandHome replaces a leading ~/ with the home directory.
+func expandHome(path string) string {
+	if rest, ok := strings.CutPrefix(path, "~/"); ok {
+		if home, err := os.UserHomeDir(); err == nil {
+			return filepath.Join(home, rest)
+		}
+	}
+	return path
+}
+
+// promptReplay asks for a recording or log to open.
+func (m *Model) promptReplay() tea.Cmd {
+	placeholder := "path/to/file.cast"
+	if m.recordDir != "" {
+		placeholder = filepath.Join(m.recordDir, placeholder)
+	}
+	return m.openPrompt("Replay a recording or log", "A .cast file plays back; any other file opens as a log.", placeholder, func(m *Model, path string) tea.Cmd {
+		return m.openReplay(path)
+	})
+}
+
+// replayPaletteCommands offers opening a file, the newest recordings, and
+// closing the replay.
+func (m *Model) replayPaletteCommands() []commandItem {
+	items := []commandItem{{
+		label:   "Replay a recording or log…",
+		enabled: true,
+		run:     func(m *Model) tea.Cmd { return m.promptReplay() },
+	}}
+	if m.recordDir != "" {
+		files, _ := cast.List(m.recordDir)
+		for _, path := range files[:min(len(files), replayRecent)] {
+			items = append(items, commandItem{
+				label:   "Replay " + filepath.Base(path),
+				enabled: true,
+				run:     func(m *Model) tea.Cmd { return m.openReplay(path) },
+			})
+		}
+	}
+	if m.replay != nil {
+		items = append(items, commandItem{
+			label:   "Close replay " + m.replay.name,
+			enabled: true,
+			run: func(m *Model) tea.Cmd {
+				m.closeReplay()
+				return nil
+			},
+		})
+	}
+	return items
+}
@@ -0,0 +1,117 @@
+// File replay_test.go covers the replay card for recordings and logs.
+package ui
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+	zone "github.com/steipete/tmuxwatch/internal/zone"
+
+	"github.com/steipete/tmuxwatch/internal/cast"
+)
+
+// writeTestCast records three frames one second apart and returns the path.
+func writeTestCast(t *testing.T) string {
+	t.Helper()
+	start := time.Unix(1_000, 0)
+	w, err := cast.Create(t.TempDir(), "ci", 80, 24, start)
+	if err != nil {
+		t.Fatalf("Create returned error: %v", err)
+	}
+	for i, text := range []string{"$ make", "$ make\nbuilding", "$ make\nbuilding\nFAIL"} {
+		if err := w.Screen(start.Add(time.Duration(i)*time.Second), text); err != nil {
+			t.Fatalf("Screen returned error: %v", err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("Close returned error: %v", err)
+	}
+	return w.Path()
+}
+
+// TestReplayPlayback plays a recording, honours speed, seeks back, and drops
+// ticks from an earlier playback loop.
+func TestReplayPlayback(t *testing.T) {
+	t.Parallel()
+
+	m := &Model{}
+	if cmd := m.openReplay(writeTestCast(t)); cmd == nil {
+		t.Fatal("opening a recording should start playback")
+	}
+	r := m.replay
+	if r == nil || !r.playing || !m.replayActive() || m.inputMode() != modeReplay {
+		t.Fatalf("replay = %+v, want it playing and focused", r)
+	}
+	if got := r.viewport.GetContent(); got != "$ make" {
+		t.Fatalf("content = %q, want the first frame", got)
+	}
+
+	m.Update(replayTickMsg{gen: r.gen, at: r.last.Add(1500 * time.Millisecond)})
+	if got := r.viewport.GetContent(); got != "$ make\nbuilding" {
+		t.Fatalf("content at 1.5s = %q", got)
+	}
+	m.Update(tea.KeyPressMsg{Code: '+', Text: "+"})
+	if _, cmd := m.Update(replayTickMsg{gen: r.gen, at: r.last.Add(250 * time.Millisecond)}); cmd != nil || r.playing {
+		t.Fatal("playback at 2x should reach the end and stop")
+	}
+	if !strings.HasSuffix(r.viewport.GetContent(), "FAIL") || r.pos != 2*time.Second {
+		t.Fatalf("pos %v content %q", r.pos, r.viewport.GetContent())
+	}
+
+	m.Update(tea.KeyPressMsg{Code: tea.KeyLeft})
+	if r.pos != 0 || r.viewport.GetContent() != "$ make" {
+		t.Fatalf("seek back: pos %v content %q", r.pos, r.viewport.GetContent())
+	}
+	m.Update(tea.KeyPressMsg{Code: tea.KeySpace, Text: " "})
+	stale := r.gen - 1
+	if _, cmd := m.Update(replayTickMsg{gen: stale, at: r.last.Add(time.Second)}); cmd != nil || r.pos != 0 {
+		t.Fatal("ticks from an earlier loop should be ignored")
+	}
+
+	m.Update(tea.KeyPressMsg{Code: 'x', Text: "x"})
+	if m.replay != nil || m.inputMode() != modeNavigate {
+		t.Fatal("x should close the replay")
+	}
+}
+
+// TestReplayLog opens a plain log with colours stripped, highlights search
+// matches, and reports unreadable files without opening a card.
+func TestReplayLog(t *testing.T) {
+	zone.NewGlobal()
+	path := filepath.Join(t.TempDir(), "build.log")
+	if err := os.WriteFile(path, []byte("ok\n\x1b[31mFAIL\x1b[0m pkg/api\n"), 0o600); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	m := &Model{width: 100, height: 30, searchQuery: "fail"}
+	m.openReplay(path)
+	r := m.replay
+	if r == nil || r.playing || r.viewport.GetContent() != "ok\nFAIL pkg/api" {
+		t.Fatalf("log replay = %+v", r)
+	}
+	card := m.renderReplayCard(m.colors(), lipgloss.NewStyle().Border(lipgloss.RoundedBorder()), 60, 6)
+	if !strings.Contains(card, "log · build.log") || !strings.Contains(card, "pkg/api") {
+		t.Fatalf("card does not show the log:\n%s", card)
+	}
+	if r.query != "fail" {
+		t.Fatalf("highlights computed for %q, want the search filter", r.query)
+	}
+
+	m.Update(tea.KeyPressMsg{Code: tea.KeyEscape})
+	if m.replayActive() || m.replay == nil {
+		t.Fatal("esc should leave the replay card open but unfocused")
+	}
+	m.Update(tea.KeyPressMsg{Code: 'P', Text: "P"})
+	if !m.replayActive() {
+		t.Fatal("P should focus the open replay")
+	}
+
+	m = &Model{}
+	if cmd := m.openReplay(filepath.Join(t.TempDir(), "missing.cast")); cmd == nil || m.replay != nil {
+		t.Fatal("a missing recording should only be reported")
+	}
+}
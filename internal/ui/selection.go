@@ -0,0 +1,254 @@
+// File selection.go implements multi-select: cards chosen with space,
+// shift-click, or select-matching, and the bulk actions the command palette
+// runs on them. Selections are keyed by session id, so they survive
+// refreshes and drop out when a session closes.
+package ui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	tea "charm.land/bubbletea/v2"
+
+	"github.com/steipete/tmuxwatch/internal/tmux"
+)
+
+// isSelected reports whether the card is part of the selection.
+func (m *Model) isSelected(id string) bool {
+	_, ok := m.selected[id]
+	return ok
+}
+
+// toggleSelected adds the card to the selection or removes it.
+func (m *Model) toggleSelected(id string) {
+	if id == "" {
+		return
+	}
+	if m.isSelected(id) {
+		delete(m.selected, id)
+		return
+	}
+	if m.selected == nil {
+		m.selected = make(map[string]struct{})
+	}
+	m.selected[id] = struct{}{}
+}
+
+// selectMatching selects every card matching the search filter, or clears
+// the selection when they are all selected already.
+func (m *Model) selectMatching() int {
+	sessions := m.orderedSessions()
+	all := len(sessions) > 0
+	for _, session := range sessions {
+		if !m.isSelected(session.ID) {
+			all = false
+			break
+		}
+	}
+	if all {
+		m.clearSelection()
+		return 0
+	}
+	for _, session := range sessions {
+		if !m.isSelected(session.ID) {
+			m.toggleSelected(session.ID)
+		}
+	}
+	return len(sessions)
+}
+
+// clearSelection deselects every card.
+func (m *Model) clearSelection() {
+	m.selected = nil
+}
+
+// pruneSelection drops sessions that left the snapshot.
+func (m *Model) pruneSelection() {
+	for id := range m.selected {
+		if !m.sessionExists(id) {
+			delete(m.selected, id)
+		}
+	}
+}
+
+// selectedIDs lists the visible selected sessions in snapshot order.
+func (m *Model) selectedIDs() []string {
+	var ids []string
+	for _, session := range m.sessions {
+		if m.isSelected(session.ID) && !m.isHidden(session.ID) {
+			ids = append(ids, session.ID)
+		}
+	}
+	return ids
+}
+
+// selectionSummary counts the selection for the title bar.
+func (m *Model) selectionSummary() string {
+	if n := len(m.selectedIDs()); n > 0 {
+		return fmt.Sprintf("%d selected", n)
+	}
+	return ""
+}
+
+// hideSelected hides every selected card and clears the selection.
+func (m *Model) hideSelected() tea.Cmd {
+	ids := m.selectedIDs()
+	for _, id := range ids {
+		m.hideSession(id)
+	}
+	m.clearSelection()
+	m.updatePreviewDimensions(m.filteredSessionCount())
+	return showStatusMessage(fmt.Sprintf("Hid %d sessions", len(ids)))
+}
+
+// collapseSelected collapses the selected cards, or expands them when they
+// are all collapsed already.
+func (m *Model) collapseSelected() {
+	ids := m.selectedIDs()
+	expand := len(ids) > 0
+	for _, id := range ids {
+		if !m.isCollapsed(id) {
+			expand = false
+			break
+		}
+	}
+	if m.collapsed == nil {
+		m.collapsed = make(map[string]struct{})
+	}
+	for _, id := range ids {
+		if expand {
+			delete(m.collapsed, id)
+		} else {
+			m.collapsed[id] = struct{}{}
+		}
+	}
+	m.updatePreviewDimensions(m.filteredSessionCount())
+}
+
+// selectedPanes returns the active pane of each selected session that can
+// still receive input.
+func (m *Model) selectedPanes() []string {
+	var panes []string
+	for _, id := range m.selectedIDs() {
+		if pane, ok := m.paneFor(id); ok && !pane.Dead {
+			panes = append(panes, pane.ID)
+		}
+	}
+	return panes
+}
+
+// sendKeysToSelected sends tmux key names such as "C-c" or "q Enter" to
+// every selected session's active pane.
+func (m *Model) sendKeysToSelected(keys string) tea.Cmd {
+	panes := m.selectedPanes()
+	if len(panes) == 0 {
+		return showStatusMessage("No selected pane can receive input")
+	}
+	for _, id := range m.selectedIDs() {
+		m.logEvent(eventAction, id, "sent keys "+keys)
+	}
+	send := func(ctx context.Context, client *tmux.Client, paneID string) error {
+		return client.SendKeys(ctx, paneID, strings.Fields(keys)...)
+	}
+	return tea.Batch(
+		panesCmd(m.client, panes, send),
+		showStatusMessage(fmt.Sprintf("Sent keys %s to %d panes", keys, len(panes))),
+	)
+}
+
+// groupSelected sets the @group option on every pane of the selected
+// sessions and switches the overview to group by it.
+func (m *Model) groupSelected(name string) tea.Cmd {
+	name = strings.TrimSpace(name)
+	var panes []string
+	ids := m.selectedIDs()
+	for _, id := range ids {
+		session, _ := m.sessionByID(id)
+		for _, window := range session.Windows {
+			for _, pane := range window.Panes {
+				panes = append(panes, pane.ID)
+			}
+		}
+		m.logEvent(eventAction, id, "grouped as "+name)
+	}
+	m.setGroupMode(groupVar)
+	return tea.Batch(
+		tea.Sequence(
+			panesCmd(m.client, panes, func(ctx context.Context, client *tmux.Client, paneID string) error {
+				return client.SetPaneOption(ctx, paneID, tmux.GroupOption, name)
+			}),
+			fetchSnapshotCmd(m.client),
+		),
+		showStatusMessage(fmt.Sprintf("Grouped %d sessions as %s", len(ids), name)),
+	)
+}
+
+// selectionPaletteCommands offers selection helpers and, with a selection,
+// the bulk actions.
+func (m *Model) selectionPaletteCommands() []commandItem {
+	n := len(m.selectedIDs())
+	items := []commandItem{{
+		label:   "Select all matching cards",
+		enabled: len(m.orderedSessions()) > 0,
+		run: func(m *Model) tea.Cmd {
+			m.selectMatching()
+			return nil
+		},
+	}}
+	if n == 0 {
+		return items
+	}
+	bulk := []commandItem{
+		{
+			label:   fmt.Sprintf("Hide selected (%d)", n),
+			enabled: true,
+			run:     func(m *Model) tea.Cmd { return m.hideSelected() },
+		},
+		{
+			label:   fmt.Sprintf("Collapse or expand selected (%d)", n),
+			enabled: true,
+			run: func(m *Model) tea.Cmd {
+				m.collapseSelected()
+				return nil
+			},
+		},
+		{
+			label:   fmt.Sprintf("Kill selected (%d)", n),
+			enabled: true,
+			run:     func(m *Model) tea.Cmd { return m.confirmKillSessions(m.selectedIDs()) },
+		},
+		{
+			label:   fmt.Sprintf("Send command to selected (%d)", n),
+			enabled: len(m.selectedPanes()) > 0,
+			run:     func(m *Model) tea.Cmd { return m.openComposer("") },
+		},
+		{
+			label:   fmt.Sprintf("Send keys to selected (%d)", n),
+			enabled: len(m.selectedPanes()) > 0,
+			run: func(m *Model) tea.Cmd {
+				return m.openPrompt(fmt.Sprintf("Send keys to %d sessions", n), "tmux key names separated by spaces.", "C-c", func(m *Model, text string) tea.Cmd {
+					return m.sendKeysToSelected(text)
+				})
+			},
+		},
+		{
+			label:   fmt.Sprintf("Save selected as group (%d)", n),
+			enabled: true,
+			run: func(m *Model) tea.Cmd {
+				return m.openPrompt(fmt.Sprintf("Group %d sessions", n), "Sets @group on their panes.", "group name", func(m *Model, name string) tea.Cmd {
+					return m.groupSelected(name)
+				})
+			},
+		},
+		{
+			label:   "Clear selection",
+			enabled: true,
+			run: func(m *Model) tea.Cmd {
+				m.clearSelection()
+				return nil
+			},
+		},
+	}
+	return append(bulk, items...)
+}
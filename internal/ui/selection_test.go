@@ -0,0 +1,105 @@
+// File selection_test.go covers multi-select and the bulk actions.
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+
+	"github.com/steipete/tmuxwatch/internal/tmux"
+)
+
+// selectionTestSessions returns two live API sessions and a dead docs one.
+func selectionTestSessions() []tmux.Session {
+	return []tmux.Session{
+		lifecycleSession("$1", "api-server", tmux.Pane{ID: "%1"}),
+		lifecycleSession("$2", "api-worker", tmux.Pane{ID: "%2"}),
+		lifecycleSession("$3", "docs", tmux.Pane{ID: "%3", Dead: true}),
+	}
+}
+
+// TestSelectionKeys toggles the cursor card with space, selects everything
+// matching the filter with ctrl+a, and clears with esc.
+func TestSelectionKeys(t *testing.T) {
+	t.Parallel()
+
+	m := testModel(selectionTestSessions()...)
+	m.Update(tea.KeyPressMsg{Code: tea.KeySpace, Text: " "})
+	if got := m.selectedIDs(); len(got) != 1 || got[0] != "$1" {
+		t.Fatalf("selected = %v after space", got)
+	}
+	m.Update(tea.KeyPressMsg{Code: tea.KeySpace, Text: " "})
+	if len(m.selectedIDs()) != 0 {
+		t.Fatal("space should deselect a selected card")
+	}
+
+	m.searchQuery = "api"
+	m.Update(tea.KeyPressMsg{Code: 'a', Mod: tea.ModCtrl})
+	if got := strings.Join(m.selectedIDs(), ","); got != "$1,$2" {
+		t.Fatalf("selected = %s, want the cards matching the filter", got)
+	}
+	if m.selectionSummary() != "2 selected" {
+		t.Fatalf("summary = %q", m.selectionSummary())
+	}
+	m.Update(tea.KeyPressMsg{Code: tea.KeyEscape})
+	if len(m.selectedIDs()) != 0 || m.searchQuery != "api" {
+		t.Fatal("esc should clear the selection before the filter")
+	}
+}
+
+// TestSelectionSurvivesRefresh keeps selections by session id and drops
+// sessions that closed.
+func TestSelectionSurvivesRefresh(t *testing.T) {
+	t.Parallel()
+
+	m := testModel(selectionTestSessions()...)
+	m.toggleSelected("$1")
+	m.toggleSelected("$2")
+	m.sessions = m.sessions[1:]
+	m.pruneSelection()
+	if !m.isSelected("$2") || m.isSelected("$1") {
+		t.Fatalf("selected = %v", m.selected)
+	}
+}
+
+// TestBulkActions applies collapse, hide, and send to the selection only.
+func TestBulkActions(t *testing.T) {
+	t.Parallel()
+
+	m := testModel(selectionTestSessions()...)
+	m.toggleSelected("$2")
+	m.toggleSelected("$3")
+
+	m.collapseSelected()
+	if !m.isCollapsed("$2") || !m.isCollapsed("$3") || m.isCollapsed("$1") {
+		t.Fatal("collapse should apply to the selected cards")
+	}
+	m.collapseSelected()
+	if m.isCollapsed("$2") || m.isCollapsed("$3") {
+		t.Fatal("a second collapse should expand them again")
+	}
+
+	if panes := m.selectedPanes(); len(panes) != 1 || panes[0] != "%2" {
+		t.Fatalf("selectedPanes = %v, want only the live pane", panes)
+	}
+	if m.sendKeysToSelected("C-c") == nil {
+		t.Fatal("sending keys should return a command")
+	}
+	if last := m.timeline[len(m.timeline)-1]; last.text != "sent keys C-c" {
+		t.Fatalf("timeline = %q", last.text)
+	}
+
+	var labels []string
+	for _, item := range m.selectionPaletteCommands() {
+		labels = append(labels, item.label)
+	}
+	if !strings.Contains(strings.Join(labels, "|"), "Kill selected (2)|Send command to selected (2)") {
+		t.Fatalf("palette = %v", labels)
+	}
+
+	m.hideSelected()
+	if !m.isHidden("$2") || !m.isHidden("$3") || m.isHidden("$1") || len(m.selected) != 0 {
+		t.Fatal("hide should hide the selection and clear it")
+	}
+}
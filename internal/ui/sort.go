@@ -0,0 +1,525 @@
+// File sort.go orders the overview grid and splits it into collapsible
+// groups.
+package ui
+
+import (
+	"cmp"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	tea "charm.land/bubbletea/v2"
+
+	"github.com/steipete/tmuxwatch/internal/tmux"
+)
+
+// sortMode selects how overview cards are ordered.
+type sortMode string
+
+const (
+	sortTmux     sortMode = "tmux"
+	sortManual   sortMode = "manual"
+	sortName     sortMode = "name"
+	sortCreated  sortMode = "created"
+	sortActivity sortMode = "activity"
+	sortFailures sortMode = "failures"
+	sortStale    sortMode = "stale"
+	sortCPU      sortMode = "cpu"
+)
+
+// sortModes lists sort modes in cycling order.
+var sortModes = []sortMode{sortTmux, sortManual, sortName, sortCreated, sortActivity, sortFailures, sortStale, sortCPU}
+
+func (s sortMode) label() string {
+	switch s {
+	case sortManual:
+		return "manual"
+	case sortName:
+		return "name"
+	case sortCreated:
+		return "oldest first"
+	case sortActivity:
+		return "recent activity"
+	case sortFailures:
+		return "failures first"
+	case sortStale:
+		return "stale last"
+	case sortCPU:
+		return "CPU use"
+	default:
+		return "tmux order"
+	}
+}
+
+// groupMode selects how overview cards are grouped.
+type groupMode string
+
+const (
+	groupNone     groupMode = "none"
+	groupPrefix   groupMode = "prefix"
+	groupRepo     groupMode = "repo"
+	groupVar      groupMode = "var"
+	groupAttached groupMode = "attached"
+)
+
+// groupModes lists group modes in cycling order.
+var groupModes = []groupMode{groupNone, groupPrefix, groupRepo, groupVar, groupAttached}
+
+func (g groupMode) label() string {
+	switch g {
+	case groupPrefix:
+		return "name prefix"
+	case groupRepo:
+		return "git repo"
+	case groupVar:
+		return "@group"
+	case groupAttached:
+		return "attached"
+	default:
+		return "none"
+	}
+}
+
+// groupFallbackLabels names the bucket for sessions without a group key.
+var groupFallbackLabels = map[groupMode]string{
+	groupPrefix: "other",
+	groupRepo:   "no repo",
+	groupVar:    "ungrouped",
+}
+
+// prefixSeparators end the name prefix used by groupPrefix.
+const prefixSeparators = "-_.:/ "
+
+// sessionGroup is one header-delimited block of cards in the overview grid.
+type sessionGroup struct {
+	key       string
+	label     string
+	sessions  []tmux.Session
+	collapsed bool
+}
+
+// groupBounds records the mouse zone of a rendered group header.
+type groupBounds struct {
+	key    string
+	zoneID string
+}
+
+// currentSort returns the active sort mode.
+func (m *Model) currentSort() sortMode {
+	if m.sortMode == "" {
+		return sortTmux
+	}
+	return m.sortMode
+}
+
+// currentGroup returns the active group mode.
+func (m *Model) currentGroup() groupMode {
+	if m.groupMode == "" {
+		return groupNone
+	}
+	return m.groupMode
+}
+
+// grouped reports whether the grid renders group headers.
+func (m *Model) grouped() bool {
+	return m.currentGroup() != groupNone && m.viewMode != viewModeDetail
+}
+
+// setSortMode switches the sort order, ignoring unknown modes.
+func (m *Model) setSortMode(mode sortMode) bool {
+	if !slices.Contains(sortModes, mode) {
+		return false
+	}
+	m.sortMode = mode
+	return true
+}
+
+// setGroupMode switches the grouping, ignoring unknown modes.
+func (m *Model) setGroupMode(mode groupMode) bool {
+	if !slices.Contains(groupModes, mode) {
+		return false
+	}
+	m.groupMode = mode
+	return true
+}
+
+// cycleSort advances to the next sort mode.
+func (m *Model) cycleSort() sortMode {
+	idx := slices.Index(sortModes, m.currentSort())
+	m.sortMode = sortModes[(idx+1)%len(sortModes)]
+	return m.sortMode
+}
+
+// cycleGroup advances to the next group mode.
+func (m *Model) cycleGroup() groupMode {
+	idx := slices.Index(groupModes, m.currentGroup())
+	m.groupMode = groupModes[(idx+1)%len(groupModes)]
+	return m.groupMode
+}
+
+// sortSessions returns a copy of sessions ordered by the active sort mode,
+// with pinned sessions first. Ties keep tmux order.
+func (m *Model) sortSessions(sessions []tmux.Session) []tmux.Session {
+	out := slices.Clone(sessions)
+	switch m.currentSort() {
+	case sortManual:
+		slices.SortStableFunc(out, func(a, b tmux.Session) int {
+			return cmp.Compare(m.manualRank(a.Name), m.manualRank(b.Name))
+		})
+	case sortName:
+		slices.SortStableFunc(out, func(a, b tmux.Session) int {
+			return cmp.Compare(strings.ToLower(a.Name), strings.ToLower(b.Name))
+		})
+	case sortCreated:
+		slices.SortStableFunc(out, func(a, b tmux.Session) int {
+			return a.CreatedAt.Compare(b.CreatedAt)
+		})
+	case sortActivity:
+		slices.SortStableFunc(out, func(a, b tmux.Session) int {
+			return m.sessionActivity(b).Compare(m.sessionActivity(a))
+		})
+	case sortFailures:
+		slices.SortStableFunc(out, func(a, b tmux.Session) int {
+			return cmp.Compare(failureRank(a), failureRank(b))
+		})
+	case sortStale:
+		slices.SortStableFunc(out, func(a, b tmux.Session) int {
+			return cmp.Compare(boolRank(m.isStale(a.ID)), boolRank(m.isStale(b.ID)))
+		})
+	case sortCPU:
+		slices.SortStableFunc(out, func(a, b tmux.Session) int {
+			return cmp.Compare(m.cpuUsage[b.ID], m.cpuUsage[a.ID])
+		})
+	}
+	m.applyPins(out)
+	return out
+}
+
+// failureRank orders sessions with a pane that exited non-zero first.
+func failureRank(session tmux.Session) int {
+	for _, window := range session.Windows {
+		for _, pane := range window.Panes {
+			if pane.Dead && pane.DeadStatus != 0 {
+				return 0
+			}
+		}
+	}
+	return 1
+}
+
+func boolRank(v bool) int {
+	if v {
+		return 1
+	}
+	return 0
+}
+
+// sessionGroups filters, sorts, and groups the snapshot for the overview.
+// Collapsed groups keep their sessions so headers can show a count.
+func (m *Model) sessionGroups() []sessionGroup {
+	sessions := m.sortSessions(m.matchingSessions())
+	mode := m.currentGroup()
+	if mode == groupNone {
+		if len(sessions) == 0 {
+			return nil
+		}
+		return []sessionGroup{{sessions: sessions}}
+	}
+	index := make(map[string]int)
+	var groups []sessionGroup
+	for _, session := range sessions {
+		key, label := m.groupKey(mode, session)
+		idx, ok := index[key]
+		if !ok {
+			idx = len(groups)
+			index[key] = idx
+			groups = append(groups, sessionGroup{
+				key:       key,
+				label:     label,
+				collapsed: m.isGroupCollapsed(key),
+			})
+		}
+		groups[idx].sessions = append(groups[idx].sessions, session)
+	}
+	slices.SortStableFunc(groups, func(a, b sessionGroup) int {
+		switch {
+		case a.label == b.label:
+			return 0
+		case a.key == "":
+			return 1
+		case b.key == "":
+			return -1
+		}
+		return cmp.Compare(strings.ToLower(a.label), strings.ToLower(b.label))
+	})
+	return groups
+}
+
+// displayGroups returns the groups rendered in the grid, honouring detail
+// view where only the maximised session is shown.
+func (m *Model) displayGroups() []sessionGroup {
+	if m.viewMode == viewModeDetail && m.detailSession != "" {
+		sessions := m.filteredSessions()
+		if len(sessions) == 0 {
+			return nil
+		}
+		return []sessionGroup{{sessions: sessions}}
+	}
+	return m.sessionGroups()
+}
+
+// groupKey derives the grouping key and header label for session. Sessions
+// without a key share the mode's fallback bucket.
+func (m *Model) groupKey(mode groupMode, session tmux.Session) (string, string) {
+	var key, label string
+	switch mode {
+	case groupPrefix:
+		if idx := strings.IndexAny(session.Name, prefixSeparators); idx > 0 {
+			key = session.Name[:idx]
+			label = session.Name[:idx+1] + "*"
+		}
+	case groupRepo:
+		if pane, ok := m.paneFor(session.ID); ok {
+			if root := m.repoRoot(pane.CurrentPath); root != "" {
+				key = root
+				label = filepath.Base(root)
+			}
+		}
+	case groupVar:
+		key = sessionGroupVar(session)
+		label = key
+	case groupAttached:
+		if session.Attached {
+			return "attached", "attached"
+		}
+		return "detached", "detached"
+	}
+	if key == "" {
+		return "", groupFallbackLabels[mode]
+	}
+	return key, label
+}
+
+// sessionGroupVar returns the @group option of the active pane, falling back
+// to the first pane that sets it.
+func sessionGroupVar(session tmux.Session) string {
+	if window, ok := activeWindow(session); ok {
+		if pane, ok := activePane(window); ok && pane.Group != "" {
+			return pane.Group
+		}
+	}
+	for _, window := range session.Windows {
+		for _, pane := range window.Panes {
+			if pane.Group != "" {
+				return pane.Group
+			}
+		}
+	}
+	return ""
+}
+
+// repoRoot returns the git work tree containing dir, or "" until
+// resolveRepoRootsCmd has looked it up.
+func (m *Model) repoRoot(dir string) string {
+	return m.repoRoots[dir]
+}
+
+// repoRootsCmd resolves the repo roots of pane directories not seen before.
+// It returns nil unless grouping by repo.
+func (m *Model) repoRootsCmd() tea.Cmd {
+	if m.currentGroup() != groupRepo {
+		return nil
+	}
+	var dirs []string
+	for _, session := range m.sessions {
+		for _, window := range session.Windows {
+			for _, pane := range window.Panes {
+				if _, ok := m.repoRoots[pane.CurrentPath]; ok || pane.CurrentPath == "" {
+					continue
+				}
+				if m.repoRoots == nil {
+					m.repoRoots = make(map[string]string)
+				}
+				// Cache the miss now so the lookup runs only once.
+				m.repoRoots[pane.CurrentPath] = ""
+				dirs = append(dirs, pane.CurrentPath)
+			}
+		}
+	}
+	if len(dirs) == 0 {
+		return nil
+	}
+	return resolveRepoRootsCmd(dirs)
+}
+
+// handleRepoRoots stores resolved repo roots and regroups the grid.
+func (m *Model) handleRepoRoots(msg repoRootsMsg) {
+	if m.repoRoots == nil {
+		m.repoRoots = make(map[string]string, len(msg.roots))
+	}
+	maps.Copy(m.repoRoots, msg.roots)
+	m.updatePreviewDimensions(m.filteredSessionCount())
+}
+
+// findRepoRoot walks up from dir until it finds a .git entry.
+func findRepoRoot(dir string) string {
+	dir = filepath.Clean(dir)
+	for {
+		if _, err := os.Stat(filepath.Join(dir, ".git")); err == nil {
+			return dir
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return ""
+		}
+		dir = parent
+	}
+}
+
+// groupStateKey scopes collapse state to the active group mode.
+func (m *Model) groupStateKey(key string) string {
+	return string(m.currentGroup()) + ":" + key
+}
+
+// isGroupCollapsed reports whether the group with key is collapsed.
+func (m *Model) isGroupCollapsed(key string) bool {
+	_, ok := m.collapsedGroups[m.groupStateKey(key)]
+	return ok
+}
+
+// toggleGroup collapses or expands the group with key.
+func (m *Model) toggleGroup(key string) {
+	if m.collapsedGroups == nil {
+		m.collapsedGroups = make(map[string]struct{})
+	}
+	state := m.groupStateKey(key)
+	if _, ok := m.collapsedGroups[state]; ok {
+		delete(m.collapsedGroups, state)
+	} else {
+		m.collapsedGroups[state] = struct{}{}
+	}
+	m.updatePreviewDimensions(m.filteredSessionCount())
+}
+
+// expandGroups clears collapse state for every group.
+func (m *Model) expandGroups() bool {
+	if len(m.collapsedGroups) == 0 {
+		return false
+	}
+	m.collapsedGroups = make(map[string]struct{})
+	m.updatePreviewDimensions(m.filteredSessionCount())
+	return true
+}
+
+// groupOfSession returns the key of the group containing sessionID.
+func (m *Model) groupOfSession(sessionID string) (string, bool) {
+	for _, group := range m.sessionGroups() {
+		for _, session := range group.sessions {
+			if session.ID == sessionID {
+				return group.key, true
+			}
+		}
+	}
+	return "", false
+}
+
+// groupSizes returns the number of visible cards per group, or nil when the
+// grid is not grouped.
+func (m *Model) groupSizes() []int {
+	if !m.grouped() {
+		return nil
+	}
+	groups := m.displayGroups()
+	sizes := make([]int, len(groups))
+	for i, group := range groups {
+		if !group.collapsed {
+			sizes[i] = len(group.sessions)
+		}
+	}
+	return sizes
+}
+
+// cardRows lays visible session IDs out in grid rows. Each group starts on a
+// fresh row so cursor movement follows what is drawn.
+func (m *Model) cardRows() [][]string {
+	cols := max(1, m.cardCols)
+	var rows [][]string
+	for _, group := range m.displayGroups() {
+		if group.collapsed {
+			continue
+		}
+		for i, session := range group.sessions {
+			if i%cols == 0 {
+				rows = append(rows, make([]string, 0, cols))
+			}
+			rows[len(rows)-1] = append(rows[len(rows)-1], session.ID)
+		}
+	}
+	return rows
+}
+
+// sortChangedCmd announces a new sort mode and starts CPU sampling when the
+// mode needs it.
+func (m *Model) sortChangedCmd(mode sortMode) tea.Cmd {
+	cmd := showStatusMessage("Sort by " + mode.label())
+	if mode == sortCPU {
+		return tea.Batch(cmd, fetchCPUCmd(m.cpuRoots()))
+	}
+	return cmd
+}
+
+// groupChangedCmd announces a new group mode and resolves repo roots when
+// the mode needs them.
+func (m *Model) groupChangedCmd(mode groupMode) tea.Cmd {
+	return tea.Batch(showStatusMessage("Group by "+mode.label()), m.repoRootsCmd())
+}
+
+// sortPaletteCommands offers cycling sort and group modes from the palette.
+func (m *Model) sortPaletteCommands() []commandItem {
+	return []commandItem{
+		{
+			label:   fmt.Sprintf("Cycle sort (now: %s)", m.currentSort().label()),
+			enabled: true,
+			run: func(m *Model) tea.Cmd {
+				mode := m.cycleSort()
+				m.updatePreviewDimensions(m.filteredSessionCount())
+				return m.sortChangedCmd(mode)
+			},
+		},
+		{
+			label:   fmt.Sprintf("Cycle grouping (now: %s)", m.currentGroup().label()),
+			enabled: true,
+			run: func(m *Model) tea.Cmd {
+				mode := m.cycleGroup()
+				m.updatePreviewDimensions(m.filteredSessionCount())
+				return m.groupChangedCmd(mode)
+			},
+		},
+		{
+			label:   "Expand all groups",
+			enabled: len(m.collapsedGroups) > 0,
+			run: func(m *Model) tea.Cmd {
+				m.expandGroups()
+				return nil
+			},
+		},
+	}
+}
+
+// cpuRoots collects pane process IDs per session for CPU sampling.
+func (m *Model) cpuRoots() map[string][]int {
+	roots := make(map[string][]int, len(m.sessions))
+	for _, session := range m.sessions {
+		for _, window := range session.Windows {
+			for _, pane := range window.Panes {
+				if pane.PID > 0 && !pane.Dead {
+					roots[session.ID] = append(roots[session.ID], pane.PID)
+				}
+			}
+		}
+	}
+	return roots
+}
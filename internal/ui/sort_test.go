@@ -0,0 +1,192 @@
+// File sort_test.go covers overview sort modes, grouping, and grouped cursor
+// navigation.
+package ui
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/steipete/tmuxwatch/internal/tmux"
+)
+
+func sessionIDs(sessions []tmux.Session) []string {
+	ids := make([]string, 0, len(sessions))
+	for _, session := range sessions {
+		ids = append(ids, session.ID)
+	}
+	return ids
+}
+
+// TestSortSessionsModes orders sessions for every sort mode.
+func TestSortSessionsModes(t *testing.T) {
+	t.Parallel()
+
+	base := time.Unix(1_700_000_000, 0)
+	sessions := []tmux.Session{
+		{ID: "$1", Name: "web", CreatedAt: base.Add(2 * time.Hour), LastActivity: base},
+		{ID: "$2", Name: "api", CreatedAt: base, LastActivity: base.Add(time.Hour), Windows: []tmux.Window{{
+			Panes: []tmux.Pane{{ID: "%2", Dead: true, DeadStatus: 1}},
+		}}},
+		{ID: "$3", Name: "Build", CreatedAt: base.Add(time.Hour), LastActivity: base.Add(2 * time.Hour)},
+	}
+	tests := []struct {
+		mode sortMode
+		want []string
+	}{
+		{sortTmux, []string{"$1", "$2", "$3"}},
+		{sortName, []string{"$2", "$3", "$1"}},
+		{sortCreated, []string{"$2", "$3", "$1"}},
+		{sortActivity, []string{"$3", "$2", "$1"}},
+		{sortFailures, []string{"$2", "$1", "$3"}},
+		{sortStale, []string{"$2", "$3", "$1"}},
+		{sortCPU, []string{"$3", "$1", "$2"}},
+	}
+	for _, tt := range tests {
+		m := &Model{
+			sortMode: tt.mode,
+			stale:    map[string]struct{}{"$1": {}},
+			cpuUsage: map[string]float64{"$1": 5, "$3": 40},
+		}
+		if got := sessionIDs(m.sortSessions(sessions)); !slices.Equal(got, tt.want) {
+			t.Fatalf("sort %s = %v, want %v", tt.mode, got, tt.want)
+		}
+	}
+}
+
+// TestSessionGroupsByPrefix buckets sessions by name prefix, puts unprefixed
+// sessions last, and drops collapsed groups from the card list.
+func TestSessionGroupsByPrefix(t *testing.T) {
+	t.Parallel()
+
+	m := &Model{
+		groupMode: groupPrefix,
+		sessions: []tmux.Session{
+			{ID: "$1", Name: "scratch"},
+			{ID: "$2", Name: "proj-web"},
+			{ID: "$3", Name: "ci_main"},
+			{ID: "$4", Name: "proj-api"},
+		},
+	}
+	groups := m.sessionGroups()
+	var labels []string
+	for _, group := range groups {
+		labels = append(labels, group.label)
+	}
+	if want := []string{"ci_*", "proj-*", "other"}; !slices.Equal(labels, want) {
+		t.Fatalf("group labels = %v, want %v", labels, want)
+	}
+	if got := sessionIDs(groups[1].sessions); !slices.Equal(got, []string{"$2", "$4"}) {
+		t.Fatalf("proj group = %v, want [$2 $4]", got)
+	}
+
+	m.toggleGroup("proj")
+	if got := sessionIDs(m.filteredSessionsFull()); !slices.Equal(got, []string{"$3", "$1"}) {
+		t.Fatalf("cards with proj collapsed = %v, want [$3 $1]", got)
+	}
+	if got := len(m.orderedSessions()); got != 4 {
+		t.Fatalf("ordered sessions = %d, want 4 including collapsed", got)
+	}
+	m.groupMode = groupAttached
+	if m.isGroupCollapsed("proj") {
+		t.Fatal("collapse state should be scoped to the group mode")
+	}
+}
+
+// TestGroupByRepoAndVar resolves git work trees in a command, once per
+// directory, and reads @group options.
+func TestGroupByRepoAndVar(t *testing.T) {
+	t.Parallel()
+
+	repo := filepath.Join(t.TempDir(), "app")
+	nested := filepath.Join(repo, "cmd", "server")
+	if err := os.MkdirAll(filepath.Join(repo, ".git"), 0o755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	if err := os.MkdirAll(nested, 0o755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	session := func(id, path, group string) tmux.Session {
+		return tmux.Session{ID: id, Windows: []tmux.Window{{
+			Active: true,
+			Panes:  []tmux.Pane{{ID: "%" + id, Active: true, CurrentPath: path, Group: group}},
+		}}}
+	}
+	m := &Model{sessions: []tmux.Session{
+		session("$1", nested, "backend"),
+		session("$2", t.TempDir(), ""),
+	}, groupMode: groupRepo}
+
+	if key, _ := m.groupKey(groupRepo, m.sessions[0]); key != "" {
+		t.Fatalf("repo group = %q before resolving, want the fallback", key)
+	}
+	cmd := m.repoRootsCmd()
+	if cmd == nil {
+		t.Fatal("grouping by repo should resolve the pane directories")
+	}
+	m.Update(cmd())
+	if m.repoRootsCmd() != nil {
+		t.Fatal("resolved directories should not be looked up again")
+	}
+	key, label := m.groupKey(groupRepo, m.sessions[0])
+	if key != repo || label != "app" {
+		t.Fatalf("repo group = %q/%q, want %q/app", key, label, repo)
+	}
+	if key, label := m.groupKey(groupRepo, m.sessions[1]); key != "" || label != "no repo" {
+		t.Fatalf("non-repo group = %q/%q, want fallback", key, label)
+	}
+	if key, _ := m.groupKey(groupVar, m.sessions[0]); key != "backend" {
+		t.Fatalf("var group = %q, want backend", key)
+	}
+}
+
+// TestMoveCursorFollowsGroups steps through grouped rows, starting each group
+// on a new row and clamping to shorter rows.
+func TestMoveCursorFollowsGroups(t *testing.T) {
+	t.Parallel()
+
+	m := &Model{
+		groupMode: groupPrefix,
+		cardCols:  2,
+		sessions: []tmux.Session{
+			{ID: "$1", Name: "a-1"},
+			{ID: "$2", Name: "b-1"},
+			{ID: "$3", Name: "a-2"},
+			{ID: "$4", Name: "a-3"},
+		},
+		cursorSession: "$3",
+	}
+	if got := m.cardRows(); len(got) != 3 || !slices.Equal(got[0], []string{"$1", "$3"}) {
+		t.Fatalf("card rows = %v, want [[$1 $3] [$4] [$2]]", got)
+	}
+	if !m.moveCursorDown() || m.cursorSession != "$4" {
+		t.Fatalf("down from $3 = %q, want $4", m.cursorSession)
+	}
+	if !m.moveCursorDown() || m.cursorSession != "$2" {
+		t.Fatalf("down into next group = %q, want $2", m.cursorSession)
+	}
+	if m.moveCursorRight() {
+		t.Fatal("right should stop at the end of a group row")
+	}
+	if !m.moveCursorUp() || m.cursorSession != "$4" {
+		t.Fatalf("up = %q, want $4", m.cursorSession)
+	}
+}
+
+// TestCycleSortAndGroupWrap returns to the first mode after the last.
+func TestCycleSortAndGroupWrap(t *testing.T) {
+	t.Parallel()
+
+	m := &Model{sortMode: sortCPU, groupMode: groupAttached}
+	if got := m.cycleSort(); got != sortTmux {
+		t.Fatalf("cycleSort = %q, want tmux", got)
+	}
+	if got := m.cycleGroup(); got != groupNone {
+		t.Fatalf("cycleGroup = %q, want none", got)
+	}
+	if m.setSortMode("bogus") {
+		t.Fatal("setSortMode accepted an unknown mode")
+	}
+}
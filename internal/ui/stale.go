@@ -2,34 +2,162 @@
 package ui
 
 import (
+	"fmt"
+	"path"
+	"slices"
 	"sort"
 	"time"
 
+	"github.com/steipete/tmuxwatch/internal/config"
 	"github.com/steipete/tmuxwatch/internal/tmux"
 )
 
-// updateStaleSessions recalculates which sessions qualify as stale.
+// staleRule gives sessions matching a name pattern their own threshold.
+type staleRule struct {
+	pattern string
+	after   time.Duration
+}
+
+// newStaleRules converts the configured rules.
+func newStaleRules(rules []config.StaleRule) []staleRule {
+	out := make([]staleRule, 0, len(rules))
+	for _, rule := range rules {
+		out = append(out, staleRule{pattern: rule.Session, after: time.Duration(rule.After)})
+	}
+	return out
+}
+
+// updateStaleSessions recalculates which sessions qualify as stale and why.
+// Attached, exempt, and protected sessions never do.
 func (m *Model) updateStaleSessions() {
 	for k := range m.stale {
 		delete(m.stale, k)
 	}
+	m.staleWhy = make(map[string]string)
 	now := time.Now()
 	for _, session := range m.sessions {
-		if session.Attached {
+		if session.Attached || m.isStaleExempt(session) {
 			continue
 		}
 		if sessionAllPanesDead(session) {
 			m.stale[session.ID] = struct{}{}
+			m.staleWhy[session.ID] = "all panes dead"
 			continue
 		}
-		last := m.sessionActivity(session)
+		last := m.staleActivity(session, now)
 		if last.IsZero() {
 			continue
 		}
-		if now.Sub(last) >= staleThreshold {
+		limit := m.staleThresholdFor(session)
+		if idle := now.Sub(last); idle >= limit {
 			m.stale[session.ID] = struct{}{}
+			m.staleWhy[session.ID] = fmt.Sprintf("idle %s ≥ %s", durationLabel(roundIdle(idle)), durationLabel(limit))
+		}
+	}
+}
+
+// roundIdle trims an idle time to whole minutes, or seconds below a minute.
+func roundIdle(d time.Duration) time.Duration {
+	if d < time.Minute {
+		return d.Truncate(time.Second)
+	}
+	return d.Truncate(time.Minute)
+}
+
+// staleActivity returns the latest activity among the configured signals,
+// never earlier than the session's creation.
+func (m *Model) staleActivity(session tmux.Session, now time.Time) time.Time {
+	signals := m.staleSignals
+	if len(signals) == 0 {
+		signals = config.DefaultStaleSignals
+	}
+	latest := session.CreatedAt
+	bump := func(t time.Time) {
+		if t.After(latest) {
+			latest = t
+		}
+	}
+	for _, signal := range signals {
+		switch signal {
+		case "pane":
+			bump(sessionLatestActivity(session))
+			if preview, ok := m.previews[session.ID]; ok && preview != nil {
+				bump(preview.lastChanged)
+			}
+		case "client":
+			bump(session.LastActivity)
+			bump(session.LastAttached)
+		case "cpu":
+			if m.cpuUsage[session.ID] >= m.staleCPUThreshold() {
+				bump(now)
+			}
+		}
+	}
+	return latest
+}
+
+// staleUsesCPU reports whether stale detection needs CPU samples.
+func (m *Model) staleUsesCPU() bool {
+	return slices.Contains(m.staleSignals, "cpu")
+}
+
+// staleCPUThreshold returns the CPU percent that counts as busy.
+func (m *Model) staleCPUThreshold() float64 {
+	if m.staleCPU > 0 {
+		return m.staleCPU
+	}
+	return config.DefaultStaleCPU
+}
+
+// staleThresholdFor picks the session's threshold: its
+// @tmuxwatch-stale-after option, then the first matching rule, then the
+// global threshold.
+func (m *Model) staleThresholdFor(session tmux.Session) time.Duration {
+	if session.StaleAfter != "" {
+		if d, err := time.ParseDuration(session.StaleAfter); err == nil && d > 0 {
+			return d
+		}
+	}
+	for _, rule := range m.staleRules {
+		if matched, _ := path.Match(rule.pattern, session.Name); matched {
+			return rule.after
+		}
+	}
+	return m.staleThreshold()
+}
+
+// isStaleExempt reports whether a session can never go stale.
+func (m *Model) isStaleExempt(session tmux.Session) bool {
+	return m.isProtected(session) || matchesAny(m.staleExempt, session.Name)
+}
+
+// isProtected reports whether tmuxwatch must refuse to kill a session.
+func (m *Model) isProtected(session tmux.Session) bool {
+	return session.Protected || matchesAny(m.staleProtect, session.Name)
+}
+
+// staleReason explains why a session counts as stale.
+func (m *Model) staleReason(sessionID string) string {
+	return m.staleWhy[sessionID]
+}
+
+// matchesAny reports whether name matches one of the glob patterns.
+func matchesAny(patterns []string, name string) bool {
+	for _, pattern := range patterns {
+		if matched, _ := path.Match(pattern, name); matched {
+			return true
 		}
 	}
+	return false
+}
+
+// staleThreshold returns the configured inactivity window before a session is
+// considered stale.
+func (m *Model) staleThreshold() time.Duration {
+	if m.staleAfter > 0 {
+		return m.staleAfter
+	}
+	return staleThreshold
 }
 
 // isStale reports whether the provided session identifier is marked stale.
@@ -65,3 +65,65 @@ func TestUpdateStaleSessionsClearsAfterActivity(t *testing.T) {
 		t.Fatal("expected session to become active after new content")
 	}
 }
+
+// TestStaleThresholdFor prefers the session option, then the first matching
+// rule, then the global threshold.
+func TestStaleThresholdFor(t *testing.T) {
+	t.Parallel()
+
+	m := &Model{
+		staleAfter: time.Hour,
+		staleRules: []staleRule{{pattern: "build-*", after: 15 * time.Minute}, {pattern: "*", after: 2 * time.Hour}},
+	}
+	tests := []struct {
+		name    string
+		session tmux.Session
+		want    time.Duration
+	}{
+		{name: "option", session: tmux.Session{Name: "build-1", StaleAfter: "5m"}, want: 5 * time.Minute},
+		{name: "bad option falls through", session: tmux.Session{Name: "build-1", StaleAfter: "soon"}, want: 15 * time.Minute},
+		{name: "first rule", session: tmux.Session{Name: "build-2"}, want: 15 * time.Minute},
+		{name: "catch-all rule", session: tmux.Session{Name: "api"}, want: 2 * time.Hour},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			if got := m.staleThresholdFor(tc.session); got != tc.want {
+				t.Fatalf("staleThresholdFor(%+v) = %v, want %v", tc.session, got, tc.want)
+			}
+		})
+	}
+}
+
+// TestUpdateStaleSessionsPolicy skips exempt and protected sessions, honours
+// the configured signals, and records why a session is stale.
+func TestUpdateStaleSessionsPolicy(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	old := now.Add(-3 * time.Hour)
+	idle := func(id, name string) tmux.Session {
+		return tmux.Session{ID: id, Name: name, CreatedAt: old, LastActivity: old, Windows: []tmux.Window{{Panes: []tmux.Pane{{LastActivity: old}}}}}
+	}
+	protected := idle("$3", "vault")
+	protected.Protected = true
+	m := &Model{
+		sessions:     []tmux.Session{idle("$1", "api"), idle("$2", "db-main"), protected, idle("$4", "cache"), idle("$5", "worker")},
+		previews:     map[string]*sessionPreview{"$4": {lastChanged: now}},
+		stale:        make(map[string]struct{}),
+		staleAfter:   time.Hour,
+		staleExempt:  []string{"db-*"},
+		staleSignals: []string{"client", "cpu"},
+		cpuUsage:     map[string]float64{"$5": 50},
+	}
+	m.updateStaleSessions()
+
+	for id, want := range map[string]bool{"$1": true, "$2": false, "$3": false, "$4": true, "$5": false} {
+		if got := m.isStale(id); got != want {
+			t.Errorf("isStale(%s) = %v, want %v", id, got, want)
+		}
+	}
+	if got, want := m.staleReason("$1"), "idle 3h ≥ 1h"; got != want {
+		t.Fatalf("staleReason = %q, want %q", got, want)
+	}
+}
@@ -10,23 +10,27 @@ import (
 	"github.com/steipete/tmuxwatch/internal/tmux"
 )
 
-// tabTitles derives the current tab titles based on overview and detail state.
-var (
-	tabActiveStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("231")).
-			Background(lipgloss.Color("62")).
-			Bold(true).
-			Padding(0, 1).
-			MarginRight(1)
-
-	tabInactiveStyle = lipgloss.NewStyle().
-				Foreground(lipgloss.Color(headerColorCursor)).
-				Padding(0, 1).
-				MarginRight(1)
-)
+// tabActiveStyle renders the selected tab title.
+func tabActiveStyle(th theme) lipgloss.Style {
+	return lipgloss.NewStyle().
+		Foreground(lipgloss.Color(th.accentText)).
+		Background(lipgloss.Color(th.accent)).
+		Bold(true).
+		Padding(0, 1).
+		MarginRight(1)
+}
 
+// tabInactiveStyle renders unselected tab titles.
+func tabInactiveStyle(th theme) lipgloss.Style {
+	return lipgloss.NewStyle().
+		Foreground(lipgloss.Color(th.headerCursor)).
+		Padding(0, 1).
+		MarginRight(1)
+}
+
+// tabTitles derives the current tab titles based on overview and detail state.
 func (m *Model) tabTitles() []string {
-	sessions := m.filteredSessionsFull()
+	sessions := m.orderedSessions()
 	titles := make([]string, 1, len(sessions)+1)
 	titles[0] = "Overview"
 	m.tabSessionIDs = m.tabSessionIDs[:0]
@@ -54,10 +58,11 @@ func (m *Model) renderTabBar(width int) string {
 		zoneID   string
 	}
 	segments := make([]tabSegment, 0, len(titles))
+	th := m.colors()
 	for i, title := range titles {
-		style := tabInactiveStyle
+		style := tabInactiveStyle(th)
 		if i == m.activeTab {
-			style = tabActiveStyle
+			style = tabActiveStyle(th)
 		}
 		zoneID := fmt.Sprintf("%s###tab:%d", m.zonePrefix, i)
 		rendered := style.Render(title)
@@ -114,6 +119,7 @@ func (m *Model) enterDetail(sessionID string) {
 	m.detailSession = sessionID
 	m.focusedSession = sessionID
 	m.cursorSession = sessionID
+	m.markRead(sessionID)
 	titles := m.tabTitles()
 	idx := m.indexForSession(sessionID)
 	if idx == 0 {
@@ -190,6 +196,7 @@ func (m *Model) applyActiveTabSelection(length int) {
 	if m.focusedSession == "" {
 		m.focusedSession = sessionID
 	}
+	m.markRead(sessionID)
 	if m.cursorSession == "" {
 		m.cursorSession = sessionID
 	}
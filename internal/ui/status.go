@@ -22,16 +22,18 @@ func (m *Model) renderStatus() string {
 // buildStatusLine assembles the footer lines detailing input helpers, stale
 // sessions, pane variables, toasts, and errors.
 func (m *Model) buildStatusLine(width int) string {
+	th := m.colors()
 	lines := []string{
+		lipgloss.NewStyle().Padding(0, 2).Render(m.renderModeBadge()),
 		lipgloss.NewStyle().
-			Foreground(lipgloss.Color("245")).
+			Foreground(lipgloss.Color(th.helpText)).
 			Padding(0, 2).
-			Render(fmt.Sprintf("mouse: click focus, scroll, %s/%s detail, %s/%s collapse, close %s · keys: / search, H show hidden, X kill stale, ctrl+X clean all, ctrl+P palette, q quit", maximizeLabel, restoreLabel, collapseLabel, expandLabel, closeLabel)),
+			Render(fmt.Sprintf("mouse: click focus, scroll, %s jump to tmux, %s/%s detail, %s/%s collapse, close %s · keys: / search, H hidden sessions, X kill stale, ctrl+X clean all, ctrl+P palette, q quit", jumpLabel, maximizeLabel, restoreLabel, collapseLabel, expandLabel, closeLabel)),
 	}
 
 	if stale := m.staleSessionNames(); len(stale) > 0 {
 		staleLine := lipgloss.NewStyle().
-			Foreground(lipgloss.Color("246")).
+			Foreground(lipgloss.Color(th.staleText)).
 			Padding(0, 2).
 			Render(formatStaleLine(stale, width))
 		lines = append(lines, staleLine)
@@ -39,7 +41,7 @@ func (m *Model) buildStatusLine(width int) string {
 
 	if preview, ok := m.previews[m.focusedSession]; ok && len(preview.vars) > 0 {
 		varsLine := lipgloss.NewStyle().
-			Foreground(lipgloss.Color("244")).
+			Foreground(lipgloss.Color(th.varsText)).
 			Padding(0, 2).
 			Render(formatPaneVariables(preview.vars))
 		lines = append(lines, varsLine)
@@ -47,7 +49,7 @@ func (m *Model) buildStatusLine(width int) string {
 
 	if m.err != nil {
 		errPart := lipgloss.NewStyle().
-			Foreground(lipgloss.Color("203")).
+			Foreground(lipgloss.Color(th.errorText)).
 			Padding(0, 2).
 			Render("Error: " + m.err.Error())
 		lines = append(lines, errPart)
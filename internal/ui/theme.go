@@ -0,0 +1,325 @@
+// File theme.go defines the semantic colour palette used across the UI and the
+// built-in themes users can pick from the config file or command palette.
+package ui
+
+import tea "charm.land/bubbletea/v2"
+
+// themeAuto selects between the default dark theme and a light theme based on
+// the terminal background.
+const themeAuto = "auto"
+
+// theme groups every colour the UI renders so switching palettes is a single
+// assignment. Values are lipgloss colour strings (ANSI-256 indexes or hex).
+type theme struct {
+	name  string
+	label string
+
+	accent     string
+	accentText string
+	titleMeta  string
+
+	borderBase     string
+	borderFocus    string
+	borderPulse    string
+	borderCursor   string
+	borderHover    string
+	borderExitFail string
+	borderExitOK   string
+	borderStale    string
+	borderAlert    string
+
+	headerBase     string
+	headerFocus    string
+	headerPulse    string
+	headerCursor   string
+	headerExitFail string
+	headerExitOK   string
+	headerStale    string
+	headerAlert    string
+
+	helpText  string
+	staleText string
+	varsText  string
+	errorText string
+	alertText string
+	emptyText string
+
+	overlayBg       string
+	overlayText     string
+	overlayMuted    string
+	overlayDisabled string
+}
+
+var (
+	themeDefault = theme{
+		name:  "default",
+		label: "Default",
+
+		accent:     "62",
+		accentText: "231",
+		titleMeta:  "249",
+
+		borderBase:     "62",
+		borderFocus:    "212",
+		borderPulse:    "213",
+		borderCursor:   "111",
+		borderHover:    "143",
+		borderExitFail: "203",
+		borderExitOK:   "36",
+		borderStale:    "95",
+		borderAlert:    "208",
+
+		headerBase:     "249",
+		headerFocus:    "212",
+		headerPulse:    "219",
+		headerCursor:   "111",
+		headerExitFail: "203",
+		headerExitOK:   "37",
+		headerStale:    "103",
+		headerAlert:    "214",
+
+		helpText:  "245",
+		staleText: "246",
+		varsText:  "244",
+		errorText: "203",
+		alertText: "208",
+		emptyText: "252",
+
+		overlayBg:       "235",
+		overlayText:     "250",
+		overlayMuted:    "244",
+		overlayDisabled: "240",
+	}
+
+	themeDracula = theme{
+		name:  "dracula",
+		label: "Dracula",
+
+		accent:     "#bd93f9",
+		accentText: "#282a36",
+		titleMeta:  "#44475a",
+
+		borderBase:     "#6272a4",
+		borderFocus:    "#ff79c6",
+		borderPulse:    "#f1fa8c",
+		borderCursor:   "#8be9fd",
+		borderHover:    "#ffb86c",
+		borderExitFail: "#ff5555",
+		borderExitOK:   "#50fa7b",
+		borderStale:    "#44475a",
+		borderAlert:    "#ffb86c",
+
+		headerBase:     "#f8f8f2",
+		headerFocus:    "#ff79c6",
+		headerPulse:    "#f1fa8c",
+		headerCursor:   "#8be9fd",
+		headerExitFail: "#ff5555",
+		headerExitOK:   "#50fa7b",
+		headerStale:    "#6272a4",
+		headerAlert:    "#ffb86c",
+
+		helpText:  "#6272a4",
+		staleText: "#bd93f9",
+		varsText:  "#8be9fd",
+		errorText: "#ff5555",
+		alertText: "#ffb86c",
+		emptyText: "#f8f8f2",
+
+		overlayBg:       "#282a36",
+		overlayText:     "#f8f8f2",
+		overlayMuted:    "#6272a4",
+		overlayDisabled: "#44475a",
+	}
+
+	themeNord = theme{
+		name:  "nord",
+		label: "Nord",
+
+		accent:     "#5e81ac",
+		accentText: "#eceff4",
+		titleMeta:  "#d8dee9",
+
+		borderBase:     "#4c566a",
+		borderFocus:    "#88c0d0",
+		borderPulse:    "#ebcb8b",
+		borderCursor:   "#81a1c1",
+		borderHover:    "#b48ead",
+		borderExitFail: "#bf616a",
+		borderExitOK:   "#a3be8c",
+		borderStale:    "#434c5e",
+		borderAlert:    "#d08770",
+
+		headerBase:     "#d8dee9",
+		headerFocus:    "#88c0d0",
+		headerPulse:    "#ebcb8b",
+		headerCursor:   "#81a1c1",
+		headerExitFail: "#bf616a",
+		headerExitOK:   "#a3be8c",
+		headerStale:    "#4c566a",
+		headerAlert:    "#d08770",
+
+		helpText:  "#4c566a",
+		staleText: "#d08770",
+		varsText:  "#81a1c1",
+		errorText: "#bf616a",
+		alertText: "#ebcb8b",
+		emptyText: "#eceff4",
+
+		overlayBg:       "#3b4252",
+		overlayText:     "#eceff4",
+		overlayMuted:    "#d8dee9",
+		overlayDisabled: "#4c566a",
+	}
+
+	themeCatppuccin = theme{
+		name:  "catppuccin",
+		label: "Catppuccin",
+
+		accent:     "#cba6f7",
+		accentText: "#1e1e2e",
+		titleMeta:  "#313244",
+
+		borderBase:     "#6c7086",
+		borderFocus:    "#f5c2e7",
+		borderPulse:    "#f9e2af",
+		borderCursor:   "#89b4fa",
+		borderHover:    "#fab387",
+		borderExitFail: "#f38ba8",
+		borderExitOK:   "#a6e3a1",
+		borderStale:    "#45475a",
+		borderAlert:    "#fab387",
+
+		headerBase:     "#cdd6f4",
+		headerFocus:    "#f5c2e7",
+		headerPulse:    "#f9e2af",
+		headerCursor:   "#89b4fa",
+		headerExitFail: "#f38ba8",
+		headerExitOK:   "#a6e3a1",
+		headerStale:    "#7f849c",
+		headerAlert:    "#fab387",
+
+		helpText:  "#7f849c",
+		staleText: "#fab387",
+		varsText:  "#74c7ec",
+		errorText: "#f38ba8",
+		alertText: "#fab387",
+		emptyText: "#cdd6f4",
+
+		overlayBg:       "#1e1e2e",
+		overlayText:     "#cdd6f4",
+		overlayMuted:    "#a6adc8",
+		overlayDisabled: "#585b70",
+	}
+
+	themeSolarizedLight = theme{
+		name:  "solarized-light",
+		label: "Solarized Light",
+
+		accent:     "#268bd2",
+		accentText: "#fdf6e3",
+		titleMeta:  "#eee8d5",
+
+		borderBase:     "#93a1a1",
+		borderFocus:    "#d33682",
+		borderPulse:    "#b58900",
+		borderCursor:   "#268bd2",
+		borderHover:    "#6c71c4",
+		borderExitFail: "#dc322f",
+		borderExitOK:   "#859900",
+		borderStale:    "#eee8d5",
+		borderAlert:    "#cb4b16",
+
+		headerBase:     "#586e75",
+		headerFocus:    "#d33682",
+		headerPulse:    "#b58900",
+		headerCursor:   "#268bd2",
+		headerExitFail: "#dc322f",
+		headerExitOK:   "#859900",
+		headerStale:    "#93a1a1",
+		headerAlert:    "#cb4b16",
+
+		helpText:  "#93a1a1",
+		staleText: "#cb4b16",
+		varsText:  "#2aa198",
+		errorText: "#dc322f",
+		alertText: "#cb4b16",
+		emptyText: "#586e75",
+
+		overlayBg:       "#eee8d5",
+		overlayText:     "#073642",
+		overlayMuted:    "#657b83",
+		overlayDisabled: "#93a1a1",
+	}
+)
+
+// builtinThemes lists the selectable themes in palette order.
+var builtinThemes = []theme{themeDefault, themeDracula, themeNord, themeCatppuccin, themeSolarizedLight}
+
+// themeByName resolves a built-in theme.
+func themeByName(name string) (theme, bool) {
+	for _, th := range builtinThemes {
+		if th.name == name {
+			return th, true
+		}
+	}
+	return theme{}, false
+}
+
+// themeForBackground picks the automatic theme for a dark or light terminal.
+func themeForBackground(dark bool) theme {
+	if dark {
+		return themeDefault
+	}
+	return themeSolarizedLight
+}
+
+// themePaletteCommands offers a palette entry per theme, disabling the one in
+// use. Choosing auto asks the terminal for its background colour again.
+func (m *Model) themePaletteCommands() []commandItem {
+	items := []commandItem{{
+		label:   "Theme: Auto (match terminal)",
+		enabled: !m.themeAuto,
+		run: func(m *Model) tea.Cmd {
+			m.setTheme(themeAuto)
+			return tea.RequestBackgroundColor
+		},
+	}}
+	current := m.colors().name
+	for _, th := range builtinThemes {
+		name := th.name
+		items = append(items, commandItem{
+			label:   "Theme: " + th.label,
+			enabled: m.themeAuto || name != current,
+			run: func(m *Model) tea.Cmd {
+				m.setTheme(name)
+				return nil
+			},
+		})
+	}
+	return items
+}
+
+// colors returns the active theme, falling back to the default palette.
+func (m *Model) colors() theme {
+	if m.theme.name == "" {
+		return themeDefault
+	}
+	return m.theme
+}
+
+// setTheme switches the active theme by name. "auto" re-enables background
+// detection and keeps the current palette until the terminal answers.
+func (m *Model) setTheme(name string) bool {
+	if name == themeAuto {
+		m.themeAuto = true
+		return true
+	}
+	th, ok := themeByName(name)
+	if !ok {
+		return false
+	}
+	m.themeAuto = false
+	m.theme = th
+	m.cachedStatus = ""
+	return true
+}
@@ -0,0 +1,80 @@
+// File theme_test.go checks theme lookup, switching, and background detection.
+package ui
+
+import (
+	"image/color"
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+
+	"github.com/steipete/tmuxwatch/internal/config"
+)
+
+// TestConfigThemesResolve keeps the config whitelist and built-ins in sync.
+func TestConfigThemesResolve(t *testing.T) {
+	t.Parallel()
+
+	for _, name := range config.Themes {
+		if name == themeAuto {
+			continue
+		}
+		if _, ok := themeByName(name); !ok {
+			t.Fatalf("config theme %q has no built-in palette", name)
+		}
+	}
+	if len(builtinThemes)+1 != len(config.Themes) {
+		t.Fatalf("config lists %d themes, ui has %d plus auto", len(config.Themes), len(builtinThemes))
+	}
+}
+
+// TestSetTheme switches palettes and rejects unknown names.
+func TestSetTheme(t *testing.T) {
+	t.Parallel()
+
+	m := &Model{}
+	if got := m.colors().name; got != "default" {
+		t.Fatalf("zero model theme = %q, want default", got)
+	}
+	if !m.setTheme("nord") || m.colors().name != "nord" {
+		t.Fatalf("setTheme(nord) did not apply, got %q", m.colors().name)
+	}
+	if m.setTheme("neon") {
+		t.Fatal("setTheme accepted an unknown theme")
+	}
+	if m.colors().name != "nord" {
+		t.Fatalf("unknown theme changed palette to %q", m.colors().name)
+	}
+}
+
+// TestAutoThemeFollowsBackground picks light or dark palettes from the
+// terminal's background colour report.
+func TestAutoThemeFollowsBackground(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		bg   color.Color
+		want string
+	}{
+		{name: "dark", bg: color.Black, want: "default"},
+		{name: "light", bg: color.White, want: "solarized-light"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			m := &Model{}
+			m.setTheme(themeAuto)
+			m.Update(tea.BackgroundColorMsg{Color: tt.bg})
+			if got := m.colors().name; got != tt.want {
+				t.Fatalf("theme = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	m := &Model{}
+	m.setTheme("dracula")
+	m.Update(tea.BackgroundColorMsg{Color: color.White})
+	if got := m.colors().name; got != "dracula" {
+		t.Fatalf("explicit theme overridden by background report: %q", got)
+	}
+}
@@ -0,0 +1,321 @@
+// File timeline.go records session changes, user actions, and alerts in an
+// in-memory event log and renders the filterable timeline panel.
+package ui
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+
+	"github.com/steipete/tmuxwatch/internal/eventlog"
+	"github.com/steipete/tmuxwatch/internal/tmux"
+)
+
+const (
+	// maxTimelineEvents bounds the in-memory event log.
+	maxTimelineEvents = 500
+	// timelineRows is how many events the panel lists at once.
+	timelineRows = 10
+	// keysCoalesceWindow merges bursts of forwarded keys into one event.
+	keysCoalesceWindow = 10 * time.Second
+)
+
+// Timeline event kinds.
+const (
+	eventSession = "session"
+	eventPane    = "pane"
+	eventAction  = "action"
+	eventAlert   = "alert"
+)
+
+// timelineKinds lists the kind filters the panel cycles through; empty shows
+// every kind.
+var timelineKinds = []string{"", eventSession, eventPane, eventAction, eventAlert}
+
+// timelineEvent is one entry in the event log.
+type timelineEvent struct {
+	at        time.Time
+	kind      string
+	sessionID string
+	session   string
+	text      string
+	// keys counts forwarded keys for coalesced send-keys events.
+	keys int
+}
+
+// logEvent appends an event, trimming the oldest once the log is full, and
+// mirrors it to the on-disk log when one is configured.
+func (m *Model) logEvent(kind, sessionID, text string) {
+	name := sessionLabel(sessionID)
+	if session, ok := m.sessionByID(sessionID); ok {
+		name = session.Name
+	}
+	ev := timelineEvent{at: time.Now(), kind: kind, sessionID: sessionID, session: name, text: text}
+	m.appendEvent(ev)
+}
+
+// appendEvent stores ev and writes it to disk, after any send-keys burst it
+// ends.
+func (m *Model) appendEvent(ev timelineEvent) {
+	m.flushKeys()
+	m.timeline = append(m.timeline, ev)
+	if over := len(m.timeline) - maxTimelineEvents; over > 0 {
+		m.timeline = slices.Delete(m.timeline, 0, over)
+	}
+	m.writeEvent(ev)
+}
+
+// writeEvent mirrors ev to the event log file in the background.
+func (m *Model) writeEvent(ev timelineEvent) {
+	if m.eventLog == nil {
+		return
+	}
+	log := m.eventLog
+	entry := eventlog.Entry{Time: ev.at, Kind: ev.kind, Session: ev.session, Text: ev.text}
+	m.writeInBackground(sinkEventLog, func() error { return log.Append(entry) })
+}
+
+// logKeys records keys forwarded to a session, merging bursts into a single
+// event so typing does not flood the log. The disk log only sees a burst
+// once it ends; keys after that start a new one.
+func (m *Model) logKeys(sessionID string, keys []string) {
+	now := time.Now()
+	if n := len(m.timeline); n > 0 {
+		last := &m.timeline[n-1]
+		if m.keysPending && last.kind == eventAction && last.keys > 0 && last.sessionID == sessionID && now.Sub(last.at) < keysCoalesceWindow {
+			last.keys += len(keys)
+			last.at = now
+			last.text = fmt.Sprintf("sent %d keys", last.keys)
+			return
+		}
+		m.flushKeys()
+	}
+	name := sessionLabel(sessionID)
+	if session, ok := m.sessionByID(sessionID); ok {
+		name = session.Name
+	}
+	ev := timelineEvent{at: now, kind: eventAction, sessionID: sessionID, session: name, keys: len(keys)}
+	ev.text = fmt.Sprintf("sent %d keys", ev.keys)
+	if len(keys) == 1 && strings.HasPrefix(keys[0], "C-") {
+		ev.text = "sent " + keys[0]
+	}
+	m.timeline = append(m.timeline, ev)
+	if over := len(m.timeline) - maxTimelineEvents; over > 0 {
+		m.timeline = slices.Delete(m.timeline, 0, over)
+	}
+	m.keysPending = true
+}
+
+// flushKeys writes a finished send-keys burst to the disk log.
+func (m *Model) flushKeys() {
+	if !m.keysPending {
+		return
+	}
+	m.keysPending = false
+	if n := len(m.timeline); n > 0 && m.timeline[n-1].keys > 0 {
+		m.writeEvent(m.timeline[n-1])
+	}
+}
+
+// logSnapshotDiff records sessions that appeared or disappeared between two
+// snapshots.
+func (m *Model) logSnapshotDiff(prev, next []tmux.Session) {
+	known := make(map[string]struct{}, len(prev))
+	for _, session := range prev {
+		known[session.ID] = struct{}{}
+	}
+	current := make(map[string]struct{}, len(next))
+	for _, session := range next {
+		current[session.ID] = struct{}{}
+		if _, ok := known[session.ID]; !ok {
+			m.appendEvent(timelineEvent{at: time.Now(), kind: eventSession, sessionID: session.ID, session: session.Name, text: "session created"})
+		}
+	}
+	for _, session := range prev {
+		if _, ok := current[session.ID]; !ok {
+			m.appendEvent(timelineEvent{at: time.Now(), kind: eventSession, sessionID: session.ID, session: session.Name, text: "session closed"})
+		}
+	}
+}
+
+// logExits records every pane exit, whether or not it was notified.
+func (m *Model) logExits(exits []paneExit) {
+	for _, exit := range exits {
+		m.appendEvent(timelineEvent{
+			at:        exit.event.EndedAt,
+			kind:      eventPane,
+			sessionID: exit.sessionID,
+			session:   exit.event.Session,
+			text:      exit.event.Summary(),
+		})
+	}
+}
+
+// openTimeline shows the timeline panel with the newest event selected.
+func (m *Model) openTimeline() {
+	m.flushKeys()
+	m.timelineOpen = true
+	m.timelineIndex = 0
+}
+
+// closeTimeline hides the panel, keeping the filter for next time.
+func (m *Model) closeTimeline() {
+	m.timelineOpen = false
+}
+
+// timelineKind returns the active kind filter.
+func (m *Model) timelineKind() string {
+	return timelineKinds[m.timelineKindIdx%len(timelineKinds)]
+}
+
+// filteredTimeline returns matching events, newest first.
+func (m *Model) filteredTimeline() []timelineEvent {
+	kind := m.timelineKind()
+	query := strings.ToLower(m.timelineFilter)
+	var out []timelineEvent
+	for i := len(m.timeline) - 1; i >= 0; i-- {
+		ev := m.timeline[i]
+		if kind != "" && ev.kind != kind {
+			continue
+		}
+		if query != "" && !strings.Contains(strings.ToLower(ev.session+" "+ev.text), query) {
+			continue
+		}
+		out = append(out, ev)
+	}
+	return out
+}
+
+// handleTimelineKey navigates, filters, and jumps from the timeline panel.
+// Printable keys edit the filter, tab cycles the kind filter, and enter jumps
+// to the selected event's card.
+func (m *Model) handleTimelineKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	press, ok := msg.(tea.KeyPressMsg)
+	if !ok {
+		return m, nil
+	}
+	events := m.filteredTimeline()
+	key := msg.String()
+	if m.keymap().lookup(scopeGlobal, key) == actionTimeline && m.timelineFilter == "" {
+		m.closeTimeline()
+		return m, nil
+	}
+	switch key {
+	case "esc":
+		m.closeTimeline()
+	case "up":
+		m.timelineIndex = max(m.timelineIndex-1, 0)
+	case "down":
+		m.timelineIndex = min(m.timelineIndex+1, max(len(events)-1, 0))
+	case "tab":
+		m.timelineKindIdx = (m.timelineKindIdx + 1) % len(timelineKinds)
+		m.timelineIndex = 0
+	case "backspace":
+		if r := []rune(m.timelineFilter); len(r) > 0 {
+			m.timelineFilter = string(r[:len(r)-1])
+			m.timelineIndex = 0
+		}
+	case "enter":
+		if m.timelineIndex >= len(events) {
+			return m, nil
+		}
+		m.closeTimeline()
+		if events[m.timelineIndex].sessionID == "" {
+			return m, nil
+		}
+		return m, m.jumpToSession(events[m.timelineIndex].sessionID)
+	default:
+		if press.Text != "" && press.Mod&(tea.ModCtrl|tea.ModAlt) == 0 {
+			m.timelineFilter += press.Text
+			m.timelineIndex = 0
+		}
+	}
+	return m, nil
+}
+
+// jumpToSession brings a session's card into view and focuses it, revealing
+// it if hidden and expanding its group.
+func (m *Model) jumpToSession(sessionID string) tea.Cmd {
+	session, ok := m.sessionByID(sessionID)
+	if !ok {
+		return showStatusMessage("Session " + sessionLabel(sessionID) + " is gone")
+	}
+	m.revealSession(sessionID)
+	if !slices.ContainsFunc(m.matchingSessions(), func(s tmux.Session) bool { return s.ID == sessionID }) {
+		m.searchQuery = ""
+	}
+	if key, ok := m.groupOfSession(sessionID); ok && m.isGroupCollapsed(key) {
+		m.toggleGroup(key)
+	}
+	if m.viewMode == viewModeDetail && m.detailSession != sessionID {
+		m.enterDetail(sessionID)
+	}
+	m.cursorSession = sessionID
+	m.focusedSession = sessionID
+	m.markRead(sessionID)
+	m.updatePreviewDimensions(m.filteredSessionCount())
+	return showStatusMessage("Jumped to " + session.Name)
+}
+
+// renderTimeline draws the timeline panel.
+func (m *Model) renderTimeline(width int) string {
+	th := m.colors()
+	kind := m.timelineKind()
+	if kind == "" {
+		kind = "all"
+	}
+	title := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.Color(th.overlayText)).
+		Render("timeline")
+	meta := lipgloss.NewStyle().
+		Foreground(lipgloss.Color(th.overlayMuted)).
+		Render(fmt.Sprintf("  kind: %s (tab) · filter: %s_ · enter jumps · esc closes", kind, m.timelineFilter))
+
+	events := m.filteredTimeline()
+	inner := max(width-6, 20)
+	var lines []string
+	if len(events) == 0 {
+		lines = append(lines, lipgloss.NewStyle().Foreground(lipgloss.Color(th.overlayMuted)).Render("no events"))
+	}
+	start := 0
+	if m.timelineIndex >= timelineRows {
+		start = m.timelineIndex - timelineRows + 1
+	}
+	for i := start; i < len(events) && i < start+timelineRows; i++ {
+		ev := events[i]
+		marker := "  "
+		style := lipgloss.NewStyle().Foreground(lipgloss.Color(th.overlayText))
+		if ev.kind == eventAlert {
+			style = style.Foreground(lipgloss.Color(th.alertText))
+		}
+		if i == m.timelineIndex {
+			marker = "▸ "
+			style = style.Bold(true)
+		}
+		line := fmt.Sprintf("%s  %-7s  %-14s  %s", ev.at.Format("15:04:05"), ev.kind, truncate(ev.session, 14), ev.text)
+		lines = append(lines, marker+style.Render(truncate(line, inner-2)))
+	}
+	body := strings.Join(lines, "\n")
+	return paletteStyle(th).
+		MarginTop(0).
+		Padding(0, 1).
+		Width(width).
+		Render(lipgloss.JoinVertical(lipgloss.Left, title+meta, body))
+}
+
+// timelinePaletteCommands offers opening the timeline from the palette.
+func (m *Model) timelinePaletteCommands() []commandItem {
+	return []commandItem{{
+		label:   fmt.Sprintf("Show event timeline (%d)", len(m.timeline)),
+		enabled: true,
+		run: func(m *Model) tea.Cmd {
+			m.openTimeline()
+			return nil
+		},
+	}}
+}
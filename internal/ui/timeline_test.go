@@ -0,0 +1,200 @@
+// File timeline_test.go covers the event timeline log, its filters, and
+// jumping from an event to its card.
+package ui
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+
+	"github.com/steipete/tmuxwatch/internal/eventlog"
+	"github.com/steipete/tmuxwatch/internal/tmux"
+)
+
+// TestLogSnapshotDiffRecordsSessionChanges logs created and closed sessions
+// under their names.
+func TestLogSnapshotDiffRecordsSessionChanges(t *testing.T) {
+	t.Parallel()
+
+	m := &Model{}
+	prev := []tmux.Session{{ID: "$1", Name: "ci"}, {ID: "$2", Name: "docs"}}
+	next := []tmux.Session{{ID: "$2", Name: "docs"}, {ID: "$3", Name: "api"}}
+	m.logSnapshotDiff(prev, next)
+
+	if len(m.timeline) != 2 {
+		t.Fatalf("timeline = %+v, want 2 events", m.timeline)
+	}
+	if got := m.timeline[0]; got.session != "api" || got.text != "session created" {
+		t.Fatalf("first event = %+v, want api created", got)
+	}
+	if got := m.timeline[1]; got.session != "ci" || got.text != "session closed" {
+		t.Fatalf("second event = %+v, want ci closed", got)
+	}
+}
+
+// TestLogKeysCoalescesBursts merges consecutive keys for one session and
+// starts a new event when the target changes.
+func TestLogKeysCoalescesBursts(t *testing.T) {
+	t.Parallel()
+
+	m := &Model{sessions: []tmux.Session{{ID: "$1", Name: "ci"}, {ID: "$2", Name: "docs"}}}
+	m.logKeys("$1", []string{"l"})
+	m.logKeys("$1", []string{"s", "Enter"})
+	m.logKeys("$2", []string{"C-c"})
+
+	if len(m.timeline) != 2 {
+		t.Fatalf("timeline = %+v, want 2 events", m.timeline)
+	}
+	if got := m.timeline[0].text; got != "sent 3 keys" {
+		t.Fatalf("burst text = %q, want %q", got, "sent 3 keys")
+	}
+	if got := m.timeline[1].text; got != "sent C-c" {
+		t.Fatalf("control key text = %q, want %q", got, "sent C-c")
+	}
+}
+
+// TestFilteredTimeline narrows by kind and text, newest first.
+func TestFilteredTimeline(t *testing.T) {
+	t.Parallel()
+
+	m := &Model{}
+	m.logEvent(eventSession, "$1", "session created")
+	m.logEvent(eventAlert, "$1", "panic: boom")
+	m.logEvent(eventAction, "$2", "killed session")
+	m.logEvent(eventAlert, "$2", "error: disk full")
+
+	tests := []struct {
+		name    string
+		kindIdx int
+		filter  string
+		want    []string
+	}{
+		{name: "all", want: []string{"error: disk full", "killed session", "panic: boom", "session created"}},
+		{name: "alerts", kindIdx: 4, want: []string{"error: disk full", "panic: boom"}},
+		{name: "text", filter: "PANIC", want: []string{"panic: boom"}},
+		{name: "kind and text", kindIdx: 3, filter: "disk", want: nil},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			view := &Model{timeline: m.timeline, timelineKindIdx: tc.kindIdx, timelineFilter: tc.filter}
+			got := view.filteredTimeline()
+			if len(got) != len(tc.want) {
+				t.Fatalf("got %d events, want %d", len(got), len(tc.want))
+			}
+			for i := range got {
+				if got[i].text != tc.want[i] {
+					t.Fatalf("event %d = %q, want %q", i, got[i].text, tc.want[i])
+				}
+			}
+		})
+	}
+}
+
+// TestTimelineEnterJumpsToCard reveals a hidden session and focuses it.
+func TestTimelineEnterJumpsToCard(t *testing.T) {
+	t.Parallel()
+
+	m := &Model{
+		sessions: []tmux.Session{{ID: "$1", Name: "ci"}, {ID: "$2", Name: "docs"}},
+		hidden:   map[string]struct{}{"$2": {}},
+		unread:   map[string]struct{}{"$2": {}},
+	}
+	m.logEvent(eventAlert, "$2", "error: disk full")
+	m.logEvent(eventAction, "$1", "sent 3 keys")
+	m.openTimeline()
+	for _, r := range "disk" {
+		m.handleTimelineKey(tea.KeyPressMsg{Code: r, Text: string(r)})
+	}
+	m.handleTimelineKey(tea.KeyPressMsg{Code: tea.KeyEnter})
+
+	if m.timelineOpen {
+		t.Fatal("enter should close the timeline")
+	}
+	if m.focusedSession != "$2" || m.cursorSession != "$2" {
+		t.Fatalf("focus = %q cursor = %q, want $2", m.focusedSession, m.cursorSession)
+	}
+	if _, ok := m.hidden["$2"]; ok {
+		t.Fatal("jumping should reveal the hidden session")
+	}
+	if _, ok := m.unread["$2"]; ok {
+		t.Fatal("jumping should mark the session read")
+	}
+}
+
+// TestTimelineMirrorsToEventLog writes events to the configured log file.
+func TestTimelineMirrorsToEventLog(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "events.jsonl")
+	w, err := eventlog.Open(path)
+	if err != nil {
+		t.Fatalf("Open returned error: %v", err)
+	}
+	m := &Model{eventLog: w, sessions: []tmux.Session{{ID: "$1", Name: "ci"}}}
+	m.logEvent(eventAction, "$1", "killed session")
+	m.Flush()
+	if err := w.Close(); err != nil {
+		t.Fatalf("Close returned error: %v", err)
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatalf("open log: %v", err)
+	}
+	defer f.Close()
+	scanner := bufio.NewScanner(f)
+	if !scanner.Scan() {
+		t.Fatal("event log is empty")
+	}
+	var e eventlog.Entry
+	if err := json.Unmarshal(scanner.Bytes(), &e); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if e.Kind != eventAction || e.Session != "ci" || e.Text != "killed session" {
+		t.Fatalf("entry = %+v", e)
+	}
+}
+
+// TestKeyBurstsReachEventLog writes a send-keys burst once another event
+// ends it, and a burst still open when tmuxwatch exits.
+func TestKeyBurstsReachEventLog(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "events.jsonl")
+	w, err := eventlog.Open(path)
+	if err != nil {
+		t.Fatalf("Open returned error: %v", err)
+	}
+	m := &Model{eventLog: w, sessions: []tmux.Session{{ID: "$1", Name: "ci"}}}
+	m.logKeys("$1", []string{"l", "s"})
+	m.logEvent(eventAction, "$1", "hid session")
+	m.logKeys("$1", []string{"Enter"})
+	m.Flush()
+	m.Flush()
+	if err := w.Close(); err != nil {
+		t.Fatalf("Close returned error: %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read log: %v", err)
+	}
+	var texts []string
+	for _, line := range strings.Split(strings.TrimSpace(string(data)), "\n") {
+		var e eventlog.Entry
+		if err := json.Unmarshal([]byte(line), &e); err != nil {
+			t.Fatalf("decode %q: %v", line, err)
+		}
+		texts = append(texts, e.Text)
+	}
+	if want := []string{"sent 2 keys", "hid session", "sent 1 keys"}; !slices.Equal(texts, want) {
+		t.Fatalf("log = %q, want %q", texts, want)
+	}
+}
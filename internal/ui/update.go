@@ -3,6 +3,7 @@
 package ui
 
 import (
+	"slices"
 	"strings"
 	"time"
 
@@ -23,25 +24,71 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		if _, ok := msg.(tea.KeyPressMsg); !ok {
 			return m, nil
 		}
+		if m.dialog != nil {
+			return m.handleDialogKey(msg)
+		}
+		if m.prompt != nil {
+			return m.handlePromptKey(msg)
+		}
+		if m.cardMenu != nil {
+			return m.handleCardMenuKey(msg)
+		}
+		if m.composer != nil {
+			return m.handleComposerKey(msg)
+		}
+		if m.timelineOpen {
+			return m.handleTimelineKey(msg)
+		}
+		if m.archiveOpen {
+			return m.handleArchiveKey(msg)
+		}
+		if m.hiddenOpen {
+			return m.handleHiddenKey(msg)
+		}
 		if m.paletteOpen {
 			return m.handlePaletteKey(msg)
 		}
 		if m.searching {
 			return m.handleSearchKey(msg)
 		}
+		if m.broadcasting {
+			return m, m.handleBroadcastKey(msg)
+		}
+		if m.replayActive() {
+			if handled, cmd := m.handleReplayKey(msg); handled {
+				return m, cmd
+			}
+		}
+		if handled, cmd := m.handleLeaderKey(msg); handled {
+			return m, cmd
+		}
+		if m.inputMode() == modeInsert {
+			_, cmd := m.handleInsertKey(msg)
+			return m, cmd
+		}
 		if handled, cmd := m.handleGlobalKey(msg); handled {
 			return m, cmd
 		}
 		if handled, cmd := m.handleFocusedKey(msg); handled {
 			return m, cmd
 		}
+	case tea.PasteMsg:
+		return m.handlePaste(msg)
 	case tea.MouseMsg:
 		return m.handleMouse(msg)
 	case searchBlurMsg:
 		m.searching = false
+	case tea.BackgroundColorMsg:
+		if m.themeAuto {
+			m.theme = themeForBackground(msg.IsDark())
+			m.cachedStatus = ""
+		}
 	case snapshotMsg:
 		m.inflight = false
 		m.err = nil
+		if !m.lastUpdated.IsZero() {
+			m.logSnapshotDiff(m.sessions, msg.snapshot.Sessions)
+		}
 		m.lastUpdated = msg.snapshot.Timestamp
 		m.sessions = msg.snapshot.Sessions
 		if m.detailSession != "" && !m.sessionExists(m.detailSession) {
@@ -52,16 +99,56 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				delete(m.collapsed, id)
 			}
 		}
+		for id := range m.revealed {
+			if !m.sessionExists(id) {
+				delete(m.revealed, id)
+			}
+		}
+		m.pruneSelection()
+		m.pruneRecordings()
+		m.applyPendingView()
+		m.advanceActivity(m.sessions)
+		now := time.Now()
+		exits := m.trackLifecycle(now)
+		m.recordHistory(now, exits)
+		m.checkWriteFailures()
+		notifyCmd := m.handleExits(exits)
+		monitorCmd := m.raiseMonitorEvents(append(m.trackWindowFlags(now), m.checkMonitors(now)...))
+		m.pruneAlerts()
+		m.pruneWatched()
 		m.updateStaleSessions()
 		cmd := m.ensurePreviewsAndCapture()
 		m.updatePreviewDimensions(m.filteredSessionCount())
-		return m, tea.Batch(scheduleTick(m.pollInterval), cmd)
+		cmds := []tea.Cmd{scheduleTick(m.pollInterval), cmd, notifyCmd, monitorCmd, m.repoRootsCmd()}
+		if m.currentSort() == sortCPU || m.staleUsesCPU() {
+			cmds = append(cmds, fetchCPUCmd(m.cpuRoots()))
+		}
+		return m, tea.Batch(cmds...)
+	case repoRootsMsg:
+		m.handleRepoRoots(msg)
+	case cpuMsg:
+		if msg.err != nil {
+			if m.sortMode == sortCPU {
+				m.sortMode = sortTmux
+			}
+			m.staleSignals = slices.DeleteFunc(m.staleSignals, func(s string) bool { return s == "cpu" })
+			m.cpuUsage = nil
+			m.showToast("CPU sampling unavailable: " + msg.err.Error())
+			return m, nil
+		}
+		m.cpuUsage = msg.usage
 	case errMsg:
 		m.inflight = false
 		m.err = msg.err
 		return m, scheduleTick(m.pollInterval)
 	case statusMsg:
 		m.showToast(string(msg))
+	case exportMsg:
+		return m, m.handleExport(msg)
+	case replayTickMsg:
+		return m, m.handleReplayTick(msg)
+	case broadcastMsg:
+		return m, m.handleBroadcastResult(msg)
 	case paneContentMsg:
 		if preview, ok := m.previews[msg.sessionID]; ok && preview.paneID == msg.paneID {
 			content := strings.TrimRight(msg.text, "\n")
@@ -69,6 +156,16 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				content = "Pane capture error: " + msg.err.Error()
 			}
 			if content != preview.lastContent {
+				var alertCmd tea.Cmd
+				if msg.err == nil && preview.lastContent != "" {
+					fresh := newLines(preview.lastContent, content)
+					m.recordActivity(msg.paneID, len(fresh))
+					m.countHistoryLines(msg.paneID, len(fresh))
+					alertCmd = m.scanAlerts(msg.sessionID, msg.paneID, fresh)
+				}
+				if msg.err == nil {
+					m.recordFrame(msg.sessionID, time.Now(), content)
+				}
 				wasAtBottom := preview.viewport.AtBottom()
 				preview.viewport.SetContent(content)
 				preview.lastContent = content
@@ -77,6 +174,7 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					preview.viewport.GotoBottom()
 				}
 				m.updateStaleSessions()
+				return m, alertCmd
 			}
 		}
 	case paneVarsMsg:
@@ -88,10 +186,14 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 		}
 	case killSessionsMsg:
+		if summary := killSummary(msg.results); summary != "" {
+			m.showToast(summary)
+		}
 		if len(msg.ids) == 0 {
 			return m, nil
 		}
 		for _, id := range msg.ids {
+			m.logEvent(eventAction, id, "killed session")
 			if m.focusedSession == id {
 				m.focusedSession = ""
 			}
@@ -105,6 +207,7 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			delete(m.hidden, id)
 			delete(m.stale, id)
 			delete(m.collapsed, id)
+			m.forgetPanes(id)
 		}
 		m.inflight = true
 		return m, fetchSnapshotCmd(m.client)
@@ -124,7 +227,7 @@ func (m *Model) ensurePreviewsAndCapture() tea.Cmd {
 	captureOrder := m.captureOrder()
 	active := make(map[string]struct{}, len(m.sessions))
 	var cmds []tea.Cmd
-	captureBudget := maxCapturesPerTick
+	captureBudget := m.capturesPerTick()
 	for _, session := range captureOrder {
 		if m.isHidden(session.ID) {
 			continue
@@ -158,11 +261,12 @@ func (m *Model) ensurePreviewsAndCapture() tea.Cmd {
 			preview.vars = nil
 		}
 		shouldCapture := true
-		if collapsed && !isFocused && !inDetail {
+		recording := m.isRecording(session.ID)
+		if collapsed && !isFocused && !inDetail && !recording {
 			shouldCapture = false
 		}
 
-		prioritized := isFocused || inDetail
+		prioritized := isFocused || inDetail || recording
 		if shouldCapture {
 			if !prioritized && captureBudget <= 0 {
 				shouldCapture = false
@@ -171,7 +275,7 @@ func (m *Model) ensurePreviewsAndCapture() tea.Cmd {
 			}
 		}
 		if shouldCapture {
-			lines := captureLinesFor(preview.viewport.Height())
+			lines := m.captureLines(preview.viewport.Height())
 			cmds = append(cmds, fetchPaneContentCmd(m.client, session.ID, pane.ID, lines))
 		}
 		if session.ID == m.focusedSession {
@@ -250,7 +354,31 @@ func (m *Model) filteredSessions() []tmux.Session {
 	return m.filteredSessionsFull()
 }
 
+// filteredSessionsFull returns the overview cards in sorted, grouped order,
+// skipping sessions inside collapsed groups.
 func (m *Model) filteredSessionsFull() []tmux.Session {
+	var out []tmux.Session
+	for _, group := range m.sessionGroups() {
+		if group.collapsed {
+			continue
+		}
+		out = append(out, group.sessions...)
+	}
+	return out
+}
+
+// orderedSessions returns every matching session in display order, including
+// those inside collapsed groups.
+func (m *Model) orderedSessions() []tmux.Session {
+	var out []tmux.Session
+	for _, group := range m.sessionGroups() {
+		out = append(out, group.sessions...)
+	}
+	return out
+}
+
+// matchingSessions applies the search filter and hidden set in tmux order.
+func (m *Model) matchingSessions() []tmux.Session {
 	var out []tmux.Session
 	query := strings.ToLower(m.searchQuery)
 	for _, session := range m.sessions {
@@ -269,17 +397,41 @@ func (m *Model) filteredSessionCount() int {
 	return len(m.filteredSessions())
 }
 
-// captureLinesFor determines how many lines to capture for a viewport height.
+// captureLinesFor determines how many lines to capture for a viewport height
+// using the built-in bounds.
 func captureLinesFor(height int) int {
-	lines := minCaptureLines
+	return captureLinesWithin(height, minCaptureLines, maxCaptureLines)
+}
+
+// captureLinesWithin sizes a capture for a viewport height, clamped to the
+// provided bounds.
+func captureLinesWithin(height, lo, hi int) int {
+	lines := lo
 	if height > 0 {
 		lines = height + captureSlackLines
 	}
-	if lines < minCaptureLines {
-		lines = minCaptureLines
+	if lines < lo {
+		lines = lo
 	}
-	if lines > maxCaptureLines {
-		lines = maxCaptureLines
+	if lines > hi {
+		lines = hi
 	}
 	return lines
 }
+
+// captureLines sizes a capture using the configured bounds, falling back to
+// the defaults when none were provided.
+func (m *Model) captureLines(height int) int {
+	if m.captureMin <= 0 || m.captureMax < m.captureMin {
+		return captureLinesFor(height)
+	}
+	return captureLinesWithin(height, m.captureMin, m.captureMax)
+}
+
+// capturesPerTick returns how many background captures run per snapshot.
+func (m *Model) capturesPerTick() int {
+	if m.capturePerTick > 0 {
+		return m.capturePerTick
+	}
+	return maxCapturesPerTick
+}
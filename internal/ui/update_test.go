@@ -35,6 +35,22 @@ func TestCaptureLinesFor(t *testing.T) {
 	}
 }
 
+// TestCaptureLinesUsesConfiguredBounds clamps to model-level settings.
+func TestCaptureLinesUsesConfiguredBounds(t *testing.T) {
+	t.Parallel()
+
+	m := &Model{captureMin: 10, captureMax: 50}
+	if got := m.captureLines(0); got != 10 {
+		t.Fatalf("captureLines(0) = %d, want 10", got)
+	}
+	if got := m.captureLines(500); got != 50 {
+		t.Fatalf("captureLines(500) = %d, want 50", got)
+	}
+	if got := (&Model{}).captureLines(1000); got != maxCaptureLines {
+		t.Fatalf("unconfigured captureLines = %d, want %d", got, maxCaptureLines)
+	}
+}
+
 // TestEnsurePreviewsSkipsCollapsed avoids captures when cards are collapsed and unfocused.
 func TestEnsurePreviewsSkipsCollapsed(t *testing.T) {
 	t.Parallel()
@@ -3,6 +3,7 @@ package ui
 
 import (
 	"fmt"
+	"strings"
 	"time"
 )
 
@@ -20,6 +21,32 @@ func coarseDuration(d time.Duration) string {
 	}
 }
 
+// durationLabel renders a configured duration without zero-valued trailing
+// units ("10m" rather than "10m0s").
+func durationLabel(d time.Duration) string {
+	s := d.String()
+	if strings.HasSuffix(s, "m0s") {
+		s = strings.TrimSuffix(s, "0s")
+	}
+	if strings.HasSuffix(s, "h0m") {
+		s = strings.TrimSuffix(s, "0m")
+	}
+	return s
+}
+
+// truncate shortens s to at most width runes, marking the cut with an
+// ellipsis.
+func truncate(s string, width int) string {
+	runes := []rune(s)
+	if width <= 0 || len(runes) <= width {
+		return s
+	}
+	if width == 1 {
+		return "…"
+	}
+	return string(runes[:width-1]) + "…"
+}
+
 // max returns the largest of two integers.
 func max(a, b int) int {
 	if a > b {
@@ -55,3 +55,46 @@ func TestMin(t *testing.T) {
 		t.Fatalf("min(7, -1) = %d, want -1", got)
 	}
 }
+
+// TestTruncate shortens long strings by rune and leaves short ones intact.
+func TestTruncate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		in    string
+		width int
+		want  string
+	}{
+		{"short", 10, "short"},
+		{"exactly", 7, "exactly"},
+		{"truncated", 5, "trun…"},
+		{"ünïcode", 4, "ünï…"},
+		{"x", 0, "x"},
+	}
+	for _, tt := range tests {
+		if got := truncate(tt.in, tt.width); got != tt.want {
+			t.Fatalf("truncate(%q, %d) = %q, want %q", tt.in, tt.width, got, tt.want)
+		}
+	}
+}
+
+// TestDurationLabel drops zero-valued trailing units.
+func TestDurationLabel(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		in   time.Duration
+		want string
+	}{
+		{10 * time.Minute, "10m"},
+		{90 * time.Second, "1m30s"},
+		{2 * time.Hour, "2h"},
+		{time.Hour + 30*time.Minute, "1h30m"},
+		{45 * time.Second, "45s"},
+	}
+	for _, tt := range tests {
+		if got := durationLabel(tt.in); got != tt.want {
+			t.Fatalf("durationLabel(%s) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
@@ -26,10 +26,16 @@ func (m *Model) View() tea.View {
 	padding := lipgloss.NewStyle().Width(targetWidth).Render(" ")
 
 	headerParts := []string{renderTitleBar(m, targetWidth)}
+	if m.broadcasting {
+		headerParts = append(headerParts, m.renderBroadcastBanner(targetWidth))
+	}
 	if m.searching {
-		headerParts = append(headerParts, renderSearchBar(m.searchInput))
+		headerParts = append(headerParts, renderSearchBar(m.colors(), m.searchInput))
 	} else if m.searchQuery != "" {
-		headerParts = append(headerParts, renderSearchSummary(m.searchQuery))
+		headerParts = append(headerParts, renderSearchSummary(m.colors(), m.searchQuery))
+	}
+	if m.composer != nil {
+		headerParts = append(headerParts, m.renderComposer(targetWidth))
 	}
 
 	m.setActiveTab(m.activeTab)
@@ -55,7 +61,7 @@ func (m *Model) View() tea.View {
 	availableHeight := max(0, targetHeight-headerHeight-m.footerHeight-separatorHeight-gridSpacing)
 	gridContent := m.renderSessionPreviews(headerHeight)
 	if gridContent == "" {
-		gridContent = emptyStateView(targetWidth, availableHeight)
+		gridContent = emptyStateView(m.colors(), targetWidth, availableHeight)
 	} else {
 		gridContent = clampHeight(gridContent, availableHeight)
 		gridContent = placeGridContent(gridContent, targetWidth, availableHeight)
@@ -90,15 +96,35 @@ func (m *Model) View() tea.View {
 	}
 
 	if m.paletteOpen {
-		palette := m.renderCommandPalette()
-		paletteWidth := lipgloss.Width(palette)
-		paletteHeight := countLines(palette)
-		width := max(m.width, max(lipgloss.Width(view), paletteWidth))
-		height := max(m.height, max(countLines(view), paletteHeight))
-		offsetX := max((width-paletteWidth)/2, 0)
-		offsetY := max((height-paletteHeight)/2, 0)
+		view = m.overlayCentered(view, m.renderCommandPalette())
+	}
+
+	if m.timelineOpen {
+		panel := m.renderTimeline(max(m.width-2, 40))
+		height := max(m.height, countLines(view))
+		view = m.overlayAt(view, panel, 0, max(height-countLines(panel)-m.footerHeight, 0))
+	}
+
+	if m.archiveOpen {
+		panel := m.renderArchive(max(m.width-2, 40))
+		height := max(m.height, countLines(view))
+		view = m.overlayAt(view, panel, 0, max((height-countLines(panel))/2, 0))
+	}
+
+	if m.hiddenOpen {
+		view = m.overlayCentered(view, m.renderHiddenManager(max(m.width-4, 40)))
+	}
 
-		view = overlayView(view, palette, width, height, offsetX, offsetY)
+	if m.cardMenu != nil {
+		view = m.overlayAt(view, m.renderCardMenu(), m.cardMenu.x, m.cardMenu.y)
+	}
+
+	if m.prompt != nil {
+		view = m.overlayCentered(view, m.renderPrompt(m.width))
+	}
+
+	if m.dialog != nil {
+		view = m.overlayCentered(view, m.renderDialog(m.width))
 	}
 
 	content := tea.NewView(zone.Scan(view))
@@ -107,6 +133,27 @@ func (m *Model) View() tea.View {
 	return content
 }
 
+// overlayCentered draws box centred over base.
+func (m *Model) overlayCentered(base, box string) string {
+	return m.overlayAt(base, box, -1, -1)
+}
+
+// overlayAt draws box over base with its top-left corner at x, y, kept on
+// screen, or centred when x is negative. The canvas grows to fit both.
+func (m *Model) overlayAt(base, box string, x, y int) string {
+	boxWidth := lipgloss.Width(box)
+	boxHeight := countLines(box)
+	width := max(m.width, max(lipgloss.Width(base), boxWidth))
+	height := max(m.height, max(countLines(base), boxHeight))
+	offsetX := max((width-boxWidth)/2, 0)
+	offsetY := max((height-boxHeight)/2, 0)
+	if x >= 0 {
+		offsetX = max(min(x, width-boxWidth), 0)
+		offsetY = max(min(y, height-boxHeight), 0)
+	}
+	return overlayView(base, box, width, height, offsetX, offsetY)
+}
+
 func clampHeight(content string, limit int) string {
 	if limit <= 0 || content == "" {
 		return ""
@@ -133,7 +180,7 @@ func clampHeight(content string, limit int) string {
 	return content[:consumed]
 }
 
-func emptyStateView(width, height int) string {
+func emptyStateView(th theme, width, height int) string {
 	if width <= 0 {
 		width = 40
 	}
@@ -142,9 +189,9 @@ func emptyStateView(width, height int) string {
 	box := lipgloss.JoinVertical(lipgloss.Left, message, helper)
 	styled := lipgloss.NewStyle().
 		Border(lipgloss.NormalBorder()).
-		BorderForeground(lipgloss.Color(borderColorCursor)).
+		BorderForeground(lipgloss.Color(th.borderCursor)).
 		Padding(1, 2).
-		Foreground(lipgloss.Color("252")).
+		Foreground(lipgloss.Color(th.emptyText)).
 		Render(box)
 
 	maxWidth := max(width, lipgloss.Width(styled))
@@ -38,7 +38,7 @@ func TestClampHeight(t *testing.T) {
 func TestEmptyStateView(t *testing.T) {
 	t.Parallel()
 
-	view := emptyStateView(60, 10)
+	view := emptyStateView(themeDefault, 60, 10)
 	if !strings.Contains(view, "No tmux sessions detected.") {
 		t.Fatalf("empty state missing headline: %q", view)
 	}
@@ -65,3 +65,26 @@ func TestPlaceGridContent(t *testing.T) {
 		t.Fatalf("expected empty string for zero height, got %q", got)
 	}
 }
+
+func TestOverlayAt(t *testing.T) {
+	t.Parallel()
+
+	base := strings.Repeat(strings.Repeat(".", 6)+"\n", 3) + strings.Repeat(".", 6)
+	cases := map[string]struct {
+		x, y int
+		want string
+	}{
+		"centred":        {x: -1, y: -1, want: "......\n..##..\n......\n......"},
+		"at pointer":     {x: 1, y: 0, want: ".##...\n......\n......\n......"},
+		"kept on screen": {x: 9, y: 9, want: "......\n......\n......\n....##"},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			m := &Model{width: 6, height: 4}
+			if got := m.overlayAt(base, "##", tc.x, tc.y); got != tc.want {
+				t.Fatalf("overlayAt = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
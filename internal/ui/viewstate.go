@@ -0,0 +1,155 @@
+// File viewstate.go saves the hidden, collapsed, focused, tab, and search
+// state on exit and restores it once a saved session is live again. Sessions
+// are matched by name, with creation time breaking ties, because tmux
+// reassigns $ids whenever the server restarts.
+package ui
+
+import (
+	"github.com/steipete/tmuxwatch/internal/store"
+	"github.com/steipete/tmuxwatch/internal/tmux"
+)
+
+// sessionRef identifies a session in the saved view.
+func sessionRef(session tmux.Session) store.SessionRef {
+	return store.SessionRef{Name: session.Name, Created: session.CreatedAt}
+}
+
+// matchSessionRef finds the session a saved reference points at. When
+// several sessions share the name, the one created at the saved time wins.
+func (m *Model) matchSessionRef(ref store.SessionRef) (string, bool) {
+	if ref.Name == "" {
+		return "", false
+	}
+	var matches []tmux.Session
+	for _, session := range m.sessions {
+		if session.Name == ref.Name {
+			matches = append(matches, session)
+		}
+	}
+	switch len(matches) {
+	case 0:
+		return "", false
+	case 1:
+		return matches[0].ID, true
+	}
+	for _, session := range matches {
+		if session.CreatedAt.Equal(ref.Created) {
+			return session.ID, true
+		}
+	}
+	return matches[0].ID, true
+}
+
+// currentView captures the view for the state file. Only sessions in the
+// snapshot are recorded, so entries for closed sessions drop out. Until the
+// saved view has been applied it is passed through unchanged.
+func (m *Model) currentView() store.View {
+	if m.pendingView != nil {
+		return *m.pendingView
+	}
+	var view store.View
+	for _, session := range m.sessions {
+		_, hidden := m.hidden[session.ID]
+		_, revealed := m.revealed[session.ID]
+		collapsed := m.isCollapsed(session.ID)
+		if hidden || revealed || collapsed {
+			view.Sessions = append(view.Sessions, store.SessionView{
+				SessionRef: sessionRef(session),
+				Hidden:     hidden,
+				Revealed:   revealed,
+				Collapsed:  collapsed,
+			})
+		}
+	}
+	ref := func(id string) store.SessionRef {
+		if session, ok := m.sessionByID(id); ok {
+			return sessionRef(session)
+		}
+		return store.SessionRef{}
+	}
+	view.Focused = ref(m.focusedSession)
+	view.Cursor = ref(m.cursorSession)
+	if m.viewMode == viewModeDetail {
+		view.Detail = ref(m.detailSession)
+	}
+	view.Search = m.searchQuery
+	return view
+}
+
+// applyPendingView restores the saved view once a snapshot contains one of
+// its sessions. Until then, e.g. while the tmux server is still starting, the
+// view stays pending so an early exit does not overwrite it.
+func (m *Model) applyPendingView() {
+	view := m.pendingView
+	if view == nil || !m.pendingViewReady(view) {
+		return
+	}
+	m.pendingView = nil
+	for _, saved := range view.Sessions {
+		id, ok := m.matchSessionRef(saved.SessionRef)
+		if !ok {
+			continue
+		}
+		if saved.Hidden {
+			if m.hidden == nil {
+				m.hidden = make(map[string]struct{})
+			}
+			m.hidden[id] = struct{}{}
+		}
+		if saved.Revealed {
+			if m.revealed == nil {
+				m.revealed = make(map[string]struct{})
+			}
+			m.revealed[id] = struct{}{}
+		}
+		if saved.Collapsed && !m.isCollapsed(id) {
+			if m.collapsed == nil {
+				m.collapsed = make(map[string]struct{})
+			}
+			m.collapsed[id] = struct{}{}
+		}
+	}
+	if view.Search != "" {
+		m.searchQuery = view.Search
+		m.searchInput.SetValue(view.Search)
+	}
+	if id, ok := m.matchSessionRef(view.Detail); ok && !m.isHidden(id) {
+		m.enterDetail(id)
+	}
+	if id, ok := m.matchSessionRef(view.Focused); ok && !m.isHidden(id) {
+		m.focusedSession = id
+	}
+	if id, ok := m.matchSessionRef(view.Cursor); ok && !m.isHidden(id) {
+		m.cursorSession = id
+	}
+}
+
+// pendingViewReady reports whether a saved session reference matches a live
+// session. A view without references waits only for the first session.
+func (m *Model) pendingViewReady(view *store.View) bool {
+	refs := []store.SessionRef{view.Focused, view.Cursor, view.Detail}
+	for _, saved := range view.Sessions {
+		refs = append(refs, saved.SessionRef)
+	}
+	named := false
+	for _, ref := range refs {
+		if ref.Name == "" {
+			continue
+		}
+		named = true
+		if _, ok := m.matchSessionRef(ref); ok {
+			return true
+		}
+	}
+	return !named && len(m.sessions) > 0
+}
+
+// SaveState writes the UI state, including the current view, to the state
+// file. It is called once tmuxwatch exits and is a no-op without a state
+// path.
+func (m *Model) SaveState() error {
+	if m.statePath == "" {
+		return nil
+	}
+	return store.Save(m.statePath, m.persistedState())
+}
@@ -0,0 +1,115 @@
+// File viewstate_test.go covers saving and restoring the view state.
+package ui
+
+import (
+	"testing"
+	"time"
+
+	"github.com/steipete/tmuxwatch/internal/store"
+	"github.com/steipete/tmuxwatch/internal/tmux"
+)
+
+// TestViewStateSurvivesRestart saves the view against one tmux server and
+// restores it by name after the $ids change, dropping closed sessions.
+func TestViewStateSurvivesRestart(t *testing.T) {
+	t.Parallel()
+
+	created := time.Date(2026, 10, 18, 9, 0, 0, 0, time.UTC)
+	before := &Model{
+		sessions: []tmux.Session{
+			{ID: "$1", Name: "api", CreatedAt: created},
+			{ID: "$2", Name: "scratch"},
+			{ID: "$3", Name: "logs"},
+			{ID: "$4", Name: "gone"},
+		},
+		hidden:         map[string]struct{}{"$2": {}},
+		collapsed:      map[string]struct{}{"$3": {}, "$4": {}},
+		focusedSession: "$1",
+		cursorSession:  "$3",
+		detailSession:  "$1",
+		viewMode:       viewModeDetail,
+		searchQuery:    "a",
+	}
+	saved := before.persistedState()
+
+	after := &Model{}
+	after.restoreState(saved)
+	after.sessions = []tmux.Session{
+		{ID: "$7", Name: "logs"},
+		{ID: "$8", Name: "scratch"},
+		{ID: "$9", Name: "api", CreatedAt: created},
+	}
+	after.applyPendingView()
+
+	if !after.isHidden("$8") || !after.isCollapsed("$7") {
+		t.Fatalf("hidden = %v, collapsed = %v", after.hidden, after.collapsed)
+	}
+	if after.focusedSession != "$9" || after.cursorSession != "$7" || after.detailSession != "$9" || after.viewMode != viewModeDetail {
+		t.Fatalf("focus %s cursor %s detail %s mode %v", after.focusedSession, after.cursorSession, after.detailSession, after.viewMode)
+	}
+	if after.searchQuery != "a" {
+		t.Fatalf("search = %q", after.searchQuery)
+	}
+	for _, s := range after.currentView().Sessions {
+		if s.Name == "gone" {
+			t.Fatal("sessions that no longer exist should be pruned")
+		}
+	}
+}
+
+// TestMatchSessionRefPrefersCreationTime picks the session created at the
+// saved time when a name is reused.
+func TestMatchSessionRefPrefersCreationTime(t *testing.T) {
+	t.Parallel()
+
+	created := time.Date(2026, 10, 18, 9, 0, 0, 0, time.UTC)
+	m := &Model{sessions: []tmux.Session{
+		{ID: "$1", Name: "dev", CreatedAt: created.Add(time.Hour)},
+		{ID: "$2", Name: "dev", CreatedAt: created},
+	}}
+	tests := []struct {
+		ref  store.SessionRef
+		want string
+	}{
+		{ref: store.SessionRef{Name: "dev", Created: created}, want: "$2"},
+		{ref: store.SessionRef{Name: "dev"}, want: "$1"},
+		{ref: store.SessionRef{Name: "ops"}, want: ""},
+	}
+	for _, tc := range tests {
+		if got, _ := m.matchSessionRef(tc.ref); got != tc.want {
+			t.Fatalf("matchSessionRef(%+v) = %q, want %q", tc.ref, got, tc.want)
+		}
+	}
+}
+
+// TestPendingViewPassesThrough keeps the saved view when tmuxwatch exits
+// before the first snapshot.
+func TestPendingViewPassesThrough(t *testing.T) {
+	t.Parallel()
+
+	m := &Model{}
+	view := store.View{Search: "db", Sessions: []store.SessionView{{SessionRef: store.SessionRef{Name: "db"}, Collapsed: true}}}
+	m.restoreState(store.State{View: view})
+	if got := m.currentView(); got.Search != "db" || len(got.Sessions) != 1 {
+		t.Fatalf("currentView = %+v, want the saved view", got)
+	}
+}
+
+// TestPendingViewWaitsForSavedSessions keeps the saved view through an empty
+// snapshot, such as a tmux server that is still starting, and applies it once
+// a saved session appears.
+func TestPendingViewWaitsForSavedSessions(t *testing.T) {
+	t.Parallel()
+
+	m := &Model{}
+	view := store.View{Sessions: []store.SessionView{{SessionRef: store.SessionRef{Name: "db"}, Collapsed: true}}}
+	m.restoreState(store.State{View: view})
+	m.Update(snapshotMsg{})
+	if m.pendingView == nil || len(m.currentView().Sessions) != 1 {
+		t.Fatalf("an empty snapshot should keep the saved view, got %+v", m.currentView())
+	}
+	m.Update(snapshotMsg{snapshot: tmux.Snapshot{Sessions: []tmux.Session{{ID: "$4", Name: "db"}}}})
+	if m.pendingView != nil || !m.isCollapsed("$4") {
+		t.Fatal("the saved view should apply once db is live")
+	}
+}
@@ -0,0 +1,172 @@
+// File writer.go runs disk appends for the event log and activity history on
+// a background goroutine, in the order they were queued, so a slow disk never
+// stalls the update loop. Failures are collected per sink and picked up on
+// the next snapshot.
+package ui
+
+import "sync"
+
+// Sinks written through the background writer.
+const (
+	sinkEventLog = "event log"
+	sinkHistory  = "activity history"
+)
+
+// diskJob is one queued write.
+type diskJob struct {
+	sink  string
+	write func() error
+}
+
+// diskWriter runs queued writes one at a time. Once a sink fails its
+// remaining writes are skipped.
+type diskWriter struct {
+	mu       sync.Mutex
+	cond     *sync.Cond
+	queue    []diskJob
+	busy     bool
+	closed   bool
+	done     chan struct{}
+	failed   map[string]error
+	reported map[string]struct{}
+}
+
+// newDiskWriter starts a writer goroutine.
+func newDiskWriter() *diskWriter {
+	w := &diskWriter{done: make(chan struct{}), failed: make(map[string]error), reported: make(map[string]struct{})}
+	w.cond = sync.NewCond(&w.mu)
+	go w.run()
+	return w
+}
+
+// enqueue adds a write to the queue. Once the writer is closed the write runs
+// on the caller instead.
+func (w *diskWriter) enqueue(sink string, write func() error) {
+	w.mu.Lock()
+	if w.closed {
+		_, failed := w.failed[sink]
+		w.mu.Unlock()
+		if failed {
+			return
+		}
+		if err := write(); err != nil {
+			w.mu.Lock()
+			w.failed[sink] = err
+			w.mu.Unlock()
+		}
+		return
+	}
+	w.queue = append(w.queue, diskJob{sink: sink, write: write})
+	w.mu.Unlock()
+	w.cond.Broadcast()
+}
+
+// run drains the queue until the writer is closed.
+func (w *diskWriter) run() {
+	defer close(w.done)
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	for {
+		for len(w.queue) == 0 && !w.closed {
+			w.cond.Wait()
+		}
+		if len(w.queue) == 0 {
+			return
+		}
+		jobs := w.queue
+		w.queue = nil
+		w.busy = true
+		for _, job := range jobs {
+			if _, ok := w.failed[job.sink]; ok {
+				continue
+			}
+			w.mu.Unlock()
+			err := job.write()
+			w.mu.Lock()
+			if err != nil {
+				w.failed[job.sink] = err
+			}
+		}
+		w.busy = false
+		w.cond.Broadcast()
+	}
+}
+
+// flush waits until every queued write has finished.
+func (w *diskWriter) flush() {
+	w.mu.Lock()
+	for len(w.queue) > 0 || w.busy {
+		w.cond.Wait()
+	}
+	w.mu.Unlock()
+}
+
+// close runs the writes still queued and stops the writer goroutine.
+func (w *diskWriter) close() {
+	w.mu.Lock()
+	w.closed = true
+	w.mu.Unlock()
+	w.cond.Broadcast()
+	<-w.done
+}
+
+// failures returns sinks that failed since the last call.
+func (w *diskWriter) failures() map[string]error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	var out map[string]error
+	for sink, err := range w.failed {
+		if _, ok := w.reported[sink]; ok {
+			continue
+		}
+		w.reported[sink] = struct{}{}
+		if out == nil {
+			out = make(map[string]error)
+		}
+		out[sink] = err
+	}
+	return out
+}
+
+// writeInBackground queues a write, starting the writer on first use.
+func (m *Model) writeInBackground(sink string, write func() error) {
+	if m.writer == nil {
+		m.writer = newDiskWriter()
+	}
+	m.writer.enqueue(sink, write)
+}
+
+// checkWriteFailures drops sinks whose writes failed, with one warning each,
+// so the dashboard keeps running.
+func (m *Model) checkWriteFailures() {
+	if m.writer == nil {
+		return
+	}
+	for sink, err := range m.writer.failures() {
+		switch sink {
+		case sinkEventLog:
+			m.eventLog = nil
+			m.showToast("Event log disabled: " + err.Error())
+		case sinkHistory:
+			m.history = nil
+			m.showToast("Activity history disabled: " + err.Error())
+		}
+	}
+}
+
+// Flush writes a send-keys burst that is still open and waits for queued
+// event log and history writes. It is called once tmuxwatch exits.
+func (m *Model) Flush() {
+	m.flushKeys()
+	if m.writer != nil {
+		m.writer.flush()
+	}
+}
+
+// Close finishes queued event log and history writes and stops the
+// background writer. It is called once after Flush when tmuxwatch exits.
+func (m *Model) Close() {
+	if m.writer != nil {
+		m.writer.close()
+	}
+}
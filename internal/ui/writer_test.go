@@ -0,0 +1,73 @@
+// File writer_test.go covers the background writer for the event log and
+// activity history.
+package ui
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/steipete/tmuxwatch/internal/eventlog"
+)
+
+// TestDiskWriterOrderAndFailures runs writes in order, skips a sink after it
+// fails, and reports each failure once.
+func TestDiskWriterOrderAndFailures(t *testing.T) {
+	t.Parallel()
+
+	w := newDiskWriter()
+	var got []string
+	write := func(name string, err error) func() error {
+		return func() error {
+			got = append(got, name)
+			return err
+		}
+	}
+	w.enqueue(sinkHistory, write("h1", nil))
+	w.enqueue(sinkEventLog, write("e1", errors.New("disk full")))
+	w.enqueue(sinkHistory, write("h2", nil))
+	w.enqueue(sinkEventLog, write("e2", nil))
+	w.flush()
+	if want := []string{"h1", "e1", "h2"}; !slices.Equal(got, want) {
+		t.Fatalf("writes = %q, want %q", got, want)
+	}
+	if failures := w.failures(); len(failures) != 1 || failures[sinkEventLog] == nil {
+		t.Fatalf("failures = %v, want the event log", failures)
+	}
+	if failures := w.failures(); failures != nil {
+		t.Fatalf("failures = %v, want each reported once", failures)
+	}
+}
+
+// TestDiskWriterClose drains queued writes, stops the goroutine, and runs
+// later writes on the caller.
+func TestDiskWriterClose(t *testing.T) {
+	t.Parallel()
+
+	w := newDiskWriter()
+	var got []string
+	w.enqueue(sinkHistory, func() error { got = append(got, "queued"); return nil })
+	w.close()
+	select {
+	case <-w.done:
+	default:
+		t.Fatal("writer goroutine still running after close")
+	}
+	w.enqueue(sinkHistory, func() error { got = append(got, "late"); return nil })
+	if want := []string{"queued", "late"}; !slices.Equal(got, want) {
+		t.Fatalf("writes = %q, want %q", got, want)
+	}
+}
+
+// TestCheckWriteFailuresDropsSink disables a failed event log with a toast.
+func TestCheckWriteFailuresDropsSink(t *testing.T) {
+	t.Parallel()
+
+	m := &Model{eventLog: &eventlog.Writer{}, toast: &toastState{}}
+	m.writeInBackground(sinkEventLog, func() error { return errors.New("disk full") })
+	m.Flush()
+	m.checkWriteFailures()
+	if m.eventLog != nil || m.toast.text != "Event log disabled: disk full" {
+		t.Fatalf("event log = %v, toast = %q", m.eventLog, m.toast.text)
+	}
+}